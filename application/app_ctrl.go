@@ -5,13 +5,35 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/buildinfo"
+	"github.com/vladimirvivien/ktop/config"
 
 	"github.com/vladimirvivien/ktop/k8s"
 	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// certWarningPeriod is the remaining validity of the API server certificate below which its expiry is highlighted
+	certWarningPeriod = 30 * 24 * time.Hour
+	// authNoticeDuration is how long the re-authenticated notice is shown in the header
+	authNoticeDuration = 10 * time.Second
+	// metricsAgeInterval is the refresh interval of the metrics age shown in the header
+	metricsAgeInterval = 5 * time.Second
+	// metricsLagWarning and metricsLagError are the metrics ages highlighted as stale
+	metricsLagWarning = 2 * time.Minute
+	metricsLagError   = 5 * time.Minute
+	// eventRateWindow is the sliding window the Warning events rate is sampled over
+	eventRateWindow = time.Minute
+	// statusMessageDuration is how long a transient message is shown in the status bar
+	statusMessageDuration = 5 * time.Second
+	// drawCoalesceWindow is how long refresh requests are batched into a single screen draw
+	drawCoalesceWindow = 50 * time.Millisecond
 )
 
 type AppPage struct {
@@ -22,15 +44,34 @@ type AppPage struct {
 type Application struct {
 	namespace   string
 	k8sClient   *k8s.Client
+	config      *config.Config
+	state       *config.State
+	session     *config.ContextState // session state of the cluster context
+	readOnly    bool
+	compact     bool
+	colorMode   ui.ColorMode
+	resync      time.Duration
 	tviewApp    *tview.Application
 	pages       []AppPage
 	modals      []tview.Primitive
 	pageIdx     int
-	tabIdx      int
 	visibleView int
 	panel       *appPanel
+	header      string // header text, without the certificate expiry and metrics age
+	certInfo    string // API server certificate expiry shown in the header
+	metricsInfo string // age of the newest metrics sample shown in the header
+	errorsInfo  string // count of the resources with failed API calls shown in the header
+	authInfo    string // authentication expired, or re-authenticated, notice shown in the header
+	eventsInfo  string // Warning events spike banner shown in the header
+	alertInfo   string // page alert (i.e. nodes becoming NotReady) shown in the header
 	refreshQ    chan struct{}
 	stopCh      chan struct{}
+
+	// status bar state, updated from the page refresh goroutines
+	statusMu     sync.Mutex
+	pageStatus   string // status of the visible page (i.e. filter, sort, counts)
+	statusAlerts string // API errors and authentication notice
+	statusMsg    string // transient message
 }
 
 func New(k8sC *k8s.Client) *Application {
@@ -38,11 +79,15 @@ func New(k8sC *k8s.Client) *Application {
 	app := &Application{
 		k8sClient: k8sC,
 		namespace: k8sC.Namespace(),
+		config:    new(config.Config),
+		state:     new(config.State),
+		session:   new(config.ContextState),
 		tviewApp:  tapp,
 		panel:     newPanel(tapp),
 		refreshQ:  make(chan struct{}, 1),
+		resync:    k8s.DefaultResyncPeriod,
 		pageIdx:   -1,
-		tabIdx:    -1,
+		colorMode: ui.ColorModeTrue,
 	}
 	return app
 }
@@ -51,24 +96,276 @@ func (app *Application) GetK8sClient() *k8s.Client {
 	return app.k8sClient
 }
 
+// SetConfig sets the configuration used by the application pages
+func (app *Application) SetConfig(cfg *config.Config) {
+	if cfg != nil {
+		app.config = cfg
+	}
+}
+
+func (app *Application) Config() *config.Config {
+	return app.config
+}
+
+// SetState sets the view state restored by the application pages
+func (app *Application) SetState(state *config.State) {
+	if state != nil {
+		app.state = state
+	}
+}
+
+// State returns the view state; pages update it so it can be saved on exit
+func (app *Application) State() *config.State {
+	return app.state
+}
+
+// SetSession sets the session state, of the cluster context, restored by the application
+// pages and updated as they are used: the visible page is restored on start
+func (app *Application) SetSession(session *config.ContextState) {
+	if session != nil {
+		app.session = session
+	}
+}
+
+// Session returns the session state of the cluster context
+func (app *Application) Session() *config.ContextState {
+	return app.session
+}
+
+// SetCompact enables the compact display mode (shorter metrics cells, smaller header)
+func (app *Application) SetCompact(compact bool) {
+	app.compact = compact
+	app.panel.compact = compact
+}
+
+// SetColorMode sets the color mode of the terminal; without colors (ui.ColorModeNone),
+// the views are drawn in monochrome, and with the 16 or 256 colors, with the nearest
+// colors of the terminal palette
+func (app *Application) SetColorMode(mode ui.ColorMode) {
+	app.colorMode = mode
+}
+
+// Compact returns true when the compact display mode is enabled
+func (app *Application) Compact() bool {
+	return app.compact
+}
+
+// SetResync sets the resync period of the controller informers (0 disables resyncs)
+func (app *Application) SetResync(resync time.Duration) {
+	app.resync = resync
+}
+
+// Resync returns the resync period of the controller informers
+func (app *Application) Resync() time.Duration {
+	return app.resync
+}
+
+// SetReadOnly disables (or enables) the page actions that modify the cluster
+func (app *Application) SetReadOnly(readOnly bool) {
+	app.readOnly = readOnly
+}
+
+// ReadOnly returns true when actions that modify the cluster are disabled
+func (app *Application) ReadOnly() bool {
+	return app.readOnly
+}
+
 func (app *Application) AddPage(panel ui.PanelController) {
 	app.pages = append(app.pages, AppPage{Title: panel.GetTitle(), Panel: panel})
 }
 
+// ShowModal displays view on top of the current page until HideModal is called
 func (app *Application) ShowModal(view tview.Primitive) {
-	app.panel.showModalView(view)
+	app.panel.showModalView(view, "", nil)
+}
+
+// ShowNamedModal is ShowModal for a view, i.e. a drill-down, named in the breadcrumb bar
+// shown above it (i.e. pod/default/web-0), with the view key bindings shown in the hint line
+func (app *Application) ShowNamedModal(name string, view tview.Primitive, hints ...ui.KeyHint) {
+	app.panel.showModalView(view, name, hints)
+}
+
+// HideModal removes the modal view and restores focus to the previously focused view
+func (app *Application) HideModal() {
+	app.panel.hideModalView()
+}
+
+// ModalShown returns true if view is the modal view currently displayed
+func (app *Application) ModalShown(view tview.Primitive) bool {
+	return app.panel.isModalView(view)
+}
+
+// ShowMessage displays a message in a modal dialog dismissed with Enter or Esc
+func (app *Application) ShowMessage(msg string) {
+	modal := tview.NewModal().
+		SetText(msg).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			app.HideModal()
+		})
+	app.ShowModal(modal)
+}
+
+// SetStatus sets the page status (i.e. filter, sort, counts) shown in the status bar
+// after the cluster context and namespace
+func (app *Application) SetStatus(status string) {
+	app.statusMu.Lock()
+	app.pageStatus = status
+	app.statusMu.Unlock()
+	app.drawStatus()
+}
+
+// Notify shows msg at the end of the status bar for statusMessageDuration
+func (app *Application) Notify(msg string) {
+	app.statusMu.Lock()
+	app.statusMsg = msg
+	app.statusMu.Unlock()
+	app.drawStatus()
+
+	time.AfterFunc(statusMessageDuration, func() {
+		app.statusMu.Lock()
+		cleared := app.statusMsg == msg
+		if cleared {
+			app.statusMsg = ""
+		}
+		app.statusMu.Unlock()
+		if cleared {
+			app.QueueUpdateDraw(app.drawStatus)
+		}
+	})
+}
+
+// setStatusAlerts sets the alerts (i.e. API errors) shown in the status bar
+func (app *Application) setStatusAlerts(alerts string) {
+	app.statusMu.Lock()
+	app.statusAlerts = alerts
+	app.statusMu.Unlock()
+	app.drawStatus()
+}
+
+// drawStatus draws the status bar: the cluster context, namespace, page status, alerts, and transient message
+func (app *Application) drawStatus() {
+	namespace := app.k8sClient.Namespace()
+	if namespace == k8s.AllNamespaces {
+		namespace = "(all)"
+	}
+
+	app.statusMu.Lock()
+	defer app.statusMu.Unlock()
+	status := fmt.Sprintf(" [green]context: [white]%s  [green]namespace: [white]%s", tview.Escape(app.k8sClient.ClusterContext()), tview.Escape(namespace))
+	if app.pageStatus != "" {
+		status += "  " + app.pageStatus
+	}
+	status += app.statusAlerts
+	if app.statusMsg != "" {
+		status += "  [yellow]" + tview.Escape(app.statusMsg)
+	}
+	app.panel.DrawStatus(status)
 }
 
 func (app *Application) Focus(t tview.Primitive) {
 	app.tviewApp.SetFocus(t)
 }
 
+// ShowConfirm displays msg in a modal dialog with confirm and cancel buttons.
+// Function onConfirm is called, after the dialog is closed, when confirmLabel is selected.
+func (app *Application) ShowConfirm(msg, confirmLabel string, onConfirm func()) {
+	modal := tview.NewModal().
+		SetText(msg).
+		AddButtons([]string{confirmLabel, "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			app.HideModal()
+			if label == confirmLabel {
+				onConfirm()
+			}
+		})
+	modal.SetFocus(1) // default to cancel
+	app.ShowModal(modal)
+}
+
+// ShowMenu displays a centered list of items; onSelect is called, after the
+// menu is closed, with the index of the selected item.
+func (app *Application) ShowMenu(title string, items []string, onSelect func(index int)) {
+	list := tview.NewList().ShowSecondaryText(false)
+	width := len(title) + 4
+	for i, item := range items {
+		idx := i
+		list.AddItem(item, "", 0, func() {
+			app.HideModal()
+			onSelect(idx)
+		})
+		if len(item)+6 > width {
+			width = len(item) + 6
+		}
+	}
+	list.SetBorder(true)
+	list.SetTitle(" " + title + " ")
+	list.SetTitleAlign(tview.AlignLeft)
+	app.ShowModal(centered(list, width, len(items)+2))
+}
+
+// ShowInput displays a centered, single line input dialog, initialized with text;
+// onDone is called, after the dialog is closed, with the entered text when Enter is pressed.
+func (app *Application) ShowInput(title, label, text string, onDone func(text string)) {
+	app.ShowInputCompletion(title, label, text, nil, onDone)
+}
+
+// ShowInputCompletion is ShowInput with the completions of the text being entered, returned
+// by complete, listed below the input field. complete may be nil.
+func (app *Application) ShowInputCompletion(title, label, text string, complete func(text string) []string, onDone func(text string)) {
+	input := tview.NewInputField().SetLabel(label).SetText(text)
+	if complete != nil {
+		input.SetAutocompleteFunc(complete)
+	}
+	input.SetDoneFunc(func(key tcell.Key) {
+		app.HideModal()
+		if key == tcell.KeyEnter {
+			onDone(input.GetText())
+		}
+	})
+	input.SetBorder(true)
+	input.SetTitle(" " + title + " (Enter: apply, Esc: cancel) ")
+	input.SetTitleAlign(tview.AlignLeft)
+	app.ShowModal(centered(input, 80, 3))
+}
+
+// QueueUpdateDraw runs f on the UI goroutine then redraws the screen.
+// It must be used to update views from other goroutines.
+func (app *Application) QueueUpdateDraw(f func()) {
+	app.tviewApp.QueueUpdateDraw(f)
+}
+
+// Suspend suspends the terminal UI while f runs (i.e. to run an external editor)
+func (app *Application) Suspend(f func()) bool {
+	return app.tviewApp.Suspend(f)
+}
+
+// Refresh requests a screen draw; requests made while a draw is pending are coalesced into it
 func (app *Application) Refresh() {
-	app.refreshQ <- struct{}{}
+	select {
+	case app.refreshQ <- struct{}{}:
+	default: // a draw is already pending
+	}
+}
+
+// drawRefreshes draws the screen on refresh requests. The panels refreshed within
+// drawCoalesceWindow of a request are drawn along with it, in a single draw.
+func (app *Application) drawRefreshes() {
+	for range app.refreshQ {
+		time.Sleep(drawCoalesceWindow)
+		select {
+		case <-app.refreshQ: // requested during the window
+		default:
+		}
+		app.tviewApp.Draw()
+	}
 }
 
 func (app *Application) ShowPanel(i int) {
 	app.visibleView = i
+	if i < len(app.pages) {
+		app.session.Page = app.pages[i].Title
+	}
 }
 
 func (app *Application) GetStopChan() <-chan struct{} {
@@ -101,6 +398,8 @@ func (app *Application) setup(ctx context.Context) error {
 	hdr.WriteString("%c [green]API server: [white]%s [green]Version: [white]%s [green]context: [white]%s [green]User: [white]%s [green]namespace: [white]%s [green] metrics:")
 	if err := app.GetK8sClient().AssertMetricsAvailable(); err != nil {
 		hdr.WriteString(" [red]not connected")
+	} else if app.GetK8sClient().UsingKubeletStats() {
+		hdr.WriteString(" [white]kubelet stats")
 	} else {
 		hdr.WriteString(" [white]connected")
 	}
@@ -110,35 +409,62 @@ func (app *Application) setup(ctx context.Context) error {
 		namespace = "[orange](all)"
 	}
 	client := app.GetK8sClient()
-	app.panel.DrawHeader(fmt.Sprintf(
+	app.header = fmt.Sprintf(
 		hdr.String(),
 		ui.Icons.Rocket, client.RESTConfig().Host, client.GetServerVersion(), client.ClusterContext(), client.Username(), namespace,
-	))
+	)
+	if impersonation := client.Impersonation(); impersonation != "" {
+		app.header += fmt.Sprintf(" [orange]impersonating: [white]%s", tview.Escape(impersonation))
+	}
+	app.drawHeader()
+	app.drawStatus()
+	go app.showCertExpiry(ctx)
+	go app.showMetricsAge(ctx)
+	go app.showAPIErrors(ctx)
+	go app.showEventSpikes(ctx)
+	client.SetAuthStateFunc(app.showAuthState)
 
+	for i, page := range app.pages {
+		if page.Title == app.session.Page {
+			app.visibleView = i // page visible at the end of the previous session
+		}
+	}
 	app.panel.DrawFooter(app.getPageTitles()[app.visibleView])
+	app.Focus(app.pages[app.visibleView].Panel.GetRootView())
+	app.tviewApp.SetBeforeDrawFunc(func(tcell.Screen) bool {
+		app.panel.drawHints(app.pageHints())
+		return false
+	})
 
 	app.tviewApp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEsc {
+			if app.panel.hasModalView() {
+				app.HideModal()
+				return nil
+			}
 			app.Stop()
 		}
 
-		if event.Key() == tcell.KeyTAB {
-			views := app.pages[0].Panel.GetChildrenViews()
-			app.tabIdx++
-			app.Focus(views[app.tabIdx])
-			if app.tabIdx == len(views)-1 {
-				app.tabIdx = -1
+		if app.navigate(event) {
+			return nil
+		}
+
+		if event.Key() == tcell.KeyTAB || event.Key() == tcell.KeyBacktab {
+			if app.cycleFocus(event.Key() == tcell.KeyBacktab) {
+				return nil
 			}
 		}
 
 		if event.Key() < tcell.KeyF1 || event.Key() > tcell.KeyF12 {
-			return event
+			return app.dispatchPageInput(event)
 		}
 
 		keyPos := event.Key() - tcell.KeyF1
 		titles := app.getPageTitles()
 		if (keyPos >= 0 || keyPos <= 9) && (int(keyPos) <= len(titles)-1) {
+			app.ShowPanel(int(keyPos))
 			app.panel.switchToPage(app.getPageTitles()[keyPos])
+			app.Focus(app.pages[keyPos].Panel.GetRootView())
 		}
 
 		return event
@@ -147,6 +473,158 @@ func (app *Application) setup(ctx context.Context) error {
 	return nil
 }
 
+// showCertExpiry adds the API server certificate expiry date to the header,
+// in orange when it expires within certWarningPeriod and in red once expired.
+func (app *Application) showCertExpiry(ctx context.Context) {
+	expiry, err := app.GetK8sClient().ServerCertExpiry(ctx)
+	if err != nil {
+		return // not shown, i.e. the API server is not served over TLS
+	}
+	color := "white"
+	switch remaining := time.Until(expiry); {
+	case remaining <= 0:
+		color = "red"
+	case remaining < certWarningPeriod:
+		color = "orange"
+	}
+	text := fmt.Sprintf(" [green]cert expiry: [%s]%s", color, expiry.Format("2006-01-02"))
+	app.QueueUpdateDraw(func() {
+		app.certInfo = text
+		app.drawHeader()
+	})
+}
+
+// showMetricsAge periodically shows, in the header, the age of the newest metrics sample:
+// in orange when older than metricsLagWarning, and in red when older than metricsLagError.
+func (app *Application) showMetricsAge(ctx context.Context) {
+	ticker := time.NewTicker(metricsAgeInterval)
+	defer ticker.Stop()
+	for {
+		var text string
+		if newest, err := app.GetK8sClient().Controller().GetNewestMetricsTime(ctx); err == nil {
+			age := time.Since(newest).Round(time.Second)
+			color := "white"
+			switch {
+			case age > metricsLagError:
+				color = "red"
+			case age > metricsLagWarning:
+				color = "orange"
+			}
+			text = fmt.Sprintf(" [green]metrics age: [%s]%s", color, age)
+		}
+		app.QueueUpdateDraw(func() {
+			app.metricsInfo = text
+			app.drawHeader()
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// showAPIErrors periodically shows, in the header, the number of resources with recently failed
+// API calls (i.e. forbidden), so that panels missing data are not silently empty.
+func (app *Application) showAPIErrors(ctx context.Context) {
+	ticker := time.NewTicker(metricsAgeInterval)
+	defer ticker.Stop()
+	for {
+		var text string
+		if apiErrors := app.GetK8sClient().Controller().GetAPIErrors(); len(apiErrors) > 0 {
+			text = fmt.Sprintf(" [red]%c %d API errors (!)", ui.Icons.Warning, len(apiErrors))
+		}
+		app.QueueUpdateDraw(func() {
+			app.errorsInfo = text
+			app.drawHeader()
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// showEventSpikes periodically samples the rate of Warning events and shows, in the header, a
+// banner with the top reasons while the rate spikes above its baseline.
+func (app *Application) showEventSpikes(ctx context.Context) {
+	ticker := time.NewTicker(metricsAgeInterval)
+	defer ticker.Stop()
+	var tracker model.EventRateTracker
+	for {
+		var text string
+		if reasons, err := app.GetK8sClient().Controller().GetWarningEventReasons(ctx, time.Now().Add(-eventRateWindow)); err == nil {
+			if rate := tracker.Observe(reasons); rate.Spike {
+				text = fmt.Sprintf(
+					" [red]%c %d warning events/min (baseline %.0f): %s",
+					ui.Icons.Warning, rate.Warnings, rate.Baseline, tview.Escape(strings.Join(rate.TopReasons, ", ")),
+				)
+			}
+		}
+		app.QueueUpdateDraw(func() {
+			app.eventsInfo = text
+			app.drawHeader()
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SetAlert shows the alert of a page (i.e. nodes becoming NotReady) in the header and
+// status bar, an empty alert clears it
+func (app *Application) SetAlert(alert string) {
+	text := ""
+	if alert != "" {
+		text = fmt.Sprintf(" [red]%c %s", ui.Icons.Warning, tview.Escape(alert))
+	}
+	app.QueueUpdateDraw(func() {
+		if app.alertInfo != text {
+			app.alertInfo = text
+			app.drawHeader()
+		}
+	})
+}
+
+// showAuthState shows, in the header, that the credentials expired until requests are
+// authenticated again, then briefly shows a re-authenticated notice.
+func (app *Application) showAuthState(authenticated bool) {
+	text := " [red]authentication expired"
+	if authenticated {
+		text = " [green]re-authenticated"
+	}
+	app.QueueUpdateDraw(func() {
+		app.authInfo = text
+		app.drawHeader()
+	})
+	if !authenticated {
+		return
+	}
+	time.AfterFunc(authNoticeDuration, func() {
+		app.QueueUpdateDraw(func() {
+			if app.authInfo == text {
+				app.authInfo = ""
+				app.drawHeader()
+			}
+		})
+	})
+}
+
+// drawHeader draws the header text followed by the metrics age, certificate expiry, API errors,
+// page alert, events spike, and authentication notice, when known. The API errors, page alert,
+// events spike, and authentication notice are also shown in the status bar.
+func (app *Application) drawHeader() {
+	alerts := app.errorsInfo + app.alertInfo + app.eventsInfo + app.authInfo
+	app.panel.DrawHeader(app.header + app.metricsInfo + app.certInfo + alerts)
+	app.setStatusAlerts(alerts)
+}
+
 func (app *Application) Run(ctx context.Context) error {
 
 	// setup application UI
@@ -154,12 +632,23 @@ func (app *Application) Run(ctx context.Context) error {
 		return err
 	}
 
-	// setup refresh queue
-	go func() {
-		for range app.refreshQ {
-			app.tviewApp.Draw()
+	switch app.colorMode {
+	case ui.ColorModeNone:
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return err
+		}
+		app.tviewApp.SetScreen(ui.MonochromeScreen(screen))
+	case ui.ColorMode16, ui.ColorMode256:
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return err
 		}
-	}()
+		app.tviewApp.SetScreen(ui.PaletteScreen(screen, app.colorMode))
+	}
+
+	// setup refresh queue
+	go app.drawRefreshes()
 
 	return app.tviewApp.Run()
 }
@@ -173,6 +662,98 @@ func (app *Application) Stop() error {
 	return nil
 }
 
+// cycleFocus moves the focus to the next, or previous when backward, panel of the visible
+// page. It returns false, leaving the focus unchanged, when a modal is shown or a form
+// item is being edited.
+func (app *Application) cycleFocus(backward bool) bool {
+	if app.panel.hasModalView() || app.visibleView >= len(app.pages) {
+		return false
+	}
+	if app.editing() {
+		return false
+	}
+	views := app.pages[app.visibleView].Panel.GetChildrenViews()
+	if len(views) == 0 {
+		return false
+	}
+
+	// the focused panel, or -1 when no panel has the focus
+	current := -1
+	for i, view := range views {
+		if view.HasFocus() {
+			current = i
+			break
+		}
+	}
+	next := (current + 1) % len(views)
+	if backward {
+		next = (current - 1 + len(views)) % len(views)
+		if current < 0 {
+			next = len(views) - 1
+		}
+	}
+	app.Focus(views[next])
+	return true
+}
+
+// editing returns true when the focused view is a form item (i.e. an input field, a drop-down,
+// or a checkbox), which gets the editing keys, like Backspace, rather than the application
+func (app *Application) editing() bool {
+	_, ok := app.tviewApp.GetFocus().(tview.FormItem)
+	return ok
+}
+
+// navigate goes back, with Backspace or Alt+Left, or forward, with Alt+Right, in the navigation
+// history of the modal views (drill-downs). It returns false, leaving the event to the views,
+// when there is no view to navigate to or a form item is being edited.
+func (app *Application) navigate(event *tcell.EventKey) bool {
+	if !app.panel.hasModalView() {
+		return false
+	}
+	if app.editing() {
+		return false
+	}
+	alt := event.Modifiers()&tcell.ModAlt != 0
+	switch {
+	case event.Key() == tcell.KeyBackspace, event.Key() == tcell.KeyBackspace2, event.Key() == tcell.KeyLeft && alt:
+		return app.panel.navigateModal(false)
+	case event.Key() == tcell.KeyRight && alt:
+		return app.panel.navigateModal(true)
+	}
+	return false
+}
+
+// dispatchPageInput passes key events to the visible page, unless a modal
+// is shown or a form item is being edited.
+func (app *Application) dispatchPageInput(event *tcell.EventKey) *tcell.EventKey {
+	if app.panel.hasModalView() || app.visibleView >= len(app.pages) {
+		return event
+	}
+	if app.editing() {
+		return event
+	}
+	if handler, ok := app.pages[app.visibleView].Panel.(ui.InputHandler); ok {
+		return handler.HandleInput(event)
+	}
+	return event
+}
+
+// pageHints returns the key bindings, of the focused view, of the visible page, followed by
+// the application key bindings
+func (app *Application) pageHints() []ui.KeyHint {
+	var hints []ui.KeyHint
+	if app.visibleView < len(app.pages) {
+		if hinter, ok := app.pages[app.visibleView].Panel.(ui.KeyHinter); ok {
+			hints = append(hints, hinter.KeyHints()...)
+		}
+	}
+	hints = append(hints, ui.KeyHint{Key: "Tab", Name: "next panel"})
+	if len(app.pages) > 1 {
+		hints = append(hints, ui.KeyHint{Key: fmt.Sprintf("F1-F%d", len(app.pages)), Name: "pages"})
+	}
+	return append(hints, ui.KeyHint{Key: "Esc", Name: "quit"})
+}
+
 func (app *Application) getPageTitles() (titles []string) {
 	for _, page := range app.pages {
 		titles = append(titles, page.Title)
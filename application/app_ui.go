@@ -7,8 +7,11 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/buildinfo"
+	"github.com/vladimirvivien/ktop/ui"
 )
 
+const modalPageName = "ktop-modal"
+
 var (
 	buttonUnselectedBgColor = tcell.ColorPaleGreen
 	buttonUnselectedFgColor = tcell.ColorDarkBlue
@@ -22,12 +25,37 @@ type appPanel struct {
 	header   *tview.Table
 	pages    *tview.Pages
 	footer   *tview.Table
+	status   *tview.TextView // status bar, shown below the pages
+	hints    *tview.TextView // key hint line of the focused view, shown below the status bar
 	modals   []tview.Primitive
 	root     *tview.Flex
+	// view focused before a modal is shown
+	modalPrevFocus tview.Primitive
+	// modal view shown, with the modal views shown before it (back), and gone back from
+	// (forward), for the navigation history
+	modal   modalEntry
+	back    []modalEntry
+	forward []modalEntry
+	// breadcrumb bar, shown above the pages while a modal view is shown, and visible page title
+	crumbs *tview.TextView
+	page   string
+	// compact mode draws a single line, borderless, header
+	compact bool
+}
+
+// modalEntry is a modal view, with its name shown in the breadcrumb bar (empty for dialogs),
+// and its key bindings shown in the hint line
+type modalEntry struct {
+	view  tview.Primitive
+	name  string
+	hints []ui.KeyHint
 }
 
 func newPanel(app *tview.Application) *appPanel {
 	p := &appPanel{title: "ktop", tviewApp: app}
+	p.status = tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	p.crumbs = tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	p.hints = tview.NewTextView().SetDynamicColors(true).SetWrap(false)
 	return p
 }
 
@@ -45,13 +73,11 @@ func (p *appPanel) Layout(data interface{}) {
 	p.footer = tview.NewTable()
 	p.footer.SetBorder(true)
 
-	root := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(p.header, 3, 1, false). // header
-		AddItem(p.pages, 0, 1, true)   // body
-		// TODO show footer when multi-page is implemented
-		//AddItem(p.footer, 3, 1, false)  // footer
-	p.root = root
-	p.tviewApp.SetRoot(root, true)
+	headerHeight := 3
+	if p.compact {
+		p.header.SetBorder(false)
+		headerHeight = 1
+	}
 
 	// add pages
 	pages, ok := data.([]AppPage)
@@ -59,6 +85,20 @@ func (p *appPanel) Layout(data interface{}) {
 		panic(fmt.Sprintf("application.Layout got unexpected data type: %T", data))
 	}
 
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(p.header, headerHeight, 1, false). // header
+		AddItem(p.crumbs, 0, 0, false).            // breadcrumb bar, shown with modal views
+		AddItem(p.pages, 0, 1, true).              // body
+		AddItem(p.status, 1, 0, false)             // status bar
+	if !p.compact {
+		root.AddItem(p.hints, 1, 0, false) // key hint line
+	}
+	if len(pages) > 1 {
+		root.AddItem(p.footer, 3, 1, false) // footer, page buttons
+	}
+	p.root = root
+	p.tviewApp.SetRoot(root, true)
+
 	// setup page and page buttons in footer
 	for i, page := range pages {
 		p.pages.AddPage(page.Title, page.Panel.GetRootView(), true, false)
@@ -99,6 +139,32 @@ func (p *appPanel) DrawHeader(data interface{}) {
 
 func (p *appPanel) DrawBody(data interface{}) {}
 
+// DrawStatus sets the status bar text
+func (p *appPanel) DrawStatus(status string) {
+	p.status.SetText(status)
+}
+
+// drawHints draws the key hint line: the key bindings of the modal view, with the navigation
+// history keys, or of the page (pageHints) when no modal view is shown
+func (p *appPanel) drawHints(pageHints []ui.KeyHint) {
+	hints := pageHints
+	if p.hasModalView() {
+		hints = append([]ui.KeyHint{}, p.modal.hints...)
+		if len(p.back) > 0 {
+			hints = append(hints, ui.KeyHint{Key: "Backspace", Name: "back"})
+		}
+		if len(p.forward) > 0 {
+			hints = append(hints, ui.KeyHint{Key: "Alt+Right", Name: "forward"})
+		}
+		hints = append(hints, ui.KeyHint{Key: "Esc", Name: "close"})
+	}
+	var text strings.Builder
+	for _, hint := range hints {
+		fmt.Fprintf(&text, " [yellow]%s[white] %s ", tview.Escape(hint.Key), tview.Escape(hint.Name))
+	}
+	p.hints.SetText(text.String())
+}
+
 func (p *appPanel) DrawFooter(data interface{}) {
 	title, ok := data.(string)
 	if !ok {
@@ -134,8 +200,105 @@ func (p *appPanel) switchToPage(title string) {
 		}
 	}
 	p.pages.SwitchToPage(title)
+	p.page = title
+}
+
+func (p *appPanel) showModalView(t tview.Primitive, name string, hints []ui.KeyHint) {
+	if !p.hasModalView() {
+		p.modalPrevFocus = p.tviewApp.GetFocus()
+	} else if p.modal.view != nil && p.modal.view != t {
+		// a drill-down from the modal: the modal is kept in the navigation history
+		p.back = append(p.back, p.modal)
+		p.forward = nil
+	}
+	p.modal = modalEntry{view: t, name: name, hints: hints}
+	p.pages.AddPage(modalPageName, t, true, true)
+	p.tviewApp.SetFocus(t)
+	p.drawCrumbs()
+}
+
+func (p *appPanel) hideModalView() {
+	if !p.hasModalView() {
+		return
+	}
+	p.pages.RemovePage(modalPageName)
+	p.modal, p.back, p.forward = modalEntry{}, nil, nil
+	p.drawCrumbs()
+	if p.modalPrevFocus != nil {
+		p.tviewApp.SetFocus(p.modalPrevFocus)
+		p.modalPrevFocus = nil
+	}
 }
 
-func (p *appPanel) showModalView(t tview.Primitive) {
-	p.tviewApp.SetRoot(t, false)
-}
\ No newline at end of file
+// drawCrumbs draws the breadcrumb bar: the page title followed by the names of the modal views
+// of the navigation history, up to the shown one (i.e. Overview > pod/default/web-0 > YAML).
+// The bar is hidden without modal view.
+func (p *appPanel) drawCrumbs() {
+	if p.root == nil {
+		return
+	}
+	if !p.hasModalView() {
+		p.root.ResizeItem(p.crumbs, 0, 0)
+		return
+	}
+	crumbs := []string{p.page}
+	for _, entry := range append(append([]modalEntry{}, p.back...), p.modal) {
+		if entry.name != "" {
+			crumbs = append(crumbs, entry.name)
+		}
+	}
+	for i := range crumbs {
+		crumbs[i] = tview.Escape(crumbs[i])
+	}
+	crumbs[len(crumbs)-1] = "[white::b]" + crumbs[len(crumbs)-1]
+	hint := ""
+	if len(p.back) > 0 {
+		hint = "  [gray::-](Backspace: back)"
+	}
+	p.crumbs.SetText(" [green]" + strings.Join(crumbs, " [yellow]>[green] ") + hint)
+	p.root.ResizeItem(p.crumbs, 1, 0)
+}
+
+// navigateModal shows the previous modal view of the navigation history, or the next one when
+// forward is set. It returns false when there is no modal view to navigate to.
+func (p *appPanel) navigateModal(forward bool) bool {
+	from, to := &p.back, &p.forward
+	if forward {
+		from, to = &p.forward, &p.back
+	}
+	if !p.hasModalView() || len(*from) == 0 {
+		return false
+	}
+	view := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, p.modal)
+	p.modal = view
+	p.pages.AddPage(modalPageName, view.view, true, true)
+	p.tviewApp.SetFocus(view.view)
+	p.drawCrumbs()
+	return true
+}
+
+func (p *appPanel) hasModalView() bool {
+	return p.pages != nil && p.pages.HasPage(modalPageName)
+}
+
+// isModalView returns true if t is the modal view currently shown
+func (p *appPanel) isModalView(t tview.Primitive) bool {
+	if !p.hasModalView() {
+		return false
+	}
+	name, view := p.pages.GetFrontPage()
+	return name == modalPageName && view == t
+}
+
+// centered returns a layout that displays t at the center of the screen with the given size
+func centered(t tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(t, height, 0, true).
+			AddItem(nil, 0, 1, false), width, 0, true).
+		AddItem(nil, 0, 1, false)
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/k8s"
+)
+
+func TestNavigateModal(t *testing.T) {
+	p := newPanel(tview.NewApplication())
+	p.Layout([]AppPage{})
+	p.page = "Overview"
+	pod, yaml, events := tview.NewTextView(), tview.NewTextView(), tview.NewTextView()
+
+	if p.navigateModal(false) {
+		t.Fatal("expecting no navigation without modal view")
+	}
+	p.showModalView(pod, "pod/default/web-0", nil)
+	p.showModalView(yaml, "YAML", nil)
+	p.showModalView(events, "Events", nil)
+
+	steps := []struct {
+		name    string
+		forward bool
+		ok      bool
+		shown   tview.Primitive
+		crumbs  string
+	}{
+		{name: "back", ok: true, shown: yaml, crumbs: "Overview > pod/default/web-0 > YAML  (Backspace: back)"},
+		{name: "back to the first view", ok: true, shown: pod, crumbs: "Overview > pod/default/web-0"},
+		{name: "back from the first view", shown: pod, crumbs: "Overview > pod/default/web-0"},
+		{name: "forward", forward: true, ok: true, shown: yaml, crumbs: "Overview > pod/default/web-0 > YAML  (Backspace: back)"},
+		{name: "forward to the last view", forward: true, ok: true, shown: events, crumbs: "Overview > pod/default/web-0 > YAML > Events  (Backspace: back)"},
+		{name: "forward from the last view", forward: true, shown: events, crumbs: "Overview > pod/default/web-0 > YAML > Events  (Backspace: back)"},
+	}
+	for _, step := range steps {
+		t.Logf("running step %s", step.name)
+		if ok := p.navigateModal(step.forward); ok != step.ok {
+			t.Fatalf("expecting navigation %t, got %t", step.ok, ok)
+		}
+		if !p.isModalView(step.shown) || p.tviewApp.GetFocus() != step.shown {
+			t.Fatalf("expecting the %s view shown and focused", p.modal.name)
+		}
+		if crumbs := strings.TrimSpace(p.crumbs.GetText(true)); crumbs != step.crumbs {
+			t.Errorf("expecting crumbs %q, got %q", step.crumbs, crumbs)
+		}
+	}
+
+	// a drill-down after going back drops the views gone back from
+	p.navigateModal(false)
+	p.showModalView(tview.NewTextView(), "Logs", nil)
+	if len(p.forward) != 0 || len(p.back) != 2 {
+		t.Errorf("expecting 2 views back and none forward, got %d and %d", len(p.back), len(p.forward))
+	}
+
+	p.hideModalView()
+	if p.hasModalView() || len(p.back) != 0 || p.navigateModal(false) {
+		t.Error("expecting the navigation history cleared with the modal view")
+	}
+}
+
+func TestNavigateEditing(t *testing.T) {
+	app := New(&k8s.Client{})
+	app.panel.Layout([]AppPage{})
+	app.panel.showModalView(tview.NewTextView(), "pod/default/web-0", nil)
+	app.panel.showModalView(tview.NewTextView(), "YAML", nil)
+	backspace := tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+
+	tests := []struct {
+		name     string
+		focus    tview.Primitive
+		expected bool
+	}{
+		{name: "input field", focus: tview.NewInputField()},
+		{name: "drop-down", focus: tview.NewDropDown()},
+		{name: "text view", focus: tview.NewTextView(), expected: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			app.tviewApp.SetFocus(tc.focus)
+			if navigated := app.navigate(backspace); navigated != tc.expected {
+				t.Errorf("expecting navigation %t, got %t", tc.expected, navigated)
+			}
+		})
+	}
+}
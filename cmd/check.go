@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/vladimirvivien/ktop/k8s"
+)
+
+// newCheckCmd returns the check subcommand, which reports whether the cluster
+// context gives ktop the access it needs (connectivity, RBAC, metrics).
+func newCheckCmd(o *ktopCmdOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:          "check",
+		Short:        "Checks the API server connectivity, RBAC authorizations, and metrics-server availability needed by ktop",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runCheck(c)
+		},
+	}
+}
+
+func (o *ktopCmdOptions) runCheck(c *cobra.Command) error {
+	if err := o.setupConnection(); err != nil {
+		return err
+	}
+	if o.allNamespaces {
+		*o.kubeFlags.Namespace = k8s.AllNamespaces
+	}
+
+	out := c.OutOrStdout()
+	k8sC, err := k8s.New(o.kubeFlags)
+	if err != nil {
+		printCheck(out, k8s.CheckResult{Name: "API server", Detail: err.Error()})
+		return fmt.Errorf("ktop check: cannot reach the API server")
+	}
+
+	namespace := k8sC.Namespace()
+	if namespace == k8s.AllNamespaces {
+		namespace = "(all)"
+	}
+	fmt.Fprintf(out, "Context: %s, user: %s, namespace: %s\n\n", k8sC.ClusterContext(), k8sC.Username(), namespace)
+
+	failed := 0
+	for _, result := range k8sC.Check(context.Background()) {
+		printCheck(out, result)
+		if !result.OK && !result.Optional {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("ktop check: %d required check(s) failed", failed)
+	}
+	return nil
+}
+
+// printCheck prints a check result as a report line: [ OK ], [WARN] for a failed optional check, or [FAIL]
+func printCheck(out io.Writer, result k8s.CheckResult) {
+	status := "[ OK ]"
+	switch {
+	case result.OK:
+	case result.Optional:
+		status = "[WARN]"
+	default:
+		status = "[FAIL]"
+	}
+	fmt.Fprintf(out, "%s %-24s %s\n", status, result.Name, result.Detail)
+}
@@ -3,15 +3,27 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/config"
 	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/server"
+	"github.com/vladimirvivien/ktop/ui"
 	"github.com/vladimirvivien/ktop/views/overview"
+	"github.com/vladimirvivien/ktop/views/rightsizing"
+	"github.com/vladimirvivien/ktop/views/snapshot"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 )
 
 var (
@@ -27,6 +39,57 @@ var (
 
 # Start ktop for a specific namespace and context
 %[1]s --namespace <namespace> --context <context>
+
+# Start ktop for a list of namespaces, without cluster-wide access
+%[1]s --namespace <namespace>,<namespace>
+
+# Start ktop and serve the collected data as JSON (and WebSocket stream) on port 8080
+%[1]s --serve :8080
+
+# Serve the collected data without the terminal UI
+%[1]s --serve :8080 --headless
+
+# Start ktop without restoring the namespace, sort, filter, and columns of the previous session
+%[1]s --fresh
+
+# Start ktop displaying only the pods with a name matching a regular expression
+%[1]s --pod-name-regex '^payments-(api|worker)-'
+
+# Start ktop with a compact display (i.e. in a tmux side pane)
+%[1]s --compact
+
+# Start ktop without the actions that modify the cluster (delete, label, taint)
+%[1]s --read-only
+
+# Start ktop with the settings of the prod profile of the configuration file
+%[1]s --profile prod
+
+# Start ktop reaching the API server through a bastion proxy
+%[1]s --proxy-url socks5://localhost:1080
+
+# Start ktop with a higher client rate limit on a large cluster
+%[1]s --qps 50 --burst 100
+
+# Start ktop with usage metrics from the kubelets when metrics-server is not installed
+%[1]s --kubelet-stats
+
+# Start ktop with the CPU throttling of the pods
+%[1]s --cpu-throttling
+
+# Print a snapshot of the nodes and pods, failing when a node uses more than 90% of its CPU
+%[1]s --once --fail-if 'node.cpu>90'
+
+# Print the name, status, and team label of the nodes and pods
+%[1]s --once -o 'custom-columns=NAME:.Name,STATUS:.Status,TEAM:.Labels.team'
+
+# Write a JSON snapshot of the nodes, pods, and cluster summary to ./snaps every 5 minutes
+%[1]s --snapshot-interval 5m --snapshot-dir ./snaps
+
+# Check the API server connectivity, RBAC authorizations, and metrics-server availability
+%[1]s check --namespace <namespace>
+
+# Print the pods usage like kubectl top pods, with the requests, limits, and the peaks over 4 samples
+%[1]s top pods -A --samples 4 --sort-by memory
 `
 )
 
@@ -40,6 +103,29 @@ type ktopCmdOptions struct {
 	nodeColumns       string // comma-separated list of node columns to display
 	podColumns        string // comma-separated list of pod columns to display
 	showAllColumns    bool   // show all columns
+	configFile        string // path to the ktop configuration file
+	serveAddr         string // address to serve JSON/WebSocket endpoints
+	headless          bool   // run without the terminal UI
+	readOnly          bool   // disable actions that modify the cluster
+	fresh             bool   // do not restore the state of the previous session
+	compact           bool   // compact display mode
+	podNameRegex      string // regular expression used to filter pods by name
+	kubeletStats      bool   // fall back to kubelet stats when metrics server is not available
+	cpuThrottling     bool   // scrape the container CPU throttling from the kubelets
+	proxyURL          string // proxy used to reach the API server
+	proxy             *url.URL
+	qps               float32 // client-go rate limiter queries per second (0 for the default)
+	burst             int     // client-go rate limiter burst (0 for the default)
+	resync            time.Duration // informers resync period (0 to disable)
+	once              bool          // print a snapshot then exit
+	failIf            []string      // thresholds breaking the --once run when breached (i.e. node.cpu>90)
+	output            string        // --once output format: wide or custom-columns=...
+	ascii             bool          // draw with ASCII characters only
+	snapshotInterval  time.Duration // interval of the scheduled snapshots (0 to disable)
+	snapshotDir       string        // directory of the scheduled snapshots
+	snapshotFormat    string        // format of the scheduled snapshots: json or csv
+	profile           string        // configuration file profile overriding the configuration settings
+	colorMode         string        // color mode of the terminal: auto, truecolor, 256, 16, or none
 }
 
 // NewKtopCmd returns a command for ktop
@@ -62,11 +148,36 @@ func NewKtopCmd() *cobra.Command {
 			return o.runKtop(c, args)
 		},
 	}
-	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "If true, display metrics for all accessible namespaces")
+	cmd.PersistentFlags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "If true, display metrics for all accessible namespaces")
 	cmd.Flags().StringVar(&o.nodeColumns, "node-columns", "", "Comma-separated list of node columns to display (e.g. 'NAME,CPU,MEM')")
 	cmd.Flags().StringVar(&o.podColumns, "pod-columns", "", "Comma-separated list of pod columns to display (e.g. 'NAMESPACE,POD,STATUS')")
 	cmd.Flags().BoolVar(&o.showAllColumns, "show-all-columns", true, "If true, show all columns (default)")
-	o.kubeFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.serveAddr, "serve", "", "If set, serve pod, node, and summary data as JSON and WebSocket stream at address (e.g. ':8080', on localhost, or '0.0.0.0:8080', on all interfaces)")
+	cmd.Flags().BoolVar(&o.headless, "headless", false, "If true, run without the terminal UI (requires --serve)")
+	cmd.Flags().BoolVar(&o.readOnly, "read-only", false, "If true, disable actions that modify the cluster (delete, label, taint)")
+	cmd.Flags().BoolVar(&o.fresh, "fresh", false, "If true, do not restore the namespace, sort, filter, and columns of the previous session")
+	cmd.Flags().BoolVar(&o.compact, "compact", false, "If true, use a compact display (percentage metrics, small graphs, single line header) for small terminals")
+	cmd.Flags().StringVar(&o.podNameRegex, "pod-name-regex", "", "If set, only display pods with a name matching the regular expression (e.g. '^payments-(api|worker)-')")
+	cmd.Flags().BoolVar(&o.kubeletStats, "kubelet-stats", false, "If true, fall back to the kubelet /stats/summary, through the API server proxy, for usage metrics when metrics-server is not available")
+	cmd.Flags().BoolVar(&o.cpuThrottling, "cpu-throttling", false, "If true, scrape the kubelet cAdvisor metrics, through the API server proxy, for the THROTTLE% pod column")
+	cmd.PersistentFlags().StringVar(&o.proxyURL, "proxy-url", "", "If set, reach the API server through the proxy at URL (http, https, or socks5), overriding the proxy environment variables and kubeconfig")
+	cmd.PersistentFlags().Float32Var(&o.qps, "qps", 0, "Maximum queries per second to the API server; raise it on large clusters, lower it on fragile API servers (default 5)")
+	cmd.PersistentFlags().IntVar(&o.burst, "burst", 0, "Maximum burst of queries to the API server above --qps (default 10)")
+	cmd.Flags().DurationVar(&o.resync, "resync", k8s.DefaultResyncPeriod, "Resync period of the informers caches, which replays the cached objects to refresh the views; 0 disables resyncs (i.e. on very large clusters)")
+	cmd.Flags().StringVar(&o.colorMode, "color", "auto", "Color mode of the terminal: auto (detected from NO_COLOR, COLORTERM, and TERM), truecolor, 256, 16 (bar graphs without block or braille characters), or none (monochrome, ASCII bar graphs)")
+	cmd.Flags().BoolVar(&o.ascii, "ascii", false, "If true, draw icons, arrows, graphs, and borders with ASCII characters only (the default when the locale is not UTF-8)")
+	cmd.Flags().BoolVar(&o.once, "once", false, "If true, print a snapshot of the nodes and pods, once the data is loaded, then exit")
+	cmd.Flags().StringArrayVar(&o.failIf, "fail-if", nil, "With --once, exit with an error when a threshold is breached (i.e. 'node.cpu>90', 'pod.restarts>=5'); can be repeated")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", "With --once, the output format: wide, or custom-columns=<HEADER>:<field path>,... with JSONPath field paths on the pod and node models (i.e. 'custom-columns=NAME:.Name,STATUS:.Status')")
+	cmd.Flags().DurationVar(&o.snapshotInterval, "snapshot-interval", 0, "If set, write a snapshot of the nodes, pods, and cluster summary to --snapshot-dir at this interval (i.e. 5m) while ktop runs")
+	cmd.Flags().StringVar(&o.snapshotDir, "snapshot-dir", "", "With --snapshot-interval, the directory of the snapshot files, created when missing (default the current directory)")
+	cmd.Flags().StringVar(&o.snapshotFormat, "snapshot-format", "json", "With --snapshot-interval, the format of the snapshot files: json, or csv (one row per node and pod)")
+	cmd.Flags().StringVar(&o.configFile, "config", "", "Path to the ktop configuration file (default $HOME/.ktop/config.yaml)")
+	cmd.Flags().StringVar(&o.profile, "profile", "", "If set, override the configuration file settings with the settings of the named profile of the configuration file (refresh interval, columns, sort, formatting rules, read-only)")
+	o.kubeFlags.AddFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().Lookup("namespace").Usage = "If present, the namespace scope of ktop: a namespace, or a comma-separated list of namespaces (i.e. 'payments,checkout')"
+	cmd.AddCommand(newCheckCmd(o))
+	cmd.AddCommand(newTopCmd(o))
 	return cmd
 }
 
@@ -78,13 +189,107 @@ func (o *ktopCmdOptions) runKtop(c *cobra.Command, args []string) error {
 		o.namespace = k8s.AllNamespaces
 	}
 
+	if o.headless && o.serveAddr == "" {
+		return fmt.Errorf("ktop: --headless requires --serve")
+	}
+	if (len(o.failIf) > 0 || o.output != "") && !o.once {
+		return fmt.Errorf("ktop: --fail-if and --output require --once")
+	}
+	if o.once && (o.headless || o.serveAddr != "") {
+		return fmt.Errorf("ktop: --once cannot be used with --serve or --headless")
+	}
+	if err := o.validateSnapshotSchedule(); err != nil {
+		return err
+	}
+	thresholds, err := parseThresholds(o.failIf)
+	if err != nil {
+		return err
+	}
+	output, err := snapshot.ParseOutput(o.output)
+	if err != nil {
+		return fmt.Errorf("ktop: --output: %s", err)
+	}
+
+	cfg, err := config.Load(o.configFile)
+	if err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+	cfg.ApplyContext(o.currentContext())
+	if o.profile != "" {
+		if err := cfg.ApplyProfile(o.profile); err != nil {
+			return fmt.Errorf("ktop: %s", err)
+		}
+	}
+	if interval := cfg.RefreshDuration(); interval > 0 {
+		k8s.SetRefreshInterval(interval)
+	}
+	if err := ui.SetGraphStyle(cfg.GraphStyle); err != nil {
+		return fmt.Errorf("ktop: config: graphStyle: %s", err)
+	}
+	if err := overview.ValidateFormatRules(cfg.FormatRules); err != nil {
+		return fmt.Errorf("ktop: config: %s", err)
+	}
+	colorMode, err := ui.ParseColorMode(o.colorMode)
+	if err != nil {
+		return fmt.Errorf("ktop: --color: %s", err)
+	}
+	ui.UseColorMode(colorMode)
+	if o.ascii || !ui.UTF8Locale() {
+		ui.UseASCII()
+	}
+
+	state, err := o.loadState(c, "")
+	if err != nil {
+		return err
+	}
+	if o.podNameRegex != "" {
+		if _, err := regexp.Compile(o.podNameRegex); err != nil {
+			return fmt.Errorf("ktop: --pod-name-regex: %s", err)
+		}
+		state.PodFilter = "~" + o.podNameRegex
+	}
+
+	if err := o.setupConnection(); err != nil {
+		return err
+	}
+	if o.resync < 0 {
+		return fmt.Errorf("ktop: --resync must not be negative")
+	}
+
 	k8sC, err := k8s.New(o.kubeFlags)
 	if err != nil {
 		return fmt.Errorf("ktop: failed to create Kubernetes client: %s", err)
 	}
+	k8sC.SetKubeletStatsFallback(o.kubeletStats)
+	k8sC.SetCPUThrottling(o.cpuThrottling)
+	if o.once {
+		return o.runOnce(ctx, k8sC, c.OutOrStdout(), output, thresholds)
+	}
 	fmt.Printf("Connected to: %s\n", k8sC.RESTConfig().Host)
+	if impersonation := k8sC.Impersonation(); impersonation != "" {
+		fmt.Printf("Impersonating: %s\n", impersonation)
+	}
+
+	if o.serveAddr != "" {
+		srv := server.New(o.serveAddr, k8sC.Controller(), 3*time.Second)
+		if err := srv.Start(ctx); err != nil {
+			return fmt.Errorf("ktop: serve: %s", err)
+		}
+		fmt.Printf("Serving on: %s\n", srv.Addr())
+	}
+
+	if o.headless {
+		return o.runHeadless(ctx, k8sC)
+	}
 
 	app := application.New(k8sC)
+	app.SetConfig(cfg)
+	app.SetState(state)
+	app.SetSession(state.Session(o.currentContext()))
+	app.SetReadOnly(o.readOnly || cfg.ReadOnly)
+	app.SetCompact(o.compact || cfg.Compact)
+	app.SetColorMode(colorMode)
+	app.SetResync(o.resync)
 	app.WelcomeBanner()
 	
 	// Process column options
@@ -102,6 +307,15 @@ func (o *ktopCmdOptions) runKtop(c *cobra.Command, args []string) error {
 	
 	// Create a new overview page with column options
 	app.AddPage(overview.NewWithColumnOptions(app, "Overview", o.showAllColumns, nodeColumns, podColumns))
+	app.AddPage(rightsizing.New(app, "Right-sizing"))
+
+	if o.snapshotInterval > 0 {
+		go o.runSnapshotSchedule(ctx, k8sC, func(msg string) {
+			app.QueueUpdateDraw(func() {
+				app.Notify(msg)
+			})
+		})
+	}
 
 	if err := k8sC.AssertCoreAuthz(ctx); err != nil {
 		return fmt.Errorf("ktop: %s", err)
@@ -119,8 +333,129 @@ func (o *ktopCmdOptions) runKtop(c *cobra.Command, args []string) error {
 			fmt.Printf("app error: %s\n", err)
 			os.Exit(1)
 		}
+		state.Context = o.currentContext()
+		state.Namespace = k8sC.Namespace()
+		state.Session(state.Context).Namespace = state.Namespace
+		if err := state.Save(); err != nil {
+			fmt.Printf("ktop: %s\n", err)
+		}
 	case <-ctx.Done():
 	}
 
 	return nil
 }
+
+// loadState reads the state file at path (the default path when empty), and restores the
+// namespace of the previous session. Run once, ktop neither reads nor saves the state, so
+// that the snapshots and thresholds depend on the flags and kubeconfig only.
+func (o *ktopCmdOptions) loadState(c *cobra.Command, path string) (*config.State, error) {
+	if o.once {
+		return &config.State{}, nil
+	}
+	state, err := config.LoadState(path)
+	if err != nil {
+		return nil, fmt.Errorf("ktop: %s", err)
+	}
+	if o.fresh {
+		state.Reset(o.currentContext())
+	}
+	o.restoreNamespace(c, state)
+	return state, nil
+}
+
+// restoreNamespace uses the namespace of the previous session, for the same
+// cluster context, when no namespace is specified on the command line.
+func (o *ktopCmdOptions) restoreNamespace(c *cobra.Command, state *config.State) {
+	if o.allNamespaces || c.Flags().Changed("namespace") {
+		return
+	}
+	context := o.currentContext()
+	if _, ok := state.Contexts[context]; !ok && (state.Context == "" || context != state.Context) {
+		return
+	}
+	*o.kubeFlags.Namespace = state.Session(context).Namespace
+}
+
+// currentContext returns the cluster context selected with --context, or the kubeconfig current context
+func (o *ktopCmdOptions) currentContext() string {
+	if o.kubeFlags.Context != nil && *o.kubeFlags.Context != "" {
+		return *o.kubeFlags.Context
+	}
+	rawConfig, err := o.kubeFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return ""
+	}
+	return rawConfig.CurrentContext
+}
+
+// runHeadless starts the controller without the terminal UI and
+// blocks until the process is interrupted.
+func (o *ktopCmdOptions) runHeadless(ctx context.Context, k8sC *k8s.Client) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := k8sC.AssertCoreAuthz(ctx); err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+
+	if err := k8sC.Controller().Start(ctx, o.resync); err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+	if o.snapshotInterval > 0 {
+		go o.runSnapshotSchedule(ctx, k8sC, func(msg string) {
+			fmt.Println(msg)
+		})
+	}
+
+	<-ctx.Done()
+	fmt.Println("ktop finished")
+	return nil
+}
+
+// setupConnection validates the connection flags (proxy and rate limiter) then sets
+// them up to be applied to the client configuration
+func (o *ktopCmdOptions) setupConnection() error {
+	if o.proxyURL != "" {
+		proxy, err := parseProxyURL(o.proxyURL)
+		if err != nil {
+			return fmt.Errorf("ktop: --proxy-url: %s", err)
+		}
+		o.proxy = proxy
+	}
+	if o.qps < 0 || o.burst < 0 {
+		return fmt.Errorf("ktop: --qps and --burst must not be negative")
+	}
+	o.kubeFlags.WrapConfigFn = o.wrapConfig
+	return nil
+}
+
+// wrapConfig applies the connection flags (proxy and rate limiter) to the client configuration
+func (o *ktopCmdOptions) wrapConfig(config *rest.Config) *rest.Config {
+	if o.proxy != nil {
+		config.Proxy = http.ProxyURL(o.proxy)
+	}
+	if o.qps > 0 {
+		config.QPS = o.qps
+	}
+	if o.burst > 0 {
+		config.Burst = o.burst
+	}
+	return config
+}
+
+// parseProxyURL returns the proxy URL, which must be an http, https, or socks5 URL
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	proxy, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (http, https, or socks5)", proxy.Scheme)
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("missing proxy host")
+	}
+	return proxy, nil
+}
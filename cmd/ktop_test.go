@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/vladimirvivien/ktop/config"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// kubeconfigFixture is a kubeconfig with the prod current context
+const kubeconfigFixture = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+- name: dev
+  cluster:
+    server: https://dev.example.com
+users:
+- name: admin
+  user: {}
+contexts:
+- name: prod
+  context: {cluster: prod, user: admin}
+- name: dev
+  context: {cluster: dev, user: admin}
+current-context: prod
+`
+
+func TestRestoreNamespace(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte(kubeconfigFixture), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		state    config.State
+		args     []string
+		expected string
+	}{
+		{
+			name:     "current context",
+			state:    config.State{Contexts: map[string]*config.ContextState{"prod": {Namespace: "payments"}}},
+			expected: "payments",
+		},
+		{
+			name:     "context flag",
+			state:    config.State{Contexts: map[string]*config.ContextState{"prod": {Namespace: "payments"}, "dev": {Namespace: "sandbox"}}},
+			args:     []string{"--context", "dev"},
+			expected: "sandbox",
+		},
+		{
+			name:     "single context state",
+			state:    config.State{Context: "prod", Namespace: "payments"},
+			expected: "payments",
+		},
+		{
+			name:  "other context",
+			state: config.State{Contexts: map[string]*config.ContextState{"dev": {Namespace: "sandbox"}}},
+		},
+		{
+			name:     "namespace flag",
+			state:    config.State{Contexts: map[string]*config.ContextState{"prod": {Namespace: "payments"}}},
+			args:     []string{"--namespace", "default"},
+			expected: "default",
+		},
+		{
+			name:  "all namespaces flag",
+			state: config.State{Contexts: map[string]*config.ContextState{"prod": {Namespace: "payments"}}},
+			args:  []string{"-A"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			// the state is restored from its file, as on launch
+			path := filepath.Join(t.TempDir(), "state.yaml")
+			saved, err := config.LoadState(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			saved.Context, saved.Namespace, saved.Contexts = tc.state.Context, tc.state.Namespace, tc.state.Contexts
+			if err := saved.Save(); err != nil {
+				t.Fatal(err)
+			}
+			state, err := config.LoadState(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			o := &ktopCmdOptions{kubeFlags: genericclioptions.NewConfigFlags(false)}
+			c := &cobra.Command{}
+			c.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "")
+			o.kubeFlags.AddFlags(c.Flags())
+			if err := c.Flags().Parse(append([]string{"--kubeconfig", kubeconfig}, tc.args...)); err != nil {
+				t.Fatal(err)
+			}
+
+			o.restoreNamespace(c, state)
+			if *o.kubeFlags.Namespace != tc.expected {
+				t.Errorf("expecting namespace %q, got %q", tc.expected, *o.kubeFlags.Namespace)
+			}
+		})
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte(kubeconfigFixture), 0600); err != nil {
+		t.Fatal(err)
+	}
+	saved := filepath.Join(dir, "state.yaml")
+	if err := os.WriteFile(saved, []byte("contexts:\n  prod:\n    namespace: payments\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := filepath.Join(dir, "corrupt.yaml")
+	if err := os.WriteFile(corrupt, []byte("contexts: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		once      bool
+		namespace string
+		shouldErr bool
+	}{
+		{name: "saved state", path: saved, namespace: "payments"},
+		{name: "saved state run once", path: saved, once: true},
+		{name: "corrupt state", path: corrupt, shouldErr: true},
+		{name: "corrupt state run once", path: corrupt, once: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			o := &ktopCmdOptions{kubeFlags: genericclioptions.NewConfigFlags(false), once: tc.once}
+			c := &cobra.Command{}
+			o.kubeFlags.AddFlags(c.Flags())
+			if err := c.Flags().Parse([]string{"--kubeconfig", kubeconfig}); err != nil {
+				t.Fatal(err)
+			}
+
+			state, err := o.loadState(c, tc.path)
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatal("expecting an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *o.kubeFlags.Namespace != tc.namespace {
+				t.Errorf("expecting namespace %q, got %q", tc.namespace, *o.kubeFlags.Namespace)
+			}
+			if tc.once && len(state.Contexts) != 0 {
+				t.Errorf("expecting an empty state run once, got %+v", state.Contexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/views/model"
+	"github.com/vladimirvivien/ktop/views/snapshot"
+)
+
+// parseThresholds parses the --fail-if threshold expressions
+func parseThresholds(exprs []string) ([]snapshot.Threshold, error) {
+	var thresholds []snapshot.Threshold
+	for _, expr := range exprs {
+		threshold, err := snapshot.ParseThreshold(expr)
+		if err != nil {
+			return nil, fmt.Errorf("ktop: --fail-if: %s", err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// runOnce prints a snapshot of the nodes and pods to out, with the output columns, once the
+// controller caches are synced, then checks the thresholds: an error is returned when one is breached.
+func (o *ktopCmdOptions) runOnce(ctx context.Context, k8sC *k8s.Client, out io.Writer, output snapshot.OutputOptions, thresholds []snapshot.Threshold) error {
+	if err := k8sC.AssertCoreAuthz(ctx); err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+	ctrl := k8sC.Controller()
+	if err := ctrl.Start(ctx, o.resync); err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+
+	snap, err := takeSnapshot(ctx, k8sC)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.WriteText(out, snap, output); err != nil {
+		return fmt.Errorf("ktop: %s", err)
+	}
+
+	breached := 0
+	for _, threshold := range thresholds {
+		breaches := threshold.Breaches(snap)
+		if len(breaches) == 0 {
+			continue
+		}
+		breached++
+		fmt.Fprintf(out, "\nthreshold %s breached by:\n", threshold.Expr)
+		for _, breach := range breaches {
+			fmt.Fprintf(out, "  %s\n", breach)
+		}
+	}
+	if breached > 0 {
+		return fmt.Errorf("ktop: %d of %d --fail-if threshold(s) breached", breached, len(thresholds))
+	}
+	return nil
+}
+
+// takeSnapshot returns a snapshot of the sorted nodes and pods models, and of the cluster
+// summary, from the synced controller caches
+func takeSnapshot(ctx context.Context, k8sC *k8s.Client) (snapshot.Snapshot, error) {
+	ctrl := k8sC.Controller()
+	nodes, err := ctrl.GetNodeModels(ctx)
+	if err != nil {
+		return snapshot.Snapshot{}, fmt.Errorf("ktop: nodes: %s", err)
+	}
+	pods, err := ctrl.GetPodModels(ctx)
+	if err != nil {
+		return snapshot.Snapshot{}, fmt.Errorf("ktop: pods: %s", err)
+	}
+	model.SortNodeModels(nodes)
+	model.SortPodModels(pods)
+
+	snap := snapshot.Snapshot{
+		Time:             time.Now(),
+		Server:           k8sC.RESTConfig().Host,
+		Context:          k8sC.ClusterContext(),
+		Namespace:        k8sC.Namespace(),
+		MetricsAvailable: k8sC.AssertMetricsAvailable() == nil,
+		Nodes:            nodes,
+		Pods:             pods,
+	}
+	// the summary is best effort, it is left empty when the summarized resources cannot be listed
+	if summary, err := ctrl.GetClusterSummary(ctx); err == nil {
+		snap.Summary = summary
+	}
+	return snap, nil
+}
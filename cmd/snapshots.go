@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/views/snapshot"
+)
+
+// minSnapshotInterval is the shortest --snapshot-interval, to keep the snapshot directory
+// growth, and the API server load of the summary, reasonable
+const minSnapshotInterval = 10 * time.Second
+
+// validateSnapshotSchedule validates the --snapshot-interval, --snapshot-dir, and
+// --snapshot-format flags, then creates the snapshot directory when scheduled
+func (o *ktopCmdOptions) validateSnapshotSchedule() error {
+	if o.snapshotInterval == 0 {
+		if o.snapshotDir != "" {
+			return fmt.Errorf("ktop: --snapshot-dir requires --snapshot-interval")
+		}
+		return nil
+	}
+	if o.once {
+		return fmt.Errorf("ktop: --snapshot-interval cannot be used with --once")
+	}
+	if o.snapshotInterval < minSnapshotInterval {
+		return fmt.Errorf("ktop: --snapshot-interval must be at least %s", minSnapshotInterval)
+	}
+	if o.snapshotFormat != snapshot.FormatJSON && o.snapshotFormat != snapshot.FormatCSV {
+		return fmt.Errorf("ktop: --snapshot-format: unsupported format %q (want json or csv)", o.snapshotFormat)
+	}
+	if o.snapshotDir == "" {
+		o.snapshotDir = "."
+	}
+	if err := os.MkdirAll(o.snapshotDir, 0o755); err != nil {
+		return fmt.Errorf("ktop: --snapshot-dir: %s", err)
+	}
+	return nil
+}
+
+// runSnapshotSchedule writes, once the controller caches are synced and until ctx is done,
+// a snapshot of the nodes, pods, and cluster summary to the snapshot directory at each
+// snapshot interval. notify reports the written files and the errors.
+func (o *ktopCmdOptions) runSnapshotSchedule(ctx context.Context, k8sC *k8s.Client, notify func(msg string)) {
+	ctrl := k8sC.Controller()
+	select {
+	case <-ctx.Done():
+		return
+	case <-ctrl.Ready():
+	}
+
+	ticker := time.NewTicker(o.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fileName, err := o.writeSnapshot(ctx, k8sC)
+		if err != nil {
+			notify(fmt.Sprintf("Snapshot failed: %s", err))
+			continue
+		}
+		notify(fmt.Sprintf("Snapshot written to %s", fileName))
+	}
+}
+
+// writeSnapshot writes a snapshot to a new file of the snapshot directory and returns its path
+func (o *ktopCmdOptions) writeSnapshot(ctx context.Context, k8sC *k8s.Client) (string, error) {
+	snap, err := takeSnapshot(ctx, k8sC)
+	if err != nil {
+		return "", err
+	}
+	fileName := filepath.Join(o.snapshotDir, snap.FileName(o.snapshotFormat))
+	file, err := os.Create(fileName)
+	if err != nil {
+		return "", err
+	}
+	if err := snapshot.Write(file, snap, o.snapshotFormat); err != nil {
+		file.Close()
+		return "", err
+	}
+	return fileName, file.Close()
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/views/snapshot"
+)
+
+// topOptions are the options of the top subcommands
+type topOptions struct {
+	sortBy    string        // cpu or memory, decreasing usage
+	noHeaders bool          // omit the header row
+	samples   int           // number of usage samples, for the peak usage
+	interval  time.Duration // interval between two samples
+}
+
+// newTopCmd returns the top subcommand, which prints the pods, or nodes, usage like kubectl top
+// with the requests, limits, usage percentages, and peak usage.
+func newTopCmd(o *ktopCmdOptions) *cobra.Command {
+	top := &topOptions{}
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Prints the CPU and memory usage of the pods, or nodes, like kubectl top, with the requests, limits, percentages, and peaks",
+	}
+	cmd.PersistentFlags().StringVar(&top.sortBy, "sort-by", "", "If set, sort the rows by decreasing usage: cpu or memory")
+	cmd.PersistentFlags().BoolVar(&top.noHeaders, "no-headers", false, "If true, do not print the header row")
+	cmd.PersistentFlags().IntVar(&top.samples, "samples", 1, "Number of usage samples, taken --interval apart, the CPU-PEAK and MEMORY-PEAK columns are computed over")
+	cmd.PersistentFlags().DurationVar(&top.interval, "interval", 15*time.Second, "With --samples, the interval between two usage samples")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "pods",
+		Aliases:      []string{"pod", "po"},
+		Short:        "Prints the CPU and memory usage of the pods",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runTop(c, top, snapshot.WriteTopPods)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:          "nodes",
+		Aliases:      []string{"node", "no"},
+		Short:        "Prints the CPU and memory usage of the nodes",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.runTop(c, top, snapshot.WriteTopNodes)
+		},
+	})
+	return cmd
+}
+
+// runTop takes the usage samples, once the controller caches are synced, and prints them with write
+func (o *ktopCmdOptions) runTop(c *cobra.Command, top *topOptions, write func(io.Writer, []snapshot.Snapshot, snapshot.TopOptions) error) error {
+	if err := snapshot.ValidateTopSort(top.sortBy); err != nil {
+		return fmt.Errorf("ktop top: --sort-by: %s", err)
+	}
+	if top.samples < 1 {
+		return fmt.Errorf("ktop top: --samples must be at least 1")
+	}
+	if err := o.setupConnection(); err != nil {
+		return err
+	}
+	if o.allNamespaces {
+		*o.kubeFlags.Namespace = k8s.AllNamespaces
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	k8sC, err := k8s.New(o.kubeFlags)
+	if err != nil {
+		return fmt.Errorf("ktop top: failed to create Kubernetes client: %s", err)
+	}
+	if err := k8sC.AssertCoreAuthz(ctx); err != nil {
+		return fmt.Errorf("ktop top: %s", err)
+	}
+	if err := k8sC.AssertMetricsAvailable(); err != nil {
+		return fmt.Errorf("ktop top: metrics not available: %s", err)
+	}
+	if err := k8sC.Controller().Start(ctx, o.resync); err != nil {
+		return fmt.Errorf("ktop top: %s", err)
+	}
+
+	var samples []snapshot.Snapshot
+	for i := 0; i < top.samples; i++ {
+		if i > 0 {
+			time.Sleep(top.interval)
+		}
+		sample, err := takeSnapshot(ctx, k8sC)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, sample)
+	}
+	// like kubectl top -A, the NAMESPACE column is printed for more than one namespace
+	allNamespaces := k8sC.Namespace() == k8s.AllNamespaces || len(k8sC.Namespaces()) > 1
+	opts := snapshot.TopOptions{AllNamespaces: allNamespaces, SortBy: top.sortBy, NoHeaders: top.noHeaders}
+	if err := write(c.OutOrStdout(), samples, opts); err != nil {
+		return fmt.Errorf("ktop top: %s", err)
+	}
+	return nil
+}
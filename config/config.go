@@ -0,0 +1,324 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ColumnTargetPod attaches a custom column to the pod panel
+	ColumnTargetPod = "pod"
+	// ColumnTargetNode attaches a custom column to the node panel
+	ColumnTargetNode = "node"
+
+	// ColumnSourceLabel reads the column value from an object label
+	ColumnSourceLabel = "label"
+	// ColumnSourceAnnotation reads the column value from an object annotation
+	ColumnSourceAnnotation = "annotation"
+	// ColumnSourceHTTP fetches the column value from an HTTP endpoint
+	ColumnSourceHTTP = "http"
+	// ColumnSourceCustomMetric reads the column value from the custom metrics API (custom.metrics.k8s.io)
+	ColumnSourceCustomMetric = "custom-metric"
+	// ColumnSourceExternalMetric reads the column value from the external metrics API (external.metrics.k8s.io)
+	ColumnSourceExternalMetric = "external-metric"
+
+	// MemoryUnitMi, MemoryUnitGi, and MemoryUnitAuto (Gi from 1Gi, Mi below) are the memory display units
+	MemoryUnitMi   = "Mi"
+	MemoryUnitGi   = "Gi"
+	MemoryUnitAuto = "auto"
+	// CPUUnitMillicores and CPUUnitCores are the CPU display units
+	CPUUnitMillicores = "millicores"
+	CPUUnitCores      = "cores"
+)
+
+const (
+	// DefaultNewPodWindow is the window within which created or restarted pods are badged
+	DefaultNewPodWindow = 5 * time.Minute
+	// MinRefreshInterval is the shortest refresh interval
+	MinRefreshInterval = time.Second
+)
+
+// Config represents the content of the ktop configuration file
+type Config struct {
+	// Columns declares additional columns computed by column providers
+	Columns []ColumnConfig `json:"columns,omitempty"`
+	// NodeColumns sets the order and width of the node panel columns
+	NodeColumns []ColumnLayout `json:"nodeColumns,omitempty"`
+	// PodColumns sets the order and width of the pod panel columns
+	PodColumns []ColumnLayout `json:"podColumns,omitempty"`
+	// NodeSort is the default sort of the node panel, used when no sort is saved from a previous session
+	NodeSort *SortState `json:"nodeSort,omitempty"`
+	// PodSort is the default sort of the pod panel, used when no sort is saved from a previous session
+	PodSort *SortState `json:"podSort,omitempty"`
+	// GraphStyle is the style of the bar graphs: bars (default), blocks, braille, or ascii
+	GraphStyle string `json:"graphStyle,omitempty"`
+	// NewPodWindow is the window, i.e. 10m, within which created or restarted pods are
+	// badged in the pod panel (default 5m, 0 disables the badges)
+	NewPodWindow string `json:"newPodWindow,omitempty"`
+	// FormatRules highlight the pod, or node, rows matching a condition, evaluated at each refresh
+	FormatRules []FormatRule `json:"formatRules,omitempty"`
+	// RefreshInterval is the interval, i.e. 10s, between two refreshes of the pods, nodes,
+	// and summary (default 3s for the pods, 5s for the nodes and summary)
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// ReadOnly disables the actions that modify the cluster, like --read-only
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// Compact enables the compact display, like --compact
+	Compact bool `json:"compact,omitempty"`
+	// MemoryUnit is the unit of the memory values of the panels: Mi, Gi, or auto (default Mi for
+	// the pods, Gi for the nodes and summary, rounded to the unit)
+	MemoryUnit string `json:"memoryUnit,omitempty"`
+	// MemoryDecimals is the number of decimals of the memory values, with MemoryUnit
+	MemoryDecimals int `json:"memoryDecimals,omitempty"`
+	// CPUUnit is the unit of the CPU values of the panels: millicores (default), or cores
+	CPUUnit string `json:"cpuUnit,omitempty"`
+	// Profiles are named sets of settings, selected with --profile, overriding the settings above
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+	// Contexts override the settings above when the active kube-context matches, before the profile
+	Contexts []ContextOverride `json:"contexts,omitempty"`
+
+	// path of the file the configuration is loaded from
+	path string
+	// applied profile
+	profile string
+}
+
+// ColumnLayout sets the position and width of a panel column. Columns are displayed
+// in the order they are declared, followed by the columns that are not declared.
+type ColumnLayout struct {
+	// Name is the column header, i.e. NAMESPACE
+	Name string `json:"name"`
+	// MinWidth is the minimum width of the column (0 for no minimum)
+	MinWidth int `json:"minWidth,omitempty"`
+	// MaxWidth is the maximum width of the column; values are truncated
+	// and the column does not expand (0 for no maximum)
+	MaxWidth int `json:"maxWidth,omitempty"`
+}
+
+// FormatRule highlights the rows, of the pod or node panel, matching a condition
+type FormatRule struct {
+	// Target is the panel of the rule: pod (default) or node
+	Target string `json:"target,omitempty"`
+	// If is the condition on a field, i.e. MEMORY% > 85, or on a label, i.e. label team=payments
+	If string `json:"if"`
+	// Then is a comma-separated list of styles: row=<color> (text color), bg=<color>, and bold
+	Then string `json:"then"`
+}
+
+// ColumnConfig declares a custom column and the source used to compute its value.
+type ColumnConfig struct {
+	// Name is the column header, i.e. TEAM
+	Name string `json:"name"`
+	// Target is the panel the column is added to: pod or node
+	Target string `json:"target"`
+	// Source is the provider type: label, annotation, http, custom-metric, or external-metric
+	Source string `json:"source"`
+	// Key is the label or annotation key (label and annotation sources)
+	Key string `json:"key,omitempty"`
+	// URL is a Go text/template rendered with the object's namespace, name,
+	// labels, and annotations (http source), i.e. http://cmdb/owner?pod={{.Name}}
+	URL string `json:"url,omitempty"`
+	// Metric is the metric name (custom-metric and external-metric sources), i.e. http_requests_per_second
+	Metric string `json:"metric,omitempty"`
+	// MetricSelector is a label selector, rendered as a Go template like URL, narrowing down
+	// the metric series (custom-metric and external-metric sources), i.e. app={{index .Labels "app"}}
+	MetricSelector string `json:"metricSelector,omitempty"`
+	// Selector is a label selector restricting the column to the matching objects, i.e. a
+	// workload; the default value is displayed for the other objects
+	Selector string `json:"selector,omitempty"`
+	// Timeout for http and metric requests, i.e. 2s (default 2s)
+	Timeout string `json:"timeout,omitempty"`
+	// TTL for cached http and metric values, i.e. 1m (default 1m)
+	TTL string `json:"ttl,omitempty"`
+	// Default value displayed when no value is found
+	Default string `json:"default,omitempty"`
+}
+
+// DefaultPath returns the default location of the configuration file ($HOME/.ktop/config.yaml)
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".ktop", "config.yaml")
+}
+
+// Load reads and validates the configuration file at path.
+// When path is empty, the default path is used and a missing file is not an error.
+func Load(path string) (*Config, error) {
+	optional := false
+	if path == "" {
+		path = DefaultPath()
+		optional = true
+	}
+
+	cfg := &Config{path: path}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Path returns the path of the configuration file
+func (c *Config) Path() string {
+	if c.path == "" {
+		return DefaultPath()
+	}
+	return c.path
+}
+
+// Validate checks the configuration for missing or unsupported values
+func (c *Config) Validate() error {
+	names := make(map[string]bool)
+	for i, col := range c.Columns {
+		if col.Name == "" {
+			return fmt.Errorf("columns[%d]: missing name", i)
+		}
+		key := col.Target + "/" + strings.ToUpper(col.Name)
+		if names[key] {
+			return fmt.Errorf("columns[%d]: duplicate column %s", i, col.Name)
+		}
+		names[key] = true
+
+		switch col.Target {
+		case ColumnTargetPod, ColumnTargetNode:
+		default:
+			return fmt.Errorf("columns[%d]: unsupported target %q", i, col.Target)
+		}
+
+		switch col.Source {
+		case ColumnSourceLabel, ColumnSourceAnnotation:
+			if col.Key == "" {
+				return fmt.Errorf("columns[%d]: source %s requires a key", i, col.Source)
+			}
+		case ColumnSourceHTTP:
+			if col.URL == "" {
+				return fmt.Errorf("columns[%d]: source %s requires a url", i, col.Source)
+			}
+		case ColumnSourceCustomMetric, ColumnSourceExternalMetric:
+			if col.Metric == "" {
+				return fmt.Errorf("columns[%d]: source %s requires a metric", i, col.Source)
+			}
+		default:
+			return fmt.Errorf("columns[%d]: unsupported source %q", i, col.Source)
+		}
+	}
+
+	for i, rule := range c.FormatRules {
+		switch rule.Target {
+		case "", ColumnTargetPod, ColumnTargetNode:
+		default:
+			return fmt.Errorf("formatRules[%d]: unsupported target %q", i, rule.Target)
+		}
+		if rule.If == "" || rule.Then == "" {
+			return fmt.Errorf("formatRules[%d]: requires if and then", i)
+		}
+	}
+
+	if c.NewPodWindow != "" {
+		window, err := time.ParseDuration(c.NewPodWindow)
+		if err != nil {
+			return fmt.Errorf("newPodWindow: %w", err)
+		}
+		if window < 0 {
+			return fmt.Errorf("newPodWindow: must not be negative")
+		}
+	}
+
+	switch c.MemoryUnit {
+	case "", MemoryUnitMi, MemoryUnitGi, MemoryUnitAuto:
+	default:
+		return fmt.Errorf("memoryUnit: unsupported unit %q (want Mi, Gi, or auto)", c.MemoryUnit)
+	}
+	if c.MemoryDecimals < 0 || c.MemoryDecimals > 3 {
+		return fmt.Errorf("memoryDecimals: must be between 0 and 3")
+	}
+	switch c.CPUUnit {
+	case "", CPUUnitMillicores, CPUUnitCores:
+	default:
+		return fmt.Errorf("cpuUnit: unsupported unit %q (want millicores or cores)", c.CPUUnit)
+	}
+
+	if c.RefreshInterval != "" {
+		interval, err := time.ParseDuration(c.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("refreshInterval: %w", err)
+		}
+		if interval < MinRefreshInterval {
+			return fmt.Errorf("refreshInterval: must be at least %s", MinRefreshInterval)
+		}
+	}
+
+	if err := validateLayout("nodeColumns", c.NodeColumns); err != nil {
+		return err
+	}
+	if err := validateLayout("podColumns", c.PodColumns); err != nil {
+		return err
+	}
+	return c.validateProfiles()
+}
+
+// RefreshDuration returns the refresh interval, 0 for the default intervals
+func (c *Config) RefreshDuration() time.Duration {
+	interval, err := time.ParseDuration(c.RefreshInterval)
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// NewPodDuration returns the window within which created or restarted pods are badged,
+// 0 when the badges are disabled
+func (c *Config) NewPodDuration() time.Duration {
+	if c.NewPodWindow == "" {
+		return DefaultNewPodWindow
+	}
+	window, err := time.ParseDuration(c.NewPodWindow)
+	if err != nil {
+		return DefaultNewPodWindow
+	}
+	return window
+}
+
+func validateLayout(field string, layouts []ColumnLayout) error {
+	names := make(map[string]bool)
+	for i, col := range layouts {
+		if col.Name == "" {
+			return fmt.Errorf("%s[%d]: missing name", field, i)
+		}
+		name := strings.ToUpper(col.Name)
+		if names[name] {
+			return fmt.Errorf("%s[%d]: duplicate column %s", field, i, col.Name)
+		}
+		names[name] = true
+
+		if col.MinWidth < 0 || col.MaxWidth < 0 {
+			return fmt.Errorf("%s[%d]: negative width", field, i)
+		}
+		if col.MaxWidth > 0 && col.MinWidth > col.MaxWidth {
+			return fmt.Errorf("%s[%d]: minWidth greater than maxWidth", field, i)
+		}
+	}
+	return nil
+}
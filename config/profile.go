@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Profile is a named set of settings, selected with --profile, overriding the settings of the
+// configuration file; unset fields keep the configuration file settings.
+type Profile struct {
+	// RefreshInterval is the interval between two refreshes of the pods, nodes, and summary
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// ReadOnly disables the actions that modify the cluster, like --read-only
+	ReadOnly *bool `json:"readOnly,omitempty"`
+	// Compact enables the compact display, like --compact
+	Compact      *bool          `json:"compact,omitempty"`
+	NodeColumns  []ColumnLayout `json:"nodeColumns,omitempty"`
+	PodColumns   []ColumnLayout `json:"podColumns,omitempty"`
+	NodeSort     *SortState     `json:"nodeSort,omitempty"`
+	PodSort      *SortState     `json:"podSort,omitempty"`
+	GraphStyle   string         `json:"graphStyle,omitempty"`
+	NewPodWindow string         `json:"newPodWindow,omitempty"`
+	// FormatRules replace the formatting rules (i.e. the usage thresholds highlighting rows)
+	FormatRules []FormatRule `json:"formatRules,omitempty"`
+}
+
+// ContextOverride overrides the configuration settings when the name of the active
+// kube-context matches Match
+type ContextOverride struct {
+	// Match is a context name pattern, i.e. prod-* (shell file name pattern syntax)
+	Match   string `json:"match"`
+	Profile `json:",inline"`
+}
+
+// ProfileNames returns the names of the profiles of the configuration, in name order
+func (c *Config) ProfileNames() []string {
+	var names []string
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyContext overrides the configuration settings with the settings of the context overrides
+// matching the kube-context name, in order.
+func (c *Config) ApplyContext(name string) {
+	for _, override := range c.Contexts {
+		if matched, _ := path.Match(override.Match, name); !matched {
+			continue
+		}
+		c.override(override.Profile)
+	}
+}
+
+// ApplyProfile overrides the configuration settings with the settings of the named profile.
+func (c *Config) ApplyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("config: unknown profile %q (no profiles in %s)", name, c.Path())
+		}
+		return fmt.Errorf("config: unknown profile %q (want one of %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	c.profile = name
+	c.override(profile)
+	return nil
+}
+
+// Profile returns the name of the applied profile, empty when none is applied
+func (c *Config) Profile() string {
+	return c.profile
+}
+
+// override sets the settings of the configuration set in profile
+func (c *Config) override(profile Profile) {
+	if profile.RefreshInterval != "" {
+		c.RefreshInterval = profile.RefreshInterval
+	}
+	if profile.ReadOnly != nil {
+		c.ReadOnly = *profile.ReadOnly
+	}
+	if profile.Compact != nil {
+		c.Compact = *profile.Compact
+	}
+	if profile.NodeColumns != nil {
+		c.NodeColumns = profile.NodeColumns
+	}
+	if profile.PodColumns != nil {
+		c.PodColumns = profile.PodColumns
+	}
+	if profile.NodeSort != nil {
+		c.NodeSort = profile.NodeSort
+	}
+	if profile.PodSort != nil {
+		c.PodSort = profile.PodSort
+	}
+	if profile.GraphStyle != "" {
+		c.GraphStyle = profile.GraphStyle
+	}
+	if profile.NewPodWindow != "" {
+		c.NewPodWindow = profile.NewPodWindow
+	}
+	if profile.FormatRules != nil {
+		c.FormatRules = profile.FormatRules
+	}
+}
+
+// validateProfiles validates the configuration with each profile, and context override, applied
+func (c *Config) validateProfiles() error {
+	validate := func(profile Profile) error {
+		cfg := *c
+		cfg.Profiles, cfg.Contexts = nil, nil
+		cfg.override(profile)
+		return cfg.Validate()
+	}
+	for _, name := range c.ProfileNames() {
+		if err := validate(c.Profiles[name]); err != nil {
+			return fmt.Errorf("profiles.%s: %w", name, err)
+		}
+	}
+	for i, override := range c.Contexts {
+		if override.Match == "" {
+			return fmt.Errorf("contexts[%d]: missing match", i)
+		}
+		if _, err := path.Match(override.Match, ""); err != nil {
+			return fmt.Errorf("contexts[%d]: match: %w", i, err)
+		}
+		if err := validate(override.Profile); err != nil {
+			return fmt.Errorf("contexts[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	readOnly := true
+	newConfig := func() *Config {
+		return &Config{
+			RefreshInterval: "5s",
+			GraphStyle:      "bars",
+			PodColumns:      []ColumnLayout{{Name: "POD"}},
+			Profiles: map[string]Profile{
+				"prod": {RefreshInterval: "30s", ReadOnly: &readOnly, PodColumns: []ColumnLayout{{Name: "NAMESPACE"}, {Name: "POD"}}},
+				"dev":  {GraphStyle: "braille"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		refresh  string
+		readOnly bool
+		style    string
+		podCols  int
+		err      bool
+	}{
+		{name: "prod", profile: "prod", refresh: "30s", readOnly: true, style: "bars", podCols: 2},
+		{name: "dev", profile: "dev", refresh: "5s", style: "braille", podCols: 1},
+		{name: "unknown", profile: "staging", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			cfg := newConfig()
+			err := cfg.ApplyProfile(tc.profile)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expecting an error for profile %s", tc.profile)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.RefreshInterval != tc.refresh || cfg.ReadOnly != tc.readOnly || cfg.GraphStyle != tc.style || len(cfg.PodColumns) != tc.podCols {
+				t.Fatalf("unexpected settings with profile %s: %+v", tc.profile, cfg)
+			}
+		})
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	cfg := &Config{Profiles: map[string]Profile{"prod": {RefreshInterval: "100ms"}}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expecting an error for a refresh interval below the minimum")
+	}
+	cfg.Profiles["prod"] = Profile{RefreshInterval: "10s"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyContext(t *testing.T) {
+	readOnly := true
+	newConfig := func() *Config {
+		return &Config{
+			RefreshInterval: "5s",
+			Profiles:        map[string]Profile{"debug": {RefreshInterval: "1s"}},
+			Contexts: []ContextOverride{
+				{Match: "prod-*", Profile: Profile{RefreshInterval: "30s", ReadOnly: &readOnly}},
+				{Match: "prod-eu-*", Profile: Profile{GraphStyle: "ascii"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		context  string
+		profile  string
+		refresh  string
+		readOnly bool
+		style    string
+	}{
+		{name: "no match", context: "dev", refresh: "5s"},
+		{name: "match", context: "prod-us-1", refresh: "30s", readOnly: true},
+		{name: "matches in order", context: "prod-eu-1", refresh: "30s", readOnly: true, style: "ascii"},
+		{name: "profile after context", context: "prod-us-1", profile: "debug", refresh: "1s", readOnly: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			cfg := newConfig()
+			cfg.ApplyContext(tc.context)
+			if tc.profile != "" {
+				if err := cfg.ApplyProfile(tc.profile); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if cfg.RefreshInterval != tc.refresh || cfg.ReadOnly != tc.readOnly || cfg.GraphStyle != tc.style {
+				t.Fatalf("unexpected settings for context %s: %+v", tc.context, cfg)
+			}
+		})
+	}
+
+	cfg := newConfig()
+	cfg.Contexts[0].Match = "prod-["
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expecting an error for an invalid match pattern")
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// State is the view state (namespace, sort, filters, and columns) saved when
+// ktop exits and restored on the next launch.
+type State struct {
+	// Context is the cluster context the namespace was selected for
+	Context string `json:"context,omitempty"`
+	// Namespace is the namespace scope (empty for all namespaces)
+	Namespace string `json:"namespace,omitempty"`
+
+	NodeSort SortState `json:"nodeSort,omitempty"`
+	PodSort  SortState `json:"podSort,omitempty"`
+	// PodFilter is the pod name filter
+	PodFilter string `json:"podFilter,omitempty"`
+	// NodeOS is the operating system the nodes are filtered by (empty for all nodes)
+	NodeOS string `json:"nodeOS,omitempty"`
+	// PreferIPv6 lists the IPv6 pod IPs first on dual-stack clusters
+	PreferIPv6 bool `json:"preferIPv6,omitempty"`
+	// MetricStyle is the display of the CPU and memory cells: graph (the default), percent (the
+	// usage percentage only), or numeric (the usage, request, and limit values)
+	MetricStyle string `json:"metricStyle,omitempty"`
+	// NodeCapacity compares the node usage to the node capacity rather than allocatable
+	NodeCapacity bool `json:"nodeCapacity,omitempty"`
+	// UsageBase is the denominator of the pod CPU and memory usage percentages: request (the
+	// default), limit, or node
+	UsageBase string `json:"usageBase,omitempty"`
+
+	// NodeColumns and PodColumns are the displayed columns, in order
+	NodeColumns []string `json:"nodeColumns,omitempty"`
+	PodColumns  []string `json:"podColumns,omitempty"`
+	// NodeColumnOrder and PodColumnOrder are the order of all the columns, displayed or hidden,
+	// set from the column chooser
+	NodeColumnOrder []string `json:"nodeColumnOrder,omitempty"`
+	PodColumnOrder  []string `json:"podColumnOrder,omitempty"`
+
+	// SummaryExpanded shows the additional rows of the cluster summary panel
+	SummaryExpanded bool `json:"summaryExpanded,omitempty"`
+
+	// Contexts is the session state of each cluster context, restored when ktop is launched
+	// for the context again
+	Contexts map[string]*ContextState `json:"contexts,omitempty"`
+
+	// path of the file the state is loaded from, and saved to
+	path string
+}
+
+// SortState is the column, and direction, a panel is sorted by along with
+// the optional secondary column used to sort rows with equal column values.
+type SortState struct {
+	Column        string `json:"column,omitempty"`
+	Desc          bool   `json:"desc,omitempty"`
+	Secondary     string `json:"secondary,omitempty"`
+	SecondaryDesc bool   `json:"secondaryDesc,omitempty"`
+}
+
+// ContextState is the session state of a cluster context: the namespace, visible page, and
+// selected rows with the scroll position of the panels
+type ContextState struct {
+	// Namespace is the namespace scope (empty for all namespaces)
+	Namespace string `json:"namespace,omitempty"`
+	// Page is the title of the visible page
+	Page string `json:"page,omitempty"`
+	// SelectedPod (namespace/name) and SelectedNode are the selected rows of the overview
+	SelectedPod  string `json:"selectedPod,omitempty"`
+	SelectedNode string `json:"selectedNode,omitempty"`
+	// PodOffset and NodeOffset are the first rows shown, the scroll position, of the panels
+	PodOffset  int `json:"podOffset,omitempty"`
+	NodeOffset int `json:"nodeOffset,omitempty"`
+}
+
+// Session returns the session state of the cluster context, added when missing
+func (s *State) Session(context string) *ContextState {
+	if s.Contexts == nil {
+		s.Contexts = make(map[string]*ContextState)
+	}
+	session, ok := s.Contexts[context]
+	if !ok || session == nil {
+		session = new(ContextState)
+		// the namespace of the single context saved before the sessions per context
+		if context == s.Context {
+			session.Namespace = s.Namespace
+		}
+		s.Contexts[context] = session
+	}
+	return session
+}
+
+// DefaultStatePath returns the default location of the state file ($HOME/.ktop/state.yaml)
+func DefaultStatePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".ktop", "state.yaml")
+}
+
+// LoadState reads the state file at path (the default path when empty).
+// A missing file returns an empty state.
+func LoadState(path string) (*State, error) {
+	if path == "" {
+		path = DefaultStatePath()
+	}
+	state := &State{path: path}
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("state: %w", err)
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("state: %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Reset clears the saved view state, and the session of the cluster context,
+// keeping the sessions of the other contexts and the file path
+func (s *State) Reset(context string) {
+	if s.Context != "" && s.Context != context {
+		// keeps the namespace of the single context saved before the sessions per context
+		s.Session(s.Context)
+	}
+	contexts := s.Contexts
+	delete(contexts, context)
+	*s = State{Contexts: contexts, path: s.path}
+}
+
+// Path returns the path of the state file
+func (s *State) Path() string {
+	if s.path == "" {
+		return DefaultStatePath()
+	}
+	return s.path
+}
+
+// Save writes the state to its file, creating the file directory if needed
+func (s *State) Save() error {
+	path := s.Path()
+	if path == "" {
+		return fmt.Errorf("state: unknown state file path")
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStateSession(t *testing.T) {
+	state := &State{Context: "prod", Namespace: "payments"}
+
+	prod := state.Session("prod")
+	if prod.Namespace != "payments" {
+		t.Fatalf("expecting the namespace of the saved context, got %q", prod.Namespace)
+	}
+	prod.Page = "Right-sizing"
+	if state.Session("prod").Page != "Right-sizing" {
+		t.Fatal("expecting the same session for the context")
+	}
+	if dev := state.Session("dev"); dev.Namespace != "" || dev.Page != "" {
+		t.Fatalf("expecting an empty session for another context, got %+v", dev)
+	}
+	if len(state.Contexts) != 2 {
+		t.Fatalf("expecting 2 sessions, got %d", len(state.Contexts))
+	}
+}
+
+func TestStateSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ktop", "state.yaml")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("expecting an empty state for a missing file, got %s", err)
+	}
+	session := state.Session("prod")
+	session.Namespace = "payments"
+	session.SelectedPod = "payments/api-0"
+	session.PodOffset = 12
+	session.SelectedNode = "node-b"
+	session.NodeOffset = 3
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored := *loaded.Session("prod"); restored != *session {
+		t.Errorf("expecting session %+v, got %+v", *session, restored)
+	}
+	if len(loaded.Contexts) != 1 {
+		t.Errorf("expecting 1 session, got %d", len(loaded.Contexts))
+	}
+}
+
+func TestStateReset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Context, state.Namespace = "staging", "qa"
+	state.PodFilter = "web"
+	state.Session("prod").Namespace = "payments"
+	state.Session("dev").SelectedPod = "sandbox/web-0"
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	state.Reset("prod")
+	if state.PodFilter != "" || state.Context != "" || state.Namespace != "" {
+		t.Errorf("expecting the view state cleared, got %+v", state)
+	}
+	if _, ok := state.Contexts["prod"]; ok {
+		t.Error("expecting the session of the reset context cleared")
+	}
+	if dev := state.Contexts["dev"]; dev == nil || dev.SelectedPod != "sandbox/web-0" {
+		t.Errorf("expecting the session of another context kept, got %+v", dev)
+	}
+	if staging := state.Contexts["staging"]; staging == nil || staging.Namespace != "qa" {
+		t.Errorf("expecting the namespace of the single context saved kept, got %+v", staging)
+	}
+
+	// the state saved on exit keeps the sessions of the other contexts
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Contexts) != 2 || loaded.Session("dev").SelectedPod != "sandbox/web-0" {
+		t.Errorf("expecting the dev and staging sessions saved, got %+v", loaded.Contexts)
+	}
+}
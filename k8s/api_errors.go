@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+)
+
+// apiErrorTTL is how long a failed API call is reported after its last occurrence
+const apiErrorTTL = 5 * time.Minute
+
+// APIError summarizes the recent failed API calls for a resource
+type APIError struct {
+	Resource string // i.e. pods, nodes/metrics
+	Reason   string // forbidden, unauthorized, timeout, not found, or error
+	Message  string // message of the last error
+	Count    int
+	LastSeen time.Time
+}
+
+// apiErrors tracks the failed API calls per resource
+type apiErrors struct {
+	sync.Mutex
+	errors map[string]*APIError
+}
+
+// recordAPIError records a failed API call for resource. Errors caused by the shutdown
+// of ktop, by the normal end of a watch, or by an expired watch resource version (the
+// informer relists the resource) are ignored.
+func (c *Controller) recordAPIError(resource string, err error) {
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
+		return
+	}
+	if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		return
+	}
+	c.apiErrors.Lock()
+	defer c.apiErrors.Unlock()
+	apiErr, ok := c.apiErrors.errors[resource]
+	if !ok {
+		apiErr = &APIError{Resource: resource}
+		c.apiErrors.errors[resource] = apiErr
+	}
+	apiErr.Reason = apiErrorReason(err)
+	apiErr.Message = err.Error()
+	apiErr.Count++
+	apiErr.LastSeen = time.Now()
+}
+
+// GetAPIErrors returns the resources with failed API calls in the last apiErrorTTL, sorted by resource
+func (c *Controller) GetAPIErrors() []APIError {
+	c.apiErrors.Lock()
+	defer c.apiErrors.Unlock()
+	var result []APIError
+	for _, apiErr := range c.apiErrors.errors {
+		if time.Since(apiErr.LastSeen) <= apiErrorTTL {
+			result = append(result, *apiErr)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Resource < result[j].Resource
+	})
+	return result
+}
+
+// watchAPIErrors records the list and watch errors of the informer for resource.
+// It must be called before the informer is started.
+func (c *Controller) watchAPIErrors(resource string, informer cache.SharedInformer) {
+	handler := func(r *cache.Reflector, err error) {
+		c.recordAPIError(resource, err)
+		cache.DefaultWatchErrorHandler(r, err)
+	}
+	if err := informer.SetWatchErrorHandler(handler); err != nil {
+		c.recordAPIError(resource, err)
+	}
+}
+
+// apiErrorReason returns a short reason for err, i.e. forbidden
+func apiErrorReason(err error) string {
+	var netErr net.Error
+	switch {
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	case apierrors.IsUnauthorized(err):
+		return "unauthorized"
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case apierrors.IsNotFound(err):
+		return "not found"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRecordAPIError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name     string
+		err      error
+		recorded bool
+		reason   string
+	}{
+		{name: "forbidden", err: apierrors.NewForbidden(pods, "", fmt.Errorf("denied")), recorded: true, reason: "forbidden"},
+		{name: "timeout", err: apierrors.NewTimeoutError("slow", 1), recorded: true, reason: "timeout"},
+		{name: "resource version too old", err: apierrors.NewResourceExpired("too old resource version: 1 (2)")},
+		{name: "gone", err: apierrors.NewGone("gone")},
+		{name: "end of watch", err: io.EOF},
+		{name: "canceled", err: context.Canceled},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			c := newController(nil)
+			c.recordAPIError("pods", tc.err)
+			errs := c.GetAPIErrors()
+			if !tc.recorded {
+				if len(errs) != 0 {
+					t.Fatalf("expecting no API error, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("expecting 1 API error, got %d", len(errs))
+			}
+			if errs[0].Reason != tc.reason || errs[0].Count != 1 {
+				t.Errorf("expecting reason %s and count 1, got %s and %d", tc.reason, errs[0].Reason, errs[0].Count)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// clusterAutoscalerNamespace and clusterAutoscalerStatusName locate the Cluster Autoscaler status ConfigMap
+	clusterAutoscalerNamespace  = "kube-system"
+	clusterAutoscalerStatusName = "cluster-autoscaler-status"
+)
+
+// GetAutoscalingModel returns the node autoscaling activity of the cluster: the Cluster
+// Autoscaler status, read from its status ConfigMap, the Karpenter NodeClaims, and the
+// autoscaling events. The ConfigMap and NodeClaims are read from the API server.
+func (c *Controller) GetAutoscalingModel(ctx context.Context) (model.AutoscalingModel, error) {
+	if ctx.Err() != nil {
+		return model.AutoscalingModel{}, ctx.Err()
+	}
+
+	var autoscalers []string
+	var status []model.AutoscalerStatus
+	configMap, err := c.client.kubeClient.CoreV1().ConfigMaps(clusterAutoscalerNamespace).Get(ctx, clusterAutoscalerStatusName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		autoscalers = append(autoscalers, model.AutoscalerClusterAutoscaler)
+		status = model.ParseClusterAutoscalerStatus(configMap.Data["status"])
+	case !errors.IsNotFound(err):
+		c.recordAPIError("configmaps", err)
+	}
+
+	nodeClaims, karpenter, err := c.client.GetNodeClaims(ctx)
+	if err != nil {
+		c.recordAPIError("nodeclaims", err)
+	}
+	if karpenter {
+		autoscalers = append(autoscalers, model.AutoscalerKarpenter)
+	}
+
+	nodes, err := c.GetNodeList(ctx)
+	if err != nil {
+		return model.AutoscalingModel{}, err
+	}
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return model.AutoscalingModel{}, err
+	}
+	events, err := c.GetEventList(ctx)
+	if err != nil {
+		return model.AutoscalingModel{}, err
+	}
+
+	var activities []model.AutoscalingActivity
+	podActivities := make(map[string]model.AutoscalingActivity)
+	for _, event := range events {
+		activity, ok := model.NewAutoscalingActivity(event, EventTime(event))
+		if !ok {
+			continue
+		}
+		activities = append(activities, activity)
+		if obj := event.InvolvedObject; obj.Kind == "Pod" {
+			key := obj.Namespace + "/" + obj.Name
+			if last, ok := podActivities[key]; !ok || activity.Time.After(last.Time) {
+				podActivities[key] = activity
+			}
+		}
+	}
+
+	return model.NewAutoscalingModel(autoscalers, status, nodeClaims, nodes, pods, activities, podActivities), nil
+}
@@ -3,17 +3,21 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	appsV1 "k8s.io/api/apps/v1"
 	authzV1 "k8s.io/api/authorization/v1"
 	batchV1 "k8s.io/api/batch/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	policyV1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
@@ -25,6 +29,9 @@ import (
 
 const (
 	AllNamespaces = metav1.NamespaceAll
+
+	// discoveryBurst is the burst of the discovery client, which sends a request per API group
+	discoveryBurst = 100
 )
 
 var (
@@ -41,15 +48,22 @@ var (
 		"statefulsets":           {Group: appsV1.GroupName, Version: "v1", Resource: "statefulsets"},
 		"jobs":                   {Group: batchV1.GroupName, Version: "v1", Resource: "jobs"},
 		"cronjobs":               {Group: batchV1.GroupName, Version: "v1", Resource: "cronjobs"},
+		"events":                 {Group: "", Version: "v1", Resource: "events"},
+		"services":               {Group: "", Version: "v1", Resource: "services"},
+		"endpoints":              {Group: "", Version: "v1", Resource: "endpoints"},
+		"resourcequotas":         {Group: "", Version: "v1", Resource: "resourcequotas"},
+		"ingresses":              {Group: networkingV1.GroupName, Version: "v1", Resource: "ingresses"},
+		"poddisruptionbudgets":   {Group: policyV1.GroupName, Version: "v1", Resource: "poddisruptionbudgets"},
+		"nodes/metrics":          {Group: metricsapi.GroupName, Version: "v1beta1", Resource: "nodes"},
+		"pods/metrics":           {Group: metricsapi.GroupName, Version: "v1beta1", Resource: "pods"},
 	}
-
-	authzdTable = make(map[string]bool)
 )
 
 type Client struct {
 	sync.RWMutex
 	clusterVersion    *version.Info
 	namespace         string
+	namespaces        []string // namespaces of namespace, or AllNamespaces only
 	config            *restclient.Config
 	apiConfig         api.Config
 	clusterContext    string
@@ -58,10 +72,15 @@ type Client struct {
 	discoClient       discovery.CachedDiscoveryInterface
 	metricsClient     *metricsclient.Clientset
 	metricsAvailCount int
+	kubeletStats      bool
+	cpuThrottling     bool
 	refreshTimeout    time.Duration
 	controller        *Controller
+	authzdTable       map[string]bool
+	auth              *authState
 }
 
+// New creates a Client from the provided kubectl-style configuration flags
 func New(flags *genericclioptions.ConfigFlags) (*Client, error) {
 	if flags == nil {
 		return nil, fmt.Errorf("configuration flagset is nil")
@@ -72,22 +91,40 @@ func New(flags *genericclioptions.ConfigFlags) (*Client, error) {
 		return nil, err
 	}
 
+	// detect expired credentials; a static token is reloaded from the kubeconfig, with the
+	// context, cluster, and user of the flags, unless it is set with --token
+	auth := new(authState)
+	if config.BearerToken != "" && stringValue(flags.BearerToken) == "" {
+		auth.reload = func() (string, error) {
+			reloaded, err := loadConfig(flags)
+			if err != nil {
+				return "", err
+			}
+			return reloaded.BearerToken, nil
+		}
+	}
+	config.Wrap(auth.wrap)
+
 	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 
-	disco, err := flags.ToDiscoveryClient()
+	// the discovery client shares the wrapped configuration, to reload expired credentials too
+	discoConfig := restclient.CopyConfig(config)
+	discoConfig.Burst = discoveryBurst
+	discoClient, err := discovery.NewDiscoveryClientForConfig(discoConfig)
 	if err != nil {
 		return nil, err
 	}
+	disco := memory.NewMemCacheClient(discoClient)
 
 	metrics, err := metricsclient.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 
-	var namespace = *flags.Namespace
+	var namespaces = splitNamespaces(*flags.Namespace)
 
 	apiCfg, err := flags.ToRawKubeConfigLoader().RawConfig()
 	if err != nil {
@@ -108,7 +145,8 @@ func New(flags *genericclioptions.ConfigFlags) (*Client, error) {
 
 	client := &Client{
 		clusterVersion: version,
-		namespace:      namespace,
+		namespace:      strings.Join(namespaces, ","),
+		namespaces:     namespaces,
 		config:         config,
 		apiConfig:      apiCfg,
 		clusterContext: apiCfg.CurrentContext,
@@ -116,15 +154,44 @@ func New(flags *genericclioptions.ConfigFlags) (*Client, error) {
 		kubeClient:     kubeClient,
 		discoClient:    disco,
 		metricsClient:  metrics,
+		authzdTable:    make(map[string]bool),
+		auth:           auth,
 	}
 	client.controller = newController(client)
 	return client, nil
 }
 
+// Namespace returns the namespace scope of the client: AllNamespaces, a namespace, or a
+// comma-separated list of namespaces
 func (k8s *Client) Namespace() string {
 	return k8s.namespace
 }
 
+// Namespaces returns the namespaces of the client scope, one informer is created for each.
+// It returns AllNamespaces only when the client is not scoped to namespaces.
+func (k8s *Client) Namespaces() []string {
+	return k8s.namespaces
+}
+
+// splitNamespaces returns the namespaces of the comma-separated list namespace, trimmed and
+// without duplicates, or AllNamespaces only for an empty list
+func splitNamespaces(namespace string) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, ns := range strings.Split(namespace, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	if len(namespaces) == 0 {
+		return []string{AllNamespaces}
+	}
+	return namespaces
+}
+
 func (k8s *Client) RESTConfig() *restclient.Config {
 	return k8s.config
 }
@@ -137,13 +204,69 @@ func (k8s *Client) Username() string {
 	return k8s.username
 }
 
+// Impersonation returns the user, and groups, impersonated on each request (--as, --as-group,
+// or the kubeconfig), i.e. "jane (groups: dev,qa)". It is empty when not impersonating.
+func (k8s *Client) Impersonation() string {
+	imp := k8s.config.Impersonate
+	if imp.UserName == "" && imp.UID == "" && len(imp.Groups) == 0 {
+		return ""
+	}
+	user := imp.UserName
+	if user == "" && imp.UID != "" {
+		user = "uid:" + imp.UID
+	}
+	groups := strings.Join(imp.Groups, ",")
+	switch {
+	case groups == "":
+		return user
+	case user == "":
+		return "groups: " + groups
+	default:
+		return fmt.Sprintf("%s (groups: %s)", user, groups)
+	}
+}
+
 func (k8s *Client) GetServerVersion() string {
 	return k8s.clusterVersion.String()
 }
 
-// AssertMetricsAvailable checks for available metrics server every 10th invocation.
-// Otherwise, it returns the last known registration state of metrics server.
+// SetKubeletStatsFallback enables, or disables, the use of the kubelet stats summary,
+// through the API server node proxy, for usage metrics when metrics server is not available.
+// It must be called prior to starting the controller.
+func (k8s *Client) SetKubeletStatsFallback(enabled bool) {
+	k8s.kubeletStats = enabled
+}
+
+// SetCPUThrottling enables, or disables, the scraping of the container CPU throttling from
+// the kubelet cAdvisor metrics, through the API server node proxy.
+// It must be called prior to starting the controller.
+func (k8s *Client) SetCPUThrottling(enabled bool) {
+	k8s.cpuThrottling = enabled
+}
+
+// CPUThrottlingEnabled returns true if the container CPU throttling is scraped from the kubelets
+func (k8s *Client) CPUThrottlingEnabled() bool {
+	return k8s.cpuThrottling
+}
+
+// UsingKubeletStats returns true if usage metrics are collected from the kubelet stats
+// summary, in place of metrics server.
+func (k8s *Client) UsingKubeletStats() bool {
+	return k8s.controller.kubeletStats != nil
+}
+
+// AssertMetricsAvailable returns nil if usage metrics are available, either from
+// metrics server or, when enabled and started, from the kubelet stats fallback.
 func (k8s *Client) AssertMetricsAvailable() error {
+	if k8s.UsingKubeletStats() {
+		return nil
+	}
+	return k8s.assertMetricsServer()
+}
+
+// assertMetricsServer checks for available metrics server every 10th invocation.
+// Otherwise, it returns the last known registration state of metrics server.
+func (k8s *Client) assertMetricsServer() error {
 	if k8s.metricsAvailCount != 0 {
 		if k8s.metricsAvailCount%10 != 0 {
 			k8s.metricsAvailCount++
@@ -175,6 +298,7 @@ func (k8s *Client) AssertMetricsAvailable() error {
 	return nil
 }
 
+// Controller returns the controller used to collect cluster data
 func (k8s *Client) Controller() *Controller {
 	return k8s.controller
 }
@@ -189,11 +313,11 @@ func (k8s *Client) IsAuthz(ctx context.Context, resource string, verbs []string)
 		return false, fmt.Errorf("unsupported resource %s", resource)
 	}
 
-	makeAccessReview := func(grv schema.GroupVersionResource, verb string) *authzV1.SelfSubjectAccessReview {
+	makeAccessReview := func(grv schema.GroupVersionResource, namespace, verb string) *authzV1.SelfSubjectAccessReview {
 		return &authzV1.SelfSubjectAccessReview{
 			Spec: authzV1.SelfSubjectAccessReviewSpec{
 				ResourceAttributes: &authzV1.ResourceAttributes{
-					Namespace: k8s.Namespace(),
+					Namespace: namespace,
 					Group:     grv.Group,
 					Version:   grv.Version,
 					Resource:  grv.Resource,
@@ -205,21 +329,24 @@ func (k8s *Client) IsAuthz(ctx context.Context, resource string, verbs []string)
 
 	arClient := k8s.kubeClient.AuthorizationV1().SelfSubjectAccessReviews()
 	result := true
-	for _, verb := range verbs {
-		key := fmt.Sprintf("%s/%s/%s", gvr.String(), k8s.Namespace(), verb)
-		if authzd, ok := authzdTable[key]; ok {
-			result = result && authzd
-			continue
-		}
-		ar := makeAccessReview(gvr, verb)
-		arResult, err := arClient.Create(ctx, ar, metav1.CreateOptions{})
-		if err != nil {
-			delete(authzdTable, key)
-			return false, err
+	// the resource must be accessible in each namespace of the client scope
+	for _, namespace := range k8s.namespaces {
+		for _, verb := range verbs {
+			key := fmt.Sprintf("%s/%s/%s", gvr.String(), namespace, verb)
+			if authzd, ok := k8s.authzdTable[key]; ok {
+				result = result && authzd
+				continue
+			}
+			ar := makeAccessReview(gvr, namespace, verb)
+			arResult, err := arClient.Create(ctx, ar, metav1.CreateOptions{})
+			if err != nil {
+				delete(k8s.authzdTable, key)
+				return false, err
+			}
+			allowed := arResult.Status.Allowed
+			k8s.authzdTable[key] = allowed
+			result = result && allowed
 		}
-		allowed := arResult.Status.Allowed
-		authzdTable[key] = allowed
-		result = result && allowed
 	}
 
 	return result, nil
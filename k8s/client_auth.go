@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"net/http"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// AuthStateFunc is called when requests start failing authentication (authenticated is false),
+// then when a request succeeds again after re-authentication (authenticated is true).
+type AuthStateFunc func(authenticated bool)
+
+// authState detects expired credentials, from 401 responses, and recovers from them.
+// Credentials from an exec plugin (i.e. EKS, GKE, AKS) are refreshed by client-go, which re-runs
+// the plugin after a 401 response; a static token is reloaded from the kubeconfig, in case it
+// was rotated, and replaces the expired one on the following requests.
+type authState struct {
+	sync.Mutex
+	failing   bool
+	reloading bool
+	token     string // reloaded bearer token, replacing the configured one when set
+	stateFunc AuthStateFunc
+
+	// reload returns the bearer token of the reloaded kubeconfig
+	reload func() (string, error)
+}
+
+// authRoundTripper reports the authentication failures, and recoveries, of the requests
+type authRoundTripper struct {
+	auth *authState
+	rt   http.RoundTripper
+}
+
+func (a *authState) wrap(rt http.RoundTripper) http.RoundTripper {
+	return &authRoundTripper{auth: a, rt: rt}
+}
+
+func (r *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.auth.Lock()
+	token := r.auth.token
+	r.auth.Unlock()
+	if token != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.rt.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		r.auth.unauthorized()
+	} else if resp.StatusCode < http.StatusBadRequest {
+		r.auth.authorized()
+	}
+	return resp, nil
+}
+
+// unauthorized marks the credentials as expired and, once, reloads the kubeconfig token
+func (a *authState) unauthorized() {
+	a.Lock()
+	notify := !a.failing
+	a.failing = true
+	reload := a.reload != nil && !a.reloading
+	if reload {
+		a.reloading = true
+	}
+	stateFunc := a.stateFunc
+	a.Unlock()
+
+	if notify {
+		klog.V(2).Info("authentication failed: refreshing credentials")
+		if stateFunc != nil {
+			go stateFunc(false)
+		}
+	}
+	if reload {
+		go a.reloadToken()
+	}
+}
+
+// authorized marks the credentials as valid, notifying the re-authentication after failures
+func (a *authState) authorized() {
+	a.Lock()
+	recovered := a.failing
+	a.failing = false
+	stateFunc := a.stateFunc
+	a.Unlock()
+
+	if recovered {
+		klog.V(2).Info("re-authenticated")
+		if stateFunc != nil {
+			go stateFunc(true)
+		}
+	}
+}
+
+func (a *authState) reloadToken() {
+	token, err := a.reload()
+	a.Lock()
+	defer a.Unlock()
+	a.reloading = false
+	if err != nil {
+		klog.V(2).Infof("authentication: reloading kubeconfig: %s", err)
+		return
+	}
+	if token != "" {
+		a.token = token
+	}
+}
+
+// SetAuthStateFunc registers the function called when the credentials expire, and
+// when requests are authenticated again.
+func (k8s *Client) SetAuthStateFunc(fn AuthStateFunc) {
+	k8s.auth.Lock()
+	defer k8s.auth.Unlock()
+	k8s.auth.stateFunc = fn
+}
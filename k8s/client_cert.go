@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// certRequestTimeout is the timeout of the request used to retrieve the API server certificate
+const certRequestTimeout = 5 * time.Second
+
+// ServerCertExpiry returns the expiry date (notAfter) of the certificate served by the API server.
+// The certificate is retrieved with a request, rather than a plain TLS connection, so that the
+// configured proxy, if any, is used. The certificate is verified against the configured CA, and
+// server name, as of a time within its validity, so that the expiry of an expired certificate,
+// which fails the standard verification, is still returned.
+func (k8s *Client) ServerCertExpiry(ctx context.Context) (time.Time, error) {
+	host, err := url.Parse(k8s.config.Host)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if host.Scheme != "https" {
+		return time.Time{}, fmt.Errorf("API server %s does not use TLS", k8s.config.Host)
+	}
+
+	tlsConfig, err := restclient.TLSConfigFor(k8s.config)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if !tlsConfig.InsecureSkipVerify {
+		serverName := tlsConfig.ServerName
+		if serverName == "" {
+			serverName = host.Hostname()
+		}
+		// the standard verification is replaced by the one tolerating the expiry
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = verifyIgnoringExpiry(tlsConfig.RootCAs, serverName)
+	}
+	proxy := k8s.config.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	client := &http.Client{
+		Timeout:   certRequestTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: proxy},
+	}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, host.String(), nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return time.Time{}, fmt.Errorf("API server %s sent no certificate", k8s.config.Host)
+	}
+	return resp.TLS.PeerCertificates[0].NotAfter, nil
+}
+
+// verifyIgnoringExpiry returns a connection verification of the server certificate chain against
+// roots (the system roots when nil), for serverName, ignoring the expiry of the certificate only:
+// the chain is verified as of now or, outside the certificate validity, as of its expiry.
+// The server certificate is verified before the client certificate, if any, is sent.
+func verifyIgnoringExpiry(roots *x509.CertPool, serverName string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("server sent no certificate")
+		}
+		leaf := state.PeerCertificates[0]
+		intermediates := x509.NewCertPool()
+		for _, cert := range state.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		verifyTime := time.Now()
+		if verifyTime.Before(leaf.NotBefore) || verifyTime.After(leaf.NotAfter) {
+			verifyTime = leaf.NotAfter
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			DNSName:       serverName,
+			CurrentTime:   verifyTime,
+		})
+		return err
+	}
+}
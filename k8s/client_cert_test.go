@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// testCert returns a self-signed certificate for 127.0.0.1 expiring at notAfter
+func testCert(t *testing.T, notAfter time.Time) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kube-apiserver"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestServerCertExpiry(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name       string
+		notAfter   time.Time
+		untrusted  bool   // the certificate is not signed by the configured CA
+		serverName string // the server name the certificate is verified for
+		insecure   bool
+		shouldErr  bool
+	}{
+		{name: "valid", notAfter: now.Add(90 * 24 * time.Hour)},
+		{name: "expired", notAfter: now.Add(-48 * time.Hour)},
+		{name: "untrusted", notAfter: now.Add(90 * 24 * time.Hour), untrusted: true, shouldErr: true},
+		{name: "expired untrusted", notAfter: now.Add(-48 * time.Hour), untrusted: true, shouldErr: true},
+		{name: "other server name", notAfter: now.Add(90 * 24 * time.Hour), serverName: "kubernetes.default", shouldErr: true},
+		{name: "insecure", notAfter: now.Add(90 * 24 * time.Hour), untrusted: true, insecure: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			cert := testCert(t, tc.notAfter)
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+			srv.StartTLS()
+			defer srv.Close()
+
+			// the self-signed certificate is its own CA
+			ca := cert.Certificate[0]
+			if tc.untrusted {
+				ca = testCert(t, tc.notAfter).Certificate[0]
+			}
+			config := &restclient.Config{Host: srv.URL, TLSClientConfig: restclient.TLSClientConfig{
+				CAData:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca}),
+				ServerName: tc.serverName,
+			}}
+			if tc.insecure {
+				config.TLSClientConfig = restclient.TLSClientConfig{Insecure: true}
+			}
+			client := &Client{config: config}
+			expiry, err := client.ServerCertExpiry(context.Background())
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatal("expecting an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !expiry.Equal(tc.notAfter.UTC()) {
+				t.Errorf("expecting expiry %s, got %s", tc.notAfter.UTC(), expiry)
+			}
+		})
+	}
+}
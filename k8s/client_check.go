@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metricsapi "k8s.io/metrics/pkg/apis/metrics"
+)
+
+// CheckResult is the outcome of a preflight check of the cluster access needed by ktop
+type CheckResult struct {
+	Name string
+	OK   bool
+	// Optional is set for checks that only leave some data missing when they fail
+	Optional bool
+	Detail   string
+}
+
+// checkedResource is a resource read by ktop along with what is missing without access to it
+type checkedResource struct {
+	resource string
+	required bool
+	missing  string
+}
+
+// checkedResources are the resources watched, or listed, by ktop
+var checkedResources = []checkedResource{
+	{resource: "namespaces", required: true, missing: "ktop does not start"},
+	{resource: "nodes", required: true, missing: "ktop does not start"},
+	{resource: "pods", required: true, missing: "ktop does not start"},
+	{resource: "events", missing: "no events in descriptions, focus view, and autoscaling view"},
+	{resource: "persistentvolumes", missing: "no PV count in the cluster summary"},
+	{resource: "persistentvolumeclaims", missing: "no PVC count in the cluster summary"},
+	{resource: "services", missing: "no services view"},
+	{resource: "endpoints", missing: "no endpoint health in the services and ingresses views"},
+	{resource: "resourcequotas", missing: "no resource quotas in the cluster summary"},
+	{resource: "deployments", missing: "no deployment count in the cluster summary"},
+	{resource: "daemonsets", missing: "no daemon set count in the cluster summary"},
+	{resource: "replicasets", missing: "no replica set count in the cluster summary"},
+	{resource: "statefulsets", missing: "no stateful set count in the cluster summary"},
+	{resource: "jobs", missing: "no job count in the cluster summary"},
+	{resource: "cronjobs", missing: "no cron job count in the cluster summary"},
+	{resource: "ingresses", missing: "no ingresses view"},
+	{resource: "poddisruptionbudgets", missing: "no pod disruption budgets view"},
+}
+
+// Check verifies the API server version, the list and watch authorizations for each resource
+// read by ktop, and the metrics server availability. The checks are run in the client
+// namespace; a failed check that is not optional prevents ktop from running.
+func (k8s *Client) Check(ctx context.Context) []CheckResult {
+	results := []CheckResult{{
+		Name:   "API server",
+		OK:     true,
+		Detail: fmt.Sprintf("%s, version %s", k8s.config.Host, k8s.GetServerVersion()),
+	}}
+
+	verbs := []string{"list", "watch"}
+	for _, res := range checkedResources {
+		result := CheckResult{Name: res.resource, Optional: !res.required}
+		authzd, err := k8s.IsAuthz(ctx, res.resource, verbs)
+		switch {
+		case err != nil:
+			result.Detail = fmt.Sprintf("access review failed: %s", err)
+		case !authzd:
+			result.Detail = fmt.Sprintf("%s forbidden: %s", strings.Join(verbs, "/"), res.missing)
+		default:
+			result.OK = true
+			result.Detail = strings.Join(verbs, "/") + " allowed"
+		}
+		results = append(results, result)
+	}
+
+	return append(results, k8s.checkMetrics(ctx)...)
+}
+
+// checkMetrics verifies that the metrics API is served and that node, and pod, metrics can be listed
+func (k8s *Client) checkMetrics(ctx context.Context) []CheckResult {
+	result := CheckResult{Name: "metrics-server", Optional: true}
+	if err := k8s.assertMetricsServer(); err != nil {
+		result.Detail = fmt.Sprintf("%s: usage graphs show requests and limits only (see --kubelet-stats)", err)
+		return []CheckResult{result}
+	}
+	result.OK = true
+	result.Detail = metricsapi.GroupName + " available"
+	results := []CheckResult{result}
+
+	for _, res := range []string{"nodes/metrics", "pods/metrics"} {
+		result := CheckResult{Name: res, Optional: true}
+		authzd, err := k8s.IsAuthz(ctx, res, []string{"list"})
+		switch {
+		case err != nil:
+			result.Detail = fmt.Sprintf("access review failed: %s", err)
+		case !authzd:
+			result.Detail = "list forbidden: no usage metrics"
+		default:
+			result.OK = true
+			result.Detail = "list allowed"
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -3,93 +3,190 @@ package k8s
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vladimirvivien/ktop/views/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
-	appsV1Informers "k8s.io/client-go/informers/apps/v1"
-	batchV1Informers "k8s.io/client-go/informers/batch/v1"
 	coreV1Informers "k8s.io/client-go/informers/core/v1"
+	appsV1Listers "k8s.io/client-go/listers/apps/v1"
+	batchV1Listers "k8s.io/client-go/listers/batch/v1"
+	coreV1Listers "k8s.io/client-go/listers/core/v1"
+	networkingV1Listers "k8s.io/client-go/listers/networking/v1"
+	policyV1Listers "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 )
 
+// DefaultResyncPeriod is the default resync period of the controller informers
+const DefaultResyncPeriod = 10 * time.Second
+
+// Intervals between two refreshes of the registered refresh functions, set with
+// SetRefreshInterval before the controller is started
+var (
+	PodsRefreshInterval    = 3 * time.Second
+	NodesRefreshInterval   = 5 * time.Second
+	SummaryRefreshInterval = 5 * time.Second
+)
+
+// SetRefreshInterval sets the interval between two refreshes of the pods, nodes, and summary
+func SetRefreshInterval(interval time.Duration) {
+	PodsRefreshInterval, NodesRefreshInterval, SummaryRefreshInterval = interval, interval, interval
+}
+
+// RefreshNodesFunc is called with the latest node models on each node refresh
 type RefreshNodesFunc func(ctx context.Context, items []model.NodeModel) error
+
+// RefreshPodsFunc is called with the latest pod models on each pod refresh
 type RefreshPodsFunc func(ctx context.Context, items []model.PodModel) error
+
+// RefreshSummaryFunc is called with the latest cluster summary on each summary refresh
 type RefreshSummaryFunc func(ctx context.Context, items model.ClusterSummary) error
 
+// Controller collects cluster data using shared informers and converts it into
+// the models found in package views/model. The data can either be pulled, using
+// the Get* methods, or pushed periodically to the registered refresh functions.
+// A Controller is obtained from Client.Controller and must be started before use.
+//
+// The namespaced resources are cached by an informer per namespace of the client scope,
+// and read through listers over the informers of all the namespaces.
 type Controller struct {
 	client *Client
 
 	nodeMetricsInformer *NodeMetricsInformer
-	podMetricsInformer  *PodMetricsInformer
+	podMetricsLister    *PodMetricsLister
+	kubeletStats        *kubeletStats  // set when metrics are scraped from kubelets instead
+	cpuThrottling       *cpuThrottling // set when the CPU throttling is scraped from kubelets
 	namespaceInformer   coreV1Informers.NamespaceInformer
 	nodeInformer        coreV1Informers.NodeInformer
-	podInformer         coreV1Informers.PodInformer
 	pvInformer          coreV1Informers.PersistentVolumeInformer
-	pvcInformer         coreV1Informers.PersistentVolumeClaimInformer
 
-	jobInformer     batchV1Informers.JobInformer
-	cronJobInformer batchV1Informers.CronJobInformer
+	podLister       coreV1Listers.PodLister
+	pvcLister       coreV1Listers.PersistentVolumeClaimLister
+	eventLister     coreV1Listers.EventLister
+	serviceLister   coreV1Listers.ServiceLister
+	endpointsLister coreV1Listers.EndpointsLister
+	quotaLister     coreV1Listers.ResourceQuotaLister
+
+	jobLister     batchV1Listers.JobLister
+	cronJobLister batchV1Listers.CronJobLister
+
+	deploymentLister  appsV1Listers.DeploymentLister
+	daemonSetLister   appsV1Listers.DaemonSetLister
+	replicaSetLister  appsV1Listers.ReplicaSetLister
+	statefulSetLister appsV1Listers.StatefulSetLister
+
+	ingressLister networkingV1Listers.IngressLister
 
-	deploymentInformer  appsV1Informers.DeploymentInformer
-	daemonSetInformer   appsV1Informers.DaemonSetInformer
-	replicaSetInformer  appsV1Informers.ReplicaSetInformer
-	statefulSetInformer appsV1Informers.StatefulSetInformer
+	pdbLister policyV1Listers.PodDisruptionBudgetLister
 
 	nodeRefreshFunc    RefreshNodesFunc
 	podRefreshFunc     RefreshPodsFunc
 	summaryRefreshFunc RefreshSummaryFunc
+
+	apiErrors apiErrors
+
+	start sync.Once
+	ready chan struct{}
+}
+
+// allowWatchBookmarks requests bookmark events on the informers watches
+func allowWatchBookmarks(options *metav1.ListOptions) {
+	options.AllowWatchBookmarks = true
 }
 
 func newController(client *Client) *Controller {
-	ctrl := &Controller{client: client}
+	ctrl := &Controller{client: client, ready: make(chan struct{})}
+	ctrl.apiErrors.errors = make(map[string]*APIError)
 	return ctrl
 }
 
+// Ready returns a channel that is closed once the controller is started
+// and core resources are synced.
+func (c *Controller) Ready() <-chan struct{} {
+	return c.ready
+}
+
+// SetNodeRefreshFunc registers the function called on each node refresh.
+// It must be called prior to Start.
 func (c *Controller) SetNodeRefreshFunc(fn RefreshNodesFunc) *Controller {
 	c.nodeRefreshFunc = fn
 	return c
 }
+
+// SetPodRefreshFunc registers the function called on each pod refresh.
+// It must be called prior to Start.
 func (c *Controller) SetPodRefreshFunc(fn RefreshPodsFunc) *Controller {
 	c.podRefreshFunc = fn
 	return c
 }
 
+// SetClusterSummaryRefreshFunc registers the function called on each summary refresh.
+// It must be called prior to Start.
 func (c *Controller) SetClusterSummaryRefreshFunc(fn RefreshSummaryFunc) *Controller {
 	c.summaryRefreshFunc = fn
 	return c
 }
 
+// Start starts the informers, waits for core resources (namespaces, nodes, pods) to sync,
+// then starts the refresh loops of the registered refresh functions (if any).
+// The informers and refresh loops are stopped when ctx is done. A controller is started
+// once: another call returns an error.
 func (c *Controller) Start(ctx context.Context, resync time.Duration) error {
 	if ctx == nil {
 		return errors.New("context cannot be nil")
 	}
+	first := false
+	c.start.Do(func() { first = true })
+	if !first {
+		return errors.New("controller already started")
+	}
 
 	// initialize
 
-	if err := c.client.AssertMetricsAvailable(); err == nil {
+	metricsServerAvailable := c.client.assertMetricsServer() == nil
+	if metricsServerAvailable {
 		c.nodeMetricsInformer = NewNodeMetricsInformer(c.client.metricsClient, resync)
 		nodeMetricsInformerHasSynced := c.nodeMetricsInformer.Informer().HasSynced
 
-		c.podMetricsInformer = NewPodMetricsInformer(c.client.metricsClient, resync, c.client.namespace)
-		podMetricsInformerHasSynced := c.podMetricsInformer.Informer().HasSynced
+		var podMetricsInformers []cache.SharedIndexInformer
+		for _, namespace := range c.client.namespaces {
+			podMetricsInformers = append(podMetricsInformers, NewPodMetricsInformer(c.client.metricsClient, resync, namespace).Informer())
+		}
+		c.podMetricsLister = NewPodMetricsLister(newNamespacesIndexer(podMetricsInformers))
+		podMetricsInformerHasSynced := allSynced(podMetricsInformers)
+
+		c.watchAPIErrors("nodes/metrics", c.nodeMetricsInformer.Informer())
+		for _, informer := range podMetricsInformers {
+			c.watchAPIErrors("pods/metrics", informer)
+		}
 
 		go c.nodeMetricsInformer.Informer().Run(ctx.Done())
-		go c.podMetricsInformer.Informer().Run(ctx.Done())
+		for _, informer := range podMetricsInformers {
+			go informer.Run(ctx.Done())
+		}
 
 		if ok := cache.WaitForCacheSync(ctx.Done(), nodeMetricsInformerHasSynced, podMetricsInformerHasSynced); !ok {
-			panic("metrics resources failed to sync [nodes, pods, containers]")
+			return fmt.Errorf("metrics resources failed to sync [nodes, pods, containers]")
 		}
 
 	}
 
-	// initialize informer factories
-	var factory informers.SharedInformerFactory
-	if c.client.namespace == AllNamespaces {
-		factory = informers.NewSharedInformerFactory(c.client.kubeClient, resync)
-	} else {
-		factory = informers.NewSharedInformerFactoryWithOptions(c.client.kubeClient, resync, informers.WithNamespace(c.client.namespace))
+	// initialize an informer factory per namespace of the client scope; watch bookmarks let
+	// watches resume, after they expire, from a recent resource version instead of relisting
+	// all the objects
+	var factories []informers.SharedInformerFactory
+	for _, namespace := range c.client.namespaces {
+		options := []informers.SharedInformerOption{informers.WithTweakListOptions(allowWatchBookmarks)}
+		if namespace != AllNamespaces {
+			options = append(options, informers.WithNamespace(namespace))
+		}
+		factories = append(factories, informers.NewSharedInformerFactoryWithOptions(c.client.kubeClient, resync, options...))
 	}
+	// cluster-scoped resources are cached once, by the first factory
+	factory := factories[0]
 
 	// NOTE: the followings captures each informer
 	// and also calls Informer() method to register the cached type.
@@ -101,32 +198,93 @@ func (c *Controller) Start(ctx context.Context, resync time.Duration) error {
 	namespaceHasSynced := c.namespaceInformer.Informer().HasSynced
 	c.nodeInformer = coreInformers.Nodes()
 	nodeHasSynced := c.nodeInformer.Informer().HasSynced
-	c.podInformer = coreInformers.Pods()
-	podHasSynced := c.podInformer.Informer().HasSynced
 	c.pvInformer = coreInformers.PersistentVolumes()
 	pvHasSynced := c.pvInformer.Informer().HasSynced
-	c.pvcInformer = coreInformers.PersistentVolumeClaims()
-	pvcHasSynced := c.pvcInformer.Informer().HasSynced
+
+	podIndexer, podHasSynced := c.namespacedInformers("pods", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Pods().Informer()
+	})
+	c.podLister = coreV1Listers.NewPodLister(podIndexer)
+	pvcIndexer, pvcHasSynced := c.namespacedInformers("persistentvolumeclaims", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().PersistentVolumeClaims().Informer()
+	})
+	c.pvcLister = coreV1Listers.NewPersistentVolumeClaimLister(pvcIndexer)
+	// the events, read by the warning events alert and the autoscaling view, are only cached
+	// when they can be listed and watched, rather than failing the watches for the session
+	eventFactories := factories
+	if authzd, err := c.client.IsAuthz(ctx, "events", []string{"list", "watch"}); err != nil || !authzd {
+		klog.V(2).Info("events not cached: list, watch not authorized")
+		eventFactories = nil
+	}
+	eventIndexer, eventHasSynced := c.namespacedInformers("events", eventFactories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Events().Informer()
+	})
+	c.eventLister = coreV1Listers.NewEventLister(eventIndexer)
+	serviceIndexer, serviceHasSynced := c.namespacedInformers("services", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Services().Informer()
+	})
+	c.serviceLister = coreV1Listers.NewServiceLister(serviceIndexer)
+	endpointsIndexer, endpointsHasSynced := c.namespacedInformers("endpoints", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Endpoints().Informer()
+	})
+	c.endpointsLister = coreV1Listers.NewEndpointsLister(endpointsIndexer)
+	quotaIndexer, quotaHasSynced := c.namespacedInformers("resourcequotas", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().ResourceQuotas().Informer()
+	})
+	c.quotaLister = coreV1Listers.NewResourceQuotaLister(quotaIndexer)
 
 	// Apps/v1 Informers
-	appsInformers := factory.Apps().V1()
-	c.deploymentInformer = appsInformers.Deployments()
-	deploymentHasSynced := c.deploymentInformer.Informer().HasSynced
-	c.daemonSetInformer = appsInformers.DaemonSets()
-	daemonsetHasSynced := c.daemonSetInformer.Informer().HasSynced
-	c.replicaSetInformer = appsInformers.ReplicaSets()
-	replicasetHasSynced := c.replicaSetInformer.Informer().HasSynced
-	c.statefulSetInformer = appsInformers.StatefulSets()
-	statefulsetHasSynced := c.statefulSetInformer.Informer().HasSynced
+	deploymentIndexer, deploymentHasSynced := c.namespacedInformers("deployments", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().Deployments().Informer()
+	})
+	c.deploymentLister = appsV1Listers.NewDeploymentLister(deploymentIndexer)
+	daemonSetIndexer, daemonsetHasSynced := c.namespacedInformers("daemonsets", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().DaemonSets().Informer()
+	})
+	c.daemonSetLister = appsV1Listers.NewDaemonSetLister(daemonSetIndexer)
+	replicaSetIndexer, replicasetHasSynced := c.namespacedInformers("replicasets", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().ReplicaSets().Informer()
+	})
+	c.replicaSetLister = appsV1Listers.NewReplicaSetLister(replicaSetIndexer)
+	statefulSetIndexer, statefulsetHasSynced := c.namespacedInformers("statefulsets", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().StatefulSets().Informer()
+	})
+	c.statefulSetLister = appsV1Listers.NewStatefulSetLister(statefulSetIndexer)
 
 	// Batch informers
-	batchInformers := factory.Batch().V1()
-	c.jobInformer = batchInformers.Jobs()
-	jobHasSynced := c.jobInformer.Informer().HasSynced
-	c.cronJobInformer = batchInformers.CronJobs()
-	cronJobHasSynced := c.cronJobInformer.Informer().HasSynced
+	jobIndexer, jobHasSynced := c.namespacedInformers("jobs", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().Jobs().Informer()
+	})
+	c.jobLister = batchV1Listers.NewJobLister(jobIndexer)
+	cronJobIndexer, cronJobHasSynced := c.namespacedInformers("cronjobs", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().CronJobs().Informer()
+	})
+	c.cronJobLister = batchV1Listers.NewCronJobLister(cronJobIndexer)
 
-	factory.Start(ctx.Done())
+	// Networking informers
+	ingressIndexer, ingressHasSynced := c.namespacedInformers("ingresses", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Networking().V1().Ingresses().Informer()
+	})
+	c.ingressLister = networkingV1Listers.NewIngressLister(ingressIndexer)
+
+	// Policy informers
+	pdbIndexer, pdbHasSynced := c.namespacedInformers("poddisruptionbudgets", factories, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Policy().V1().PodDisruptionBudgets().Informer()
+	})
+	c.pdbLister = policyV1Listers.NewPodDisruptionBudgetLister(pdbIndexer)
+
+	// record the list and watch errors of each cluster-scoped informer
+	for resource, informer := range map[string]cache.SharedInformer{
+		"namespaces":        c.namespaceInformer.Informer(),
+		"nodes":             c.nodeInformer.Informer(),
+		"persistentvolumes": c.pvInformer.Informer(),
+	} {
+		c.watchAPIErrors(resource, informer)
+	}
+
+	for _, namespaceFactory := range factories {
+		namespaceFactory.Start(ctx.Done())
+	}
 
 	// wait immediately for core resources to syn
 	// wait for core resources to sync
@@ -135,7 +293,15 @@ func (c *Controller) Start(ctx context.Context, resync time.Duration) error {
 		nodeHasSynced,
 		podHasSynced,
 	); !ok {
-		panic("core resources failed to sync [namespaces, nodes, pods]")
+		return fmt.Errorf("core resources failed to sync [namespaces, nodes, pods]")
+	}
+
+	// fall back to kubelet stats, scraped from the synced nodes, when enabled
+	if !metricsServerAvailable && c.client.kubeletStats {
+		c.startKubeletStats(ctx)
+	}
+	if c.client.cpuThrottling {
+		c.startCPUThrottling(ctx)
 	}
 
 	// defer waiting for non-core resources to sync
@@ -143,15 +309,22 @@ func (c *Controller) Start(ctx context.Context, resync time.Duration) error {
 		ok := cache.WaitForCacheSync(ctx.Done(),
 			pvHasSynced,
 			pvcHasSynced,
+			eventHasSynced,
+			serviceHasSynced,
+			endpointsHasSynced,
+			quotaHasSynced,
 			deploymentHasSynced,
 			daemonsetHasSynced,
 			replicasetHasSynced,
 			statefulsetHasSynced,
 			jobHasSynced,
 			cronJobHasSynced,
+			ingressHasSynced,
+			pdbHasSynced,
 		)
-		if !ok {
-			panic("resource failed to sync")
+		// sync is interrupted when ctx is done, which is not an error
+		if !ok && ctx.Err() == nil {
+			klog.Error("non-core resources failed to sync")
 		}
 	}()
 
@@ -159,5 +332,31 @@ func (c *Controller) Start(ctx context.Context, resync time.Duration) error {
 	c.setupNodeHandler(ctx, c.nodeRefreshFunc)
 	c.installPodsHandler(ctx, c.podRefreshFunc)
 
+	close(c.ready)
 	return nil
 }
+
+// namespacedInformers creates, with newInformer, the informer of the namespaced resource from
+// each factory, one per namespace of the client scope, and records their list and watch errors.
+// It returns the indexer over the informers, for a lister, and their sync function.
+func (c *Controller) namespacedInformers(resource string, factories []informers.SharedInformerFactory, newInformer func(informers.SharedInformerFactory) cache.SharedIndexInformer) (cache.Indexer, cache.InformerSynced) {
+	var created []cache.SharedIndexInformer
+	for _, factory := range factories {
+		informer := newInformer(factory)
+		c.watchAPIErrors(resource, informer)
+		created = append(created, informer)
+	}
+	return newNamespacesIndexer(created), allSynced(created)
+}
+
+// allSynced returns a sync function returning true once all the informers are synced
+func allSynced(all []cache.SharedIndexInformer) cache.InformerSynced {
+	return func() bool {
+		for _, informer := range all {
+			if !informer.HasSynced() {
+				return false
+			}
+		}
+		return true
+	}
+}
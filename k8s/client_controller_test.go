@@ -0,0 +1,15 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestControllerStartOnce(t *testing.T) {
+	ctrl := newController(&Client{})
+	// the controller is started: the second Start call returns before using the client
+	ctrl.start.Do(func() {})
+	if err := ctrl.Start(context.Background(), DefaultResyncPeriod); err == nil {
+		t.Fatal("expecting an error starting the controller again, got none")
+	}
+}
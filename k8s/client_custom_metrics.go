@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	customMetricsPath   = "/apis/custom.metrics.k8s.io/v1beta1"
+	externalMetricsPath = "/apis/external.metrics.k8s.io/v1beta1"
+)
+
+// metricValueList is the subset, used by ktop, of the custom and external metrics API value lists
+type metricValueList struct {
+	Items []struct {
+		Value resource.Quantity `json:"value"`
+	} `json:"items"`
+}
+
+// GetCustomMetric returns the value of metric, from the custom metrics API, for the named object
+// of the specified resource (i.e. pods, nodes). An empty namespace designates a cluster-scoped object.
+// The metricSelector is a label selector, that can be empty, narrowing down the metric series.
+func (k8s *Client) GetCustomMetric(ctx context.Context, resourceName, namespace, name, metric, metricSelector string) (*resource.Quantity, error) {
+	objPath := path.Join(customMetricsPath, resourceName, name, metric)
+	if namespace != "" {
+		objPath = path.Join(customMetricsPath, "namespaces", namespace, resourceName, name, metric)
+	}
+	sum, err := k8s.getMetricValues(ctx, objPath, metricSelector)
+	if err != nil {
+		return nil, fmt.Errorf("custom metric %s: %w", metric, err)
+	}
+	return sum, nil
+}
+
+// GetExternalMetric returns the sum of the values of metric, from the external metrics API,
+// matching metricSelector in namespace.
+func (k8s *Client) GetExternalMetric(ctx context.Context, namespace, metric, metricSelector string) (*resource.Quantity, error) {
+	sum, err := k8s.getMetricValues(ctx, path.Join(externalMetricsPath, "namespaces", namespace, metric), metricSelector)
+	if err != nil {
+		return nil, fmt.Errorf("external metric %s: %w", metric, err)
+	}
+	return sum, nil
+}
+
+// getMetricValues returns the sum of the metric values listed at the API path
+func (k8s *Client) getMetricValues(ctx context.Context, apiPath, metricSelector string) (*resource.Quantity, error) {
+	req := k8s.kubeClient.Discovery().RESTClient().Get().AbsPath(apiPath)
+	if metricSelector != "" {
+		req = req.Param("metricLabelSelector", metricSelector)
+	}
+	data, err := req.DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := new(metricValueList)
+	if err := json.Unmarshal(data, values); err != nil {
+		return nil, err
+	}
+	if len(values.Items) == 0 {
+		return nil, fmt.Errorf("no values")
+	}
+	sum := resource.NewQuantity(0, resource.DecimalSI)
+	for _, item := range values.Items {
+		sum.Add(item.Value)
+	}
+	return sum, nil
+}
@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// GetAPIResources returns the resources, excluding subresources, served by the API server for
+// each group version, sorted by group, version, and name. The discovery cache is refreshed first so
+// that newly installed CRDs are listed. When some groups fail discovery, the resources of the other
+// groups are returned along with the error.
+func (k8s *Client) GetAPIResources() ([]model.APIResourceModel, error) {
+	k8s.discoClient.Invalidate()
+	_, lists, err := k8s.discoClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	var resources []model.APIResourceModel
+	for _, list := range lists {
+		gv, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		for _, res := range list.APIResources {
+			if strings.Contains(res.Name, "/") {
+				continue // subresource
+			}
+			resources = append(resources, model.APIResourceModel{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Name:       res.Name,
+				Kind:       res.Kind,
+				Namespaced: res.Namespaced,
+				Verbs:      res.Verbs,
+				ShortNames: res.ShortNames,
+			})
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Name < b.Name
+	})
+	return resources, err
+}
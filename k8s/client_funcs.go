@@ -2,11 +2,18 @@ package k8s
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	appsV1 "k8s.io/api/apps/v1"
 	batchV1 "k8s.io/api/batch/v1"
 	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (c *Controller) GetNamespaceList(ctx context.Context) ([]*coreV1.Namespace, error) {
@@ -27,7 +34,7 @@ func (c *Controller) GetDeploymentList(ctx context.Context) ([]*appsV1.Deploymen
 		return nil, ctx.Err()
 	}
 
-	items, err := c.deploymentInformer.Lister().List(labels.Everything())
+	items, err := c.deploymentLister.List(labels.Everything())
 
 	if err != nil {
 		return nil, err
@@ -41,7 +48,7 @@ func (c *Controller) GetDaemonSetList(ctx context.Context) ([]*appsV1.DaemonSet,
 		return nil, ctx.Err()
 	}
 
-	items, err := c.daemonSetInformer.Lister().List(labels.Everything())
+	items, err := c.daemonSetLister.List(labels.Everything())
 
 	if err != nil {
 		return nil, err
@@ -56,7 +63,7 @@ func (c *Controller) GetReplicaSetList(ctx context.Context) ([]*appsV1.ReplicaSe
 		return nil, ctx.Err()
 	}
 
-	items, err := c.replicaSetInformer.Lister().List(labels.Everything())
+	items, err := c.replicaSetLister.List(labels.Everything())
 
 	if err != nil {
 		return nil, err
@@ -69,7 +76,7 @@ func (c *Controller) GetStatefulSetList(ctx context.Context) ([]*appsV1.Stateful
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	items, err := c.statefulSetInformer.Lister().List(labels.Everything())
+	items, err := c.statefulSetLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +87,7 @@ func (c *Controller) GetJobList(ctx context.Context) ([]*batchV1.Job, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	items, err := c.jobInformer.Lister().List(labels.Everything())
+	items, err := c.jobLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +98,7 @@ func (c *Controller) GetCronJobList(ctx context.Context) ([]*batchV1.CronJob, er
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	items, err := c.cronJobInformer.Lister().List(labels.Everything())
+	items, err := c.cronJobLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
@@ -113,9 +120,149 @@ func (c *Controller) GetPVCList(ctx context.Context) ([]*coreV1.PersistentVolume
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	items, err := c.pvcInformer.Lister().List(labels.Everything())
+	items, err := c.pvcLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 	return items, nil
 }
+
+func (c *Controller) GetEventList(ctx context.Context) ([]*coreV1.Event, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.eventLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetServiceList returns all cached services in the client namespace scope
+func (c *Controller) GetServiceList(ctx context.Context) ([]*coreV1.Service, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.serviceLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetEndpointsList returns all cached endpoints in the client namespace scope
+func (c *Controller) GetEndpointsList(ctx context.Context) ([]*coreV1.Endpoints, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.endpointsLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetResourceQuotaList returns all cached resource quotas in the client namespace scope
+func (c *Controller) GetResourceQuotaList(ctx context.Context) ([]*coreV1.ResourceQuota, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.quotaLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetIngressList returns all cached ingresses in the client namespace scope
+func (c *Controller) GetIngressList(ctx context.Context) ([]*networkingV1.Ingress, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.ingressLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetPDBList returns all cached pod disruption budgets in the client namespace scope
+func (c *Controller) GetPDBList(ctx context.Context) ([]*policyV1.PodDisruptionBudget, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	items, err := c.pdbLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetObjectEvents lists, on demand, the events involving the object with the specified uid,
+// oldest first. The events are listed in the object namespace or, for a cluster-scoped object
+// (empty namespace), in the namespaces of the client scope.
+func (c *Controller) GetObjectEvents(ctx context.Context, namespace string, uid types.UID) ([]*coreV1.Event, error) {
+	namespaces := []string{namespace}
+	if namespace == "" {
+		namespaces = c.client.namespaces
+	}
+	selector := fields.OneTermEqualSelector("involvedObject.uid", string(uid)).String()
+
+	var result []*coreV1.Event
+	for _, ns := range namespaces {
+		list, err := c.client.kubeClient.CoreV1().Events(ns).List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			result = append(result, &list.Items[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return EventTime(result[i]).Before(EventTime(result[j]))
+	})
+	return result, nil
+}
+
+// GetWarningEventReasons returns the occurrences, by reason, of the Warning events observed after since
+func (c *Controller) GetWarningEventReasons(ctx context.Context, since time.Time) (map[string]int, error) {
+	events, err := c.GetEventList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string]int)
+	for _, event := range events {
+		if event.Type == coreV1.EventTypeWarning && EventTime(event).After(since) {
+			reasons[event.Reason] += eventCount(event)
+		}
+	}
+	return reasons, nil
+}
+
+// eventCount returns the occurrences of an event: the count of an aggregated event or of
+// its series, and at least 1
+func eventCount(event *coreV1.Event) int {
+	count := int(event.Count)
+	if event.Series != nil && int(event.Series.Count) > count {
+		count = int(event.Series.Count)
+	}
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+// EventTime returns the most recent time an event was observed
+func EventTime(event *coreV1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,29 @@
+package k8s
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestEventCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    coreV1.Event
+		expected int
+	}{
+		{name: "single", event: coreV1.Event{}, expected: 1},
+		{name: "aggregated", event: coreV1.Event{Count: 12}, expected: 12},
+		{name: "series", event: coreV1.Event{Series: &coreV1.EventSeries{Count: 40}}, expected: 40},
+		{name: "series below count", event: coreV1.Event{Count: 7, Series: &coreV1.EventSeries{Count: 3}}, expected: 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if count := eventCount(&tc.event); count != tc.expected {
+				t.Errorf("expecting count %d, got %d", tc.expected, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// gatewayGroupName is the API group of the Gateway API resources
+const gatewayGroupName = "gateway.networking.k8s.io"
+
+// httpRouteList is the subset, used by ktop, of a Gateway API HTTPRoute list
+type httpRouteList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Hostnames []string `json:"hostnames"`
+			Rules     []struct {
+				Matches []struct {
+					Path *struct {
+						Value string `json:"value"`
+					} `json:"path"`
+				} `json:"matches"`
+				BackendRefs []struct {
+					Kind      *string `json:"kind"`
+					Name      string  `json:"name"`
+					Namespace *string `json:"namespace"`
+					Port      *int32  `json:"port"`
+				} `json:"backendRefs"`
+			} `json:"rules"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// gatewayAPIVersion returns the preferred version of the Gateway API, empty when the API is not installed
+func (k8s *Client) gatewayAPIVersion() (string, error) {
+	groups, err := k8s.discoClient.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+	for _, group := range groups.Groups {
+		if group.Name == gatewayGroupName {
+			return group.PreferredVersion.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// GetHTTPRoutes returns a model of each Gateway API HTTPRoute in the client namespace scope,
+// none when the Gateway API is not installed. The backend health is not set.
+func (k8s *Client) GetHTTPRoutes(ctx context.Context) ([]model.RouteModel, error) {
+	version, err := k8s.gatewayAPIVersion()
+	if err != nil || version == "" {
+		return nil, err
+	}
+	list := new(httpRouteList)
+	for _, namespace := range k8s.namespaces {
+		apiPath := path.Join("/apis", gatewayGroupName, version, "httproutes")
+		if namespace != AllNamespaces {
+			apiPath = path.Join("/apis", gatewayGroupName, version, "namespaces", namespace, "httproutes")
+		}
+		data, err := k8s.kubeClient.Discovery().RESTClient().Get().AbsPath(apiPath).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("httproutes: %w", err)
+		}
+		namespaceList := new(httpRouteList)
+		if err := json.Unmarshal(data, namespaceList); err != nil {
+			return nil, fmt.Errorf("httproutes: %w", err)
+		}
+		list.Items = append(list.Items, namespaceList.Items...)
+	}
+
+	routes := make([]model.RouteModel, 0, len(list.Items))
+	for _, item := range list.Items {
+		route := model.RouteModel{
+			Kind:      model.RouteKindHTTPRoute,
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Hosts:     item.Spec.Hostnames,
+		}
+		for _, rule := range item.Spec.Rules {
+			paths := []string{"/"}
+			if len(rule.Matches) > 0 {
+				paths = paths[:0]
+				for _, match := range rule.Matches {
+					if match.Path != nil && match.Path.Value != "" {
+						paths = append(paths, match.Path.Value)
+					} else {
+						paths = append(paths, "/")
+					}
+				}
+			}
+			for _, ref := range rule.BackendRefs {
+				if ref.Kind != nil && *ref.Kind != "Service" {
+					continue
+				}
+				service := ref.Name
+				if ref.Namespace != nil && *ref.Namespace != item.Metadata.Namespace {
+					service = *ref.Namespace + "/" + ref.Name
+				}
+				var port string
+				if ref.Port != nil {
+					port = fmt.Sprintf("%d", *ref.Port)
+				}
+				for _, p := range paths {
+					route.Backends = append(route.Backends, model.RouteBackend{Path: p, Service: service, Port: port})
+				}
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// karpenterGroupName is the API group of the Karpenter resources
+const karpenterGroupName = "karpenter.sh"
+
+// nodeClaimConditions are the NodeClaim conditions, in lifecycle order
+var nodeClaimConditions = []string{"Launched", "Registered", "Initialized"}
+
+// nodeClaimList is the subset, used by ktop, of a Karpenter NodeClaim list
+type nodeClaimList struct {
+	Items []struct {
+		Metadata struct {
+			Name              string            `json:"name"`
+			Labels            map[string]string `json:"labels"`
+			CreationTimestamp time.Time         `json:"creationTimestamp"`
+		} `json:"metadata"`
+		Status struct {
+			NodeName   string `json:"nodeName"`
+			Conditions []struct {
+				Type    string `json:"type"`
+				Status  string `json:"status"`
+				Reason  string `json:"reason"`
+				Message string `json:"message"`
+			} `json:"conditions"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// karpenterAPIVersion returns the preferred version of the Karpenter API, empty when Karpenter is not installed
+func (k8s *Client) karpenterAPIVersion() (string, error) {
+	groups, err := k8s.discoClient.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+	for _, group := range groups.Groups {
+		if group.Name == karpenterGroupName {
+			return group.PreferredVersion.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// GetNodeClaims returns a model of each Karpenter NodeClaim, none when Karpenter is not installed.
+// The second value is true when Karpenter is installed.
+func (k8s *Client) GetNodeClaims(ctx context.Context) ([]model.NodeClaimModel, bool, error) {
+	version, err := k8s.karpenterAPIVersion()
+	if err != nil || version == "" {
+		return nil, false, err
+	}
+	data, err := k8s.kubeClient.Discovery().RESTClient().Get().
+		AbsPath(path.Join("/apis", karpenterGroupName, version, "nodeclaims")).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, true, fmt.Errorf("nodeclaims: %w", err)
+	}
+	claims, err := nodeClaimModels(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("nodeclaims: %w", err)
+	}
+	return claims, true, nil
+}
+
+// nodeClaimModels returns a model of each NodeClaim of a NodeClaim list, with the status of the
+// first lifecycle condition not met (i.e. NotLaunched), or Ready
+func nodeClaimModels(data []byte) ([]model.NodeClaimModel, error) {
+	list := new(nodeClaimList)
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+
+	claims := make([]model.NodeClaimModel, 0, len(list.Items))
+	for _, item := range list.Items {
+		claim := model.NodeClaimModel{
+			Name:         item.Metadata.Name,
+			NodePool:     item.Metadata.Labels[model.KarpenterNodePoolLabel],
+			InstanceType: item.Metadata.Labels["node.kubernetes.io/instance-type"],
+			Node:         item.Status.NodeName,
+			Status:       "Ready",
+			CreationTime: item.Metadata.CreationTimestamp,
+		}
+		statuses := make(map[string]int, len(item.Status.Conditions))
+		for i, cond := range item.Status.Conditions {
+			statuses[cond.Type] = i
+		}
+		for _, condType := range nodeClaimConditions {
+			i, ok := statuses[condType]
+			if ok && item.Status.Conditions[i].Status == "True" {
+				continue
+			}
+			claim.Status = "Not" + condType
+			if ok {
+				cond := item.Status.Conditions[i]
+				claim.Failed = cond.Status == "False"
+				claim.Message = cond.Message
+				if claim.Message == "" {
+					claim.Message = cond.Reason
+				}
+			}
+			break
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
@@ -0,0 +1,101 @@
+package k8s
+
+import "testing"
+
+// nodeClaimsFixture is a NodeClaim list, as returned by the karpenter.sh/v1 API, trimmed to the
+// fields used by ktop: a ready claim, a claim failing to launch, and a claim not registered yet
+const nodeClaimsFixture = `{
+  "apiVersion": "karpenter.sh/v1",
+  "kind": "NodeClaimList",
+  "items": [
+    {
+      "metadata": {
+        "name": "default-8kq2x",
+        "creationTimestamp": "2024-01-02T14:00:00Z",
+        "labels": {"karpenter.sh/nodepool": "default", "node.kubernetes.io/instance-type": "m5.large"}
+      },
+      "status": {
+        "nodeName": "ip-10-0-1-12.ec2.internal",
+        "conditions": [
+          {"type": "Initialized", "status": "True", "reason": "Initialized"},
+          {"type": "Launched", "status": "True", "reason": "Launched"},
+          {"type": "Registered", "status": "True", "reason": "Registered"},
+          {"type": "Ready", "status": "True", "reason": "Ready"}
+        ]
+      }
+    },
+    {
+      "metadata": {
+        "name": "gpu-t7m4p",
+        "creationTimestamp": "2024-01-02T14:55:00Z",
+        "labels": {"karpenter.sh/nodepool": "gpu"}
+      },
+      "status": {
+        "conditions": [
+          {"type": "Launched", "status": "False", "reason": "InsufficientCapacity", "message": "all requested instance types were unavailable during launch"}
+        ]
+      }
+    },
+    {
+      "metadata": {
+        "name": "default-x2v9b",
+        "creationTimestamp": "2024-01-02T14:58:00Z",
+        "labels": {"karpenter.sh/nodepool": "default", "node.kubernetes.io/instance-type": "c5.xlarge"}
+      },
+      "status": {
+        "conditions": [
+          {"type": "Launched", "status": "True", "reason": "Launched"},
+          {"type": "Registered", "status": "Unknown", "reason": "AwaitingReconciliation"}
+        ]
+      }
+    }
+  ]
+}`
+
+func TestNodeClaimModels(t *testing.T) {
+	claims, err := nodeClaimModels([]byte(nodeClaimsFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claims) != 3 {
+		t.Fatalf("expecting 3 node claims, got %d", len(claims))
+	}
+
+	tests := []struct {
+		name         string
+		nodePool     string
+		instanceType string
+		node         string
+		status       string
+		failed       bool
+		message      string
+	}{
+		{name: "default-8kq2x", nodePool: "default", instanceType: "m5.large", node: "ip-10-0-1-12.ec2.internal", status: "Ready"},
+		{
+			name: "gpu-t7m4p", nodePool: "gpu", status: "NotLaunched", failed: true,
+			message: "all requested instance types were unavailable during launch",
+		},
+		{name: "default-x2v9b", nodePool: "default", instanceType: "c5.xlarge", status: "NotRegistered", message: "AwaitingReconciliation"},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			claim := claims[i]
+			if claim.Name != tc.name || claim.NodePool != tc.nodePool || claim.InstanceType != tc.instanceType || claim.Node != tc.node {
+				t.Errorf("unexpected node claim %+v", claim)
+			}
+			if claim.Status != tc.status || claim.Failed != tc.failed || claim.Message != tc.message {
+				t.Errorf("expecting status %s (failed %t, %q), got %s (failed %t, %q)",
+					tc.status, tc.failed, tc.message, claim.Status, claim.Failed, claim.Message)
+			}
+			if claim.CreationTime.IsZero() {
+				t.Error("expecting a creation time")
+			}
+		})
+	}
+
+	if _, err := nodeClaimModels([]byte("<html>")); err == nil {
+		t.Error("expecting an error for an invalid list")
+	}
+}
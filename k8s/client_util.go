@@ -1,40 +1,49 @@
 package k8s
 
 import (
-	"os"
-	"path/filepath"
+	"encoding/json"
 
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-// findKubeCfgFile looks for possible location for kubeconfig file
-func findKubeCfgFile() (string, error) {
-	// try KUBECONFIG env
-	kubecfg := os.Getenv(clientcmd.RecommendedConfigPathEnvVar)
-	if kubecfg != "" {
-		return kubecfg, nil
+// loadConfig loads the kubeconfig again, rather than the cached one of flags, with the
+// kubeconfig, context, cluster, user, and impersonation overrides of flags
+func loadConfig(flags *genericclioptions.ConfigFlags) (*rest.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := stringValue(flags.KubeConfig); kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
 	}
-
-	// return known default
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: stringValue(flags.Context),
+		Context: api.Context{
+			Cluster:  stringValue(flags.ClusterName),
+			AuthInfo: stringValue(flags.AuthInfoName),
+		},
+		AuthInfo: api.AuthInfo{
+			Impersonate: stringValue(flags.Impersonate),
+		},
+	}
+	if flags.ImpersonateGroup != nil {
+		overrides.AuthInfo.ImpersonateGroups = *flags.ImpersonateGroup
 	}
-	return filepath.Join(homeDir, clientcmd.RecommendedHomeDir, clientcmd.RecommendedFileName), nil
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
 }
 
-func loadConfig(kubeconfig, context string) (*rest.Config, error) {
-	if kubeconfig == ""{
-		kcfg, err := findKubeCfgFile()
-		if err != nil {
-			return nil, err
-		}
-		kubeconfig = kcfg
+// stringValue returns the value of s, or an empty string when s is nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
 	}
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
-		&clientcmd.ConfigOverrides{
-			CurrentContext: context,
-		}).ClientConfig()
+	return *s
+}
+
+// labelsPatch returns a JSON merge patch that sets labels on an object;
+// labels with a nil value are removed.
+func labelsPatch(labels map[string]*string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	})
 }
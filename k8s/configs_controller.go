@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// configListPageSize is the page size of the ConfigMap and Secret lists
+const configListPageSize = 100
+
+// GetConfigInventory returns the ConfigMaps and Secrets inventory of each namespace in the client
+// namespace scope. Unlike other resources, ConfigMaps and Secrets are listed on demand, rather
+// than cached by informers, to avoid keeping their (possibly large and sensitive) data in memory:
+// they are listed by pages, and only the size of their data is kept.
+func (c *Controller) GetConfigInventory(ctx context.Context) ([]model.ConfigInventoryModel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	coreClient := c.client.kubeClient.CoreV1()
+	var objects []model.ConfigObject
+	for _, namespace := range c.client.namespaces {
+		err := listPages(func(opts metav1.ListOptions) (string, error) {
+			list, err := coreClient.ConfigMaps(namespace).List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			for _, cm := range list.Items {
+				objects = append(objects, model.NewConfigMapObject(cm))
+			}
+			return list.Continue, nil
+		})
+		if err != nil {
+			c.recordAPIError("configmaps", err)
+			return nil, fmt.Errorf("configmaps: %w", err)
+		}
+		err = listPages(func(opts metav1.ListOptions) (string, error) {
+			list, err := coreClient.Secrets(namespace).List(ctx, opts)
+			if err != nil {
+				return "", err
+			}
+			for _, secret := range list.Items {
+				objects = append(objects, model.NewSecretObject(secret))
+			}
+			return list.Continue, nil
+		})
+		if err != nil {
+			c.recordAPIError("secrets", err)
+			return nil, fmt.Errorf("secrets: %w", err)
+		}
+	}
+	return model.NewConfigInventoryModels(objects), nil
+}
+
+// listPages calls list for each page of configListPageSize objects, with the continue
+// token returned for the previous page, until the last page
+func listPages(list func(opts metav1.ListOptions) (string, error)) error {
+	opts := metav1.ListOptions{Limit: configListPageSize}
+	for {
+		next, err := list(opts)
+		if err != nil || next == "" {
+			return err
+		}
+		opts.Continue = next
+	}
+}
@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetConfigInventory(t *testing.T) {
+	configMapCount := configListPageSize + 20
+	var limits []string
+	ctrl := testController(t, func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		start, _ := strconv.Atoi(r.URL.Query().Get("continue"))
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/configmaps":
+			list := coreV1.ConfigMapList{}
+			for i := start; i < configMapCount && i < start+configListPageSize; i++ {
+				list.Items = append(list.Items, coreV1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("config-%d", i)},
+					Data:       map[string]string{"key": "value"},
+				})
+			}
+			if start+configListPageSize < configMapCount {
+				list.Continue = strconv.Itoa(start + configListPageSize)
+			}
+			json.NewEncoder(w).Encode(list)
+		case "/api/v1/namespaces/default/secrets":
+			json.NewEncoder(w).Encode(coreV1.SecretList{Items: []coreV1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token"}, Data: map[string][]byte{"token": []byte("secret")}},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctrl.client.namespaces = []string{"default"}
+
+	models, err := ctrl.GetConfigInventory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 1 {
+		t.Fatalf("expecting 1 namespace, got %d", len(models))
+	}
+	m := models[0]
+	// 8 bytes of data by ConfigMap, 11 bytes for the Secret
+	if m.ConfigMaps != configMapCount || m.Secrets != 1 || m.Size != int64(configMapCount*8+11) {
+		t.Errorf("expecting %d configmaps, 1 secret, and %d bytes, got %+v", configMapCount, configMapCount*8+11, m)
+	}
+	// two pages of ConfigMaps, one of Secrets
+	if len(limits) != 3 {
+		t.Fatalf("expecting 3 list requests, got %d", len(limits))
+	}
+	for _, limit := range limits {
+		if limit != strconv.Itoa(configListPageSize) {
+			t.Errorf("expecting paginated lists of %d objects, got limit %q", configListPageSize, limit)
+		}
+	}
+
+	ctrl.client.namespaces = []string{"missing"}
+	if _, err := ctrl.GetConfigInventory(context.Background()); err == nil {
+		t.Error("expecting an error for a failed list")
+	}
+}
@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetCronJobModels returns a model for each cronjob, sorted by namespace and name
+func (c *Controller) GetCronJobModels(ctx context.Context) ([]model.CronJobModel, error) {
+	cronJobs, err := c.GetCronJobList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := c.GetJobList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	models := make([]model.CronJobModel, 0, len(cronJobs))
+	for _, cronJob := range cronJobs {
+		models = append(models, model.NewCronJobModel(cronJob, jobs, now))
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
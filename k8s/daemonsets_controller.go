@@ -0,0 +1,36 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetDaemonSetModels returns a model for each daemon set, sorted by namespace and name
+func (c *Controller) GetDaemonSetModels(ctx context.Context) ([]model.DaemonSetModel, error) {
+	daemonSets, err := c.GetDaemonSetList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.GetNodeList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]model.DaemonSetModel, 0, len(daemonSets))
+	for _, ds := range daemonSets {
+		models = append(models, model.NewDaemonSetModel(ds, pods, nodes))
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
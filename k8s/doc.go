@@ -0,0 +1,17 @@
+// Package k8s implements ktop's data-collection layer. It can be used
+// independently of the terminal UI to collect cluster data as models
+// (see package views/model):
+//
+//	client, err := k8s.New(genericclioptions.NewConfigFlags(false))
+//	if err != nil {
+//		return err
+//	}
+//	ctrl := client.Controller()
+//	if err := ctrl.Start(ctx, k8s.DefaultResyncPeriod); err != nil {
+//		return err
+//	}
+//	pods, err := ctrl.GetPodModels(ctx)
+//
+// Alternatively, refresh functions can be registered (prior to Start) to
+// receive node, pod, and summary models periodically.
+package k8s
@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetJobModels returns a model for each job, sorted by namespace and name
+func (c *Controller) GetJobModels(ctx context.Context) ([]model.JobModel, error) {
+	jobs, err := c.GetJobList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	models := make([]model.JobModel, 0, len(jobs))
+	for _, job := range jobs {
+		models = append(models, model.NewJobModel(job, pods, now))
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	// kubeletTimeout bounds each request to a node kubelet through the API server node proxy,
+	// so that a slow kubelet does not stall a whole scrape
+	kubeletTimeout = 5 * time.Second
+	// maxKubeletRequests bounds the concurrent requests to the node kubelets of a scrape
+	maxKubeletRequests = 8
+)
+
+// forEachNode calls scrape for each node, with at most maxKubeletRequests calls in flight and
+// a context timing out after kubeletTimeout for each call, and returns once all calls are done.
+func forEachNode(ctx context.Context, nodes []*coreV1.Node, scrape func(ctx context.Context, node *coreV1.Node)) {
+	var wg sync.WaitGroup
+	inflight := make(chan struct{}, maxKubeletRequests)
+	for _, node := range nodes {
+		wg.Add(1)
+		inflight <- struct{}{}
+		go func(node *coreV1.Node) {
+			defer func() {
+				<-inflight
+				wg.Done()
+			}()
+			nodeCtx, cancel := context.WithTimeout(ctx, kubeletTimeout)
+			defer cancel()
+			scrape(nodeCtx, node)
+		}(node)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,48 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestForEachNode(t *testing.T) {
+	var nodes []*coreV1.Node
+	for i := 0; i < 3*maxKubeletRequests; i++ {
+		nodes = append(nodes, &coreV1.Node{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)}})
+	}
+
+	var mu sync.Mutex
+	scraped := make(map[string]bool)
+	inflight, maxInflight := 0, 0
+	forEachNode(context.Background(), nodes, func(ctx context.Context, node *coreV1.Node) {
+		mu.Lock()
+		inflight++
+		if inflight > maxInflight {
+			maxInflight = inflight
+		}
+		mu.Unlock()
+
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > kubeletTimeout {
+			t.Errorf("expecting node %s scraped with a %s timeout", node.Name, kubeletTimeout)
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inflight--
+		scraped[node.Name] = true
+		mu.Unlock()
+	})
+
+	if len(scraped) != len(nodes) {
+		t.Errorf("expecting %d nodes scraped, got %d", len(nodes), len(scraped))
+	}
+	if maxInflight > maxKubeletRequests {
+		t.Errorf("expecting at most %d scrapes in flight, got %d", maxKubeletRequests, maxInflight)
+	}
+}
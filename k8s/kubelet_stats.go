@@ -0,0 +1,275 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// kubeletStatsInterval is the interval between two scrapes of the kubelet stats
+const kubeletStatsInterval = 15 * time.Second
+
+// statsSummary is the subset, used by ktop, of the kubelet /stats/summary response
+type statsSummary struct {
+	Node struct {
+		NodeName string       `json:"nodeName"`
+		CPU      *cpuStats    `json:"cpu"`
+		Memory   *memoryStats `json:"memory"`
+		Swap     *swapStats   `json:"swap"`
+	} `json:"node"`
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Swap       *swapStats `json:"swap"`
+		Containers []struct {
+			Name   string       `json:"name"`
+			CPU    *cpuStats    `json:"cpu"`
+			Memory *memoryStats `json:"memory"`
+			Swap   *swapStats   `json:"swap"`
+		} `json:"containers"`
+	} `json:"pods"`
+}
+
+type cpuStats struct {
+	Time           metav1.Time `json:"time"`
+	UsageNanoCores *uint64     `json:"usageNanoCores"`
+}
+
+type memoryStats struct {
+	Time            metav1.Time `json:"time"`
+	UsageBytes      *uint64     `json:"usageBytes"`
+	WorkingSetBytes *uint64     `json:"workingSetBytes"`
+	RSSBytes        *uint64     `json:"rssBytes"`
+}
+
+// swapStats are the swap stats, reported by kubelets from 1.28 on clusters with swap enabled
+type swapStats struct {
+	Time               metav1.Time `json:"time"`
+	SwapAvailableBytes *uint64     `json:"swapAvailableBytes"`
+	SwapUsageBytes     *uint64     `json:"swapUsageBytes"`
+}
+
+// kubeletStats caches the node and pod metrics built from the kubelet stats, used
+// in place of the metrics-server metrics when the metrics API is not available.
+type kubeletStats struct {
+	sync.RWMutex
+	nodes map[string]*metricsV1beta1.NodeMetrics
+	pods  map[string]*metricsV1beta1.PodMetrics // keyed by namespace/name
+}
+
+func newKubeletStats() *kubeletStats {
+	return &kubeletStats{
+		nodes: make(map[string]*metricsV1beta1.NodeMetrics),
+		pods:  make(map[string]*metricsV1beta1.PodMetrics),
+	}
+}
+
+func (s *kubeletStats) nodeMetrics(name string) (*metricsV1beta1.NodeMetrics, error) {
+	s.RLock()
+	defer s.RUnlock()
+	metrics, ok := s.nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("kubelet stats: node %s not found", name)
+	}
+	return metrics, nil
+}
+
+func (s *kubeletStats) podMetrics(namespace, name string) (*metricsV1beta1.PodMetrics, error) {
+	s.RLock()
+	defer s.RUnlock()
+	metrics, ok := s.pods[namespace+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("kubelet stats: pod %s/%s not found", namespace, name)
+	}
+	return metrics, nil
+}
+
+func (s *kubeletStats) allPodMetrics() []*metricsV1beta1.PodMetrics {
+	s.RLock()
+	defer s.RUnlock()
+	result := make([]*metricsV1beta1.PodMetrics, 0, len(s.pods))
+	for _, metrics := range s.pods {
+		result = append(result, metrics)
+	}
+	return result
+}
+
+func (s *kubeletStats) allNodeMetrics() []*metricsV1beta1.NodeMetrics {
+	s.RLock()
+	defer s.RUnlock()
+	result := make([]*metricsV1beta1.NodeMetrics, 0, len(s.nodes))
+	for _, metrics := range s.nodes {
+		result = append(result, metrics)
+	}
+	return result
+}
+
+// startKubeletStats scrapes, until ctx is done, the stats of each node kubelet
+// through the API server node proxy.
+func (c *Controller) startKubeletStats(ctx context.Context) {
+	c.kubeletStats = newKubeletStats()
+	go func() {
+		ticker := time.NewTicker(kubeletStatsInterval)
+		defer ticker.Stop()
+		for {
+			c.scrapeKubeletStats(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// scrapeKubeletStats replaces the cached metrics with the stats of the nodes currently listed,
+// scraped concurrently
+func (c *Controller) scrapeKubeletStats(ctx context.Context) {
+	nodes, err := c.nodeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return
+	}
+	var mu sync.Mutex
+	nodeMetrics := make(map[string]*metricsV1beta1.NodeMetrics)
+	podMetrics := make(map[string]*metricsV1beta1.PodMetrics)
+	forEachNode(ctx, nodes, func(ctx context.Context, node *coreV1.Node) {
+		summary, err := c.getKubeletStats(ctx, node)
+		if err != nil {
+			klog.V(2).Infof("kubelet stats: node %s: %s", node.Name, err)
+			c.recordAPIError("nodes/proxy", err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodeMetrics[node.Name] = summary.nodeMetrics(node.Name)
+		for _, pod := range summary.podMetrics() {
+			podMetrics[pod.Namespace+"/"+pod.Name] = pod
+		}
+	})
+
+	c.kubeletStats.Lock()
+	c.kubeletStats.nodes, c.kubeletStats.pods = nodeMetrics, podMetrics
+	c.kubeletStats.Unlock()
+}
+
+// getKubeletStats returns the stats summary of the node kubelet
+func (c *Controller) getKubeletStats(ctx context.Context, node *coreV1.Node) (*statsSummary, error) {
+	data, err := c.client.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(node.Name).SubResource("proxy").Suffix("stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := new(statsSummary)
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetPodMemoryStats returns the memory breakdown (working set, RSS, and page cache) of the
+// containers of pod, read from the stats summary of the kubelet of its node within kubeletTimeout.
+func (c *Controller) GetPodMemoryStats(ctx context.Context, pod *coreV1.Pod) ([]model.ContainerMemoryModel, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, fmt.Errorf("kubelet stats: pod %s/%s not scheduled", pod.Namespace, pod.Name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, kubeletTimeout)
+	defer cancel()
+	node := &coreV1.Node{ObjectMeta: metav1.ObjectMeta{Name: pod.Spec.NodeName}}
+	summary, err := c.getKubeletStats(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	for _, podStats := range summary.Pods {
+		if podStats.PodRef.Namespace != pod.Namespace || podStats.PodRef.Name != pod.Name {
+			continue
+		}
+		var result []model.ContainerMemoryModel
+		for _, container := range podStats.Containers {
+			memory := container.Memory.memoryModel(container.Name)
+			if container.Swap != nil && container.Swap.SwapUsageBytes != nil {
+				memory.SwapQty = resource.NewQuantity(int64(*container.Swap.SwapUsageBytes), resource.BinarySI)
+			}
+			result = append(result, memory)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("kubelet stats: pod %s/%s not found", pod.Namespace, pod.Name)
+}
+
+// memoryModel returns the memory breakdown of the stats; the page cache is the
+// usage not accounted as RSS. Missing stats are nil.
+func (m *memoryStats) memoryModel(name string) model.ContainerMemoryModel {
+	result := model.ContainerMemoryModel{Name: name}
+	if m == nil {
+		return result
+	}
+	if m.WorkingSetBytes != nil {
+		result.WorkingSetQty = resource.NewQuantity(int64(*m.WorkingSetBytes), resource.BinarySI)
+	}
+	if m.RSSBytes != nil {
+		result.RSSQty = resource.NewQuantity(int64(*m.RSSBytes), resource.BinarySI)
+		if m.UsageBytes != nil && *m.UsageBytes >= *m.RSSBytes {
+			result.PageCacheQty = resource.NewQuantity(int64(*m.UsageBytes-*m.RSSBytes), resource.BinarySI)
+		}
+	}
+	return result
+}
+
+// nodeMetrics returns the node usage of the summary as node metrics
+func (s *statsSummary) nodeMetrics(name string) *metricsV1beta1.NodeMetrics {
+	metrics := &metricsV1beta1.NodeMetrics{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	metrics.Timestamp, metrics.Usage = statsUsage(s.Node.CPU, s.Node.Memory)
+	return metrics
+}
+
+// podMetrics returns the container usage of the summary pods as pod metrics
+func (s *statsSummary) podMetrics() []*metricsV1beta1.PodMetrics {
+	result := make([]*metricsV1beta1.PodMetrics, 0, len(s.Pods))
+	for _, pod := range s.Pods {
+		metrics := &metricsV1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.PodRef.Name, Namespace: pod.PodRef.Namespace},
+		}
+		for _, container := range pod.Containers {
+			timestamp, usage := statsUsage(container.CPU, container.Memory)
+			if timestamp.After(metrics.Timestamp.Time) {
+				metrics.Timestamp = timestamp
+			}
+			metrics.Containers = append(metrics.Containers, metricsV1beta1.ContainerMetrics{Name: container.Name, Usage: usage})
+		}
+		result = append(result, metrics)
+	}
+	return result
+}
+
+// statsUsage returns the CPU and memory (working set) usage, and sample time, of kubelet stats
+func statsUsage(cpu *cpuStats, mem *memoryStats) (metav1.Time, coreV1.ResourceList) {
+	var timestamp metav1.Time
+	usage := coreV1.ResourceList{
+		coreV1.ResourceCPU:    *resource.NewMilliQuantity(0, resource.DecimalSI),
+		coreV1.ResourceMemory: *resource.NewQuantity(0, resource.BinarySI),
+	}
+	if cpu != nil && cpu.UsageNanoCores != nil {
+		usage[coreV1.ResourceCPU] = *resource.NewMilliQuantity(int64(*cpu.UsageNanoCores/1000000), resource.DecimalSI)
+		timestamp = cpu.Time
+	}
+	if mem != nil && mem.WorkingSetBytes != nil {
+		usage[coreV1.ResourceMemory] = *resource.NewQuantity(int64(*mem.WorkingSetBytes), resource.BinarySI)
+		if mem.Time.After(timestamp.Time) {
+			timestamp = mem.Time
+		}
+	}
+	return timestamp, usage
+}
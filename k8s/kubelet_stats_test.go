@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+// statsSummaryFixture is a kubelet stats summary, trimmed to the fields used by ktop, of a
+// node with swap and two pods
+const statsSummaryFixture = `{
+  "node": {
+    "nodeName": "node-a",
+    "cpu": {"time": "2024-01-02T15:00:00Z", "usageNanoCores": 250000000},
+    "memory": {"time": "2024-01-02T15:00:00Z", "usageBytes": 2147483648, "workingSetBytes": 1073741824, "rssBytes": 805306368},
+    "swap": {"time": "2024-01-02T15:00:00Z", "swapAvailableBytes": 3221225472, "swapUsageBytes": 1073741824}
+  },
+  "pods": [
+    {
+      "podRef": {"name": "web-0", "namespace": "default"},
+      "swap": {"swapUsageBytes": 67108864},
+      "containers": [
+        {
+          "name": "app",
+          "cpu": {"time": "2024-01-02T15:00:00Z", "usageNanoCores": 100000000},
+          "memory": {"time": "2024-01-02T15:00:00Z", "usageBytes": 314572800, "workingSetBytes": 262144000, "rssBytes": 209715200},
+          "swap": {"swapUsageBytes": 67108864}
+        },
+        {
+          "name": "sidecar",
+          "memory": {"time": "2024-01-02T15:00:00Z", "workingSetBytes": 10485760}
+        }
+      ]
+    },
+    {
+      "podRef": {"name": "dns-0", "namespace": "kube-system"},
+      "swap": {"swapUsageBytes": 0},
+      "containers": [{"name": "dns", "memory": {"usageBytes": 20971520, "workingSetBytes": 20971520, "rssBytes": 16777216}}]
+    }
+  ]
+}`
+
+// testController returns a controller of a client of the API server served by handler, i.e. the
+// kubelet endpoints through the API server node proxy
+func testController(t *testing.T, handler http.HandlerFunc) *Controller {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	kubeClient, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newController(&Client{kubeClient: kubeClient})
+}
+
+func TestGetPodMemoryStats(t *testing.T) {
+	ctrl := testController(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/node-a/proxy/stats/summary" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(statsSummaryFixture))
+	})
+	pod := func(node, namespace, name string) *coreV1.Pod {
+		return &coreV1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: coreV1.PodSpec{NodeName: node}}
+	}
+
+	t.Run("containers", func(t *testing.T) {
+		memory, err := ctrl.GetPodMemoryStats(context.Background(), pod("node-a", "default", "web-0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(memory) != 2 {
+			t.Fatalf("expecting 2 containers, got %d", len(memory))
+		}
+		app, sidecar := memory[0], memory[1]
+		if app.Name != "app" || app.WorkingSetQty.String() != "250Mi" || app.RSSQty.String() != "200Mi" ||
+			app.PageCacheQty.String() != "100Mi" || app.SwapQty.String() != "64Mi" {
+			t.Errorf("unexpected app memory %s %v %v %v %v", app.Name, app.WorkingSetQty, app.RSSQty, app.PageCacheQty, app.SwapQty)
+		}
+		if sidecar.Name != "sidecar" || sidecar.WorkingSetQty.String() != "10Mi" || sidecar.RSSQty != nil || sidecar.PageCacheQty != nil || sidecar.SwapQty != nil {
+			t.Errorf("unexpected sidecar memory %s %v %v %v %v", sidecar.Name, sidecar.WorkingSetQty, sidecar.RSSQty, sidecar.PageCacheQty, sidecar.SwapQty)
+		}
+	})
+
+	tests := []struct {
+		name string
+		pod  *coreV1.Pod
+	}{
+		{name: "pod not found", pod: pod("node-a", "default", "api-0")},
+		{name: "not scheduled", pod: pod("", "default", "web-0")},
+		{name: "kubelet error", pod: pod("node-b", "default", "web-0")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if _, err := ctrl.GetPodMemoryStats(context.Background(), tc.pod); err == nil {
+				t.Error("expecting an error")
+			}
+		})
+	}
+}
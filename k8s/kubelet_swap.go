@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kubeletConfigz is the subset, used by ktop, of the kubelet /configz response
+type kubeletConfigz struct {
+	KubeletConfig struct {
+		FailSwapOn *bool `json:"failSwapOn"`
+		MemorySwap struct {
+			SwapBehavior string `json:"swapBehavior"`
+		} `json:"memorySwap"`
+	} `json:"kubeletconfig"`
+}
+
+// GetNodeSwap returns the swap behavior and usage of the node, and the swap usage of its pods,
+// read from the configuration and stats summary of the node kubelet through the API server node proxy.
+// The configuration is not known when the kubelet configuration endpoint can not be read.
+func (c *Controller) GetNodeSwap(ctx context.Context, nodeName string) (*model.NodeSwapModel, error) {
+	node := &coreV1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	summary, err := c.getKubeletStats(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	swap := summarySwap(summary)
+
+	data, err := c.client.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("configz").
+		DoRaw(ctx)
+	if err != nil {
+		return swap, nil
+	}
+	setSwapConfig(swap, data)
+	return swap, nil
+}
+
+// summarySwap returns the swap usage and capacity of the node of the stats summary, and the
+// swap usage of its pods using swap
+func summarySwap(summary *statsSummary) *model.NodeSwapModel {
+	swap := &model.NodeSwapModel{Pods: make(map[string]*resource.Quantity)}
+	if stats := summary.Node.Swap; stats != nil && stats.SwapUsageBytes != nil {
+		swap.UsageQty = resource.NewQuantity(int64(*stats.SwapUsageBytes), resource.BinarySI)
+		capacity := *stats.SwapUsageBytes
+		if stats.SwapAvailableBytes != nil {
+			capacity += *stats.SwapAvailableBytes
+		}
+		swap.CapacityQty = resource.NewQuantity(int64(capacity), resource.BinarySI)
+	}
+	for _, pod := range summary.Pods {
+		if pod.Swap != nil && pod.Swap.SwapUsageBytes != nil && *pod.Swap.SwapUsageBytes > 0 {
+			swap.Pods[pod.PodRef.Namespace+"/"+pod.PodRef.Name] = resource.NewQuantity(int64(*pod.Swap.SwapUsageBytes), resource.BinarySI)
+		}
+	}
+	return swap
+}
+
+// setSwapConfig sets the swap settings of the kubelet /configz response data on swap; the
+// configuration stays unknown when data can not be decoded
+func setSwapConfig(swap *model.NodeSwapModel, data []byte) {
+	configz := new(kubeletConfigz)
+	if err := json.Unmarshal(data, configz); err != nil {
+		return
+	}
+	swap.ConfigKnown = true
+	swap.Behavior = configz.KubeletConfig.MemorySwap.SwapBehavior
+	// failSwapOn defaults to true
+	swap.FailSwapOn = configz.KubeletConfig.FailSwapOn == nil || *configz.KubeletConfig.FailSwapOn
+}
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSetSwapConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		configz    string
+		known      bool
+		behavior   string
+		failSwapOn bool
+	}{
+		{
+			name:     "limited swap",
+			configz:  `{"kubeletconfig":{"failSwapOn":false,"memorySwap":{"swapBehavior":"LimitedSwap"}}}`,
+			known:    true,
+			behavior: "LimitedSwap",
+		},
+		{name: "defaults", configz: `{"kubeletconfig":{}}`, known: true, failSwapOn: true},
+		{name: "invalid", configz: `<html>forbidden</html>`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			swap := summarySwap(&statsSummary{})
+			setSwapConfig(swap, []byte(tc.configz))
+			if swap.ConfigKnown != tc.known || swap.Behavior != tc.behavior || swap.FailSwapOn != tc.failSwapOn {
+				t.Errorf("expecting config %t %q failSwapOn %t, got %t %q failSwapOn %t",
+					tc.known, tc.behavior, tc.failSwapOn, swap.ConfigKnown, swap.Behavior, swap.FailSwapOn)
+			}
+		})
+	}
+}
+
+func TestGetNodeSwap(t *testing.T) {
+	configz := true
+	ctrl := testController(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/nodes/node-a/proxy/stats/summary":
+			w.Write([]byte(statsSummaryFixture))
+		case "/api/v1/nodes/node-a/proxy/configz":
+			if !configz {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"kubeletconfig":{"failSwapOn":false,"memorySwap":{"swapBehavior":"LimitedSwap"}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	swap, err := ctrl.GetNodeSwap(context.Background(), "node-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swap.UsageQty.String() != "1Gi" || swap.CapacityQty.String() != "4Gi" {
+		t.Errorf("expecting swap 1Gi/4Gi, got %v/%v", swap.UsageQty, swap.CapacityQty)
+	}
+	if fmt.Sprint(swap.Pods) != "map[default/web-0:64Mi]" {
+		t.Errorf("expecting pods swap map[default/web-0:64Mi], got %v", swap.Pods)
+	}
+	if !swap.ConfigKnown || swap.Behavior != "LimitedSwap" || swap.FailSwapOn {
+		t.Errorf("expecting config LimitedSwap failSwapOn false, got %t %q failSwapOn %t", swap.ConfigKnown, swap.Behavior, swap.FailSwapOn)
+	}
+
+	configz = false
+	swap, err = ctrl.GetNodeSwap(context.Background(), "node-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swap.ConfigKnown || swap.UsageQty.String() != "1Gi" {
+		t.Errorf("expecting swap usage with an unknown config, got %t %v", swap.ConfigKnown, swap.UsageQty)
+	}
+
+	if _, err := ctrl.GetNodeSwap(context.Background(), "node-b"); err == nil {
+		t.Error("expecting an error for an unreachable kubelet")
+	}
+}
@@ -0,0 +1,208 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// cpuThrottlingInterval is the interval between two scrapes of the cAdvisor metrics
+	cpuThrottlingInterval = 15 * time.Second
+
+	cfsPeriodsMetric          = "container_cpu_cfs_periods_total"
+	cfsThrottledPeriodsMetric = "container_cpu_cfs_throttled_periods_total"
+)
+
+// cfsPeriods are the CFS (CPU quota) periods counters of a pod, summed over its containers
+type cfsPeriods struct {
+	periods   float64
+	throttled float64
+}
+
+// cpuThrottling caches the CPU throttling of the pods, computed from the CFS periods
+// counters of the kubelet cAdvisor metrics between two scrapes.
+type cpuThrottling struct {
+	sync.RWMutex
+	counters map[string]cfsPeriods // last scraped counters, keyed by namespace/name
+	percents map[string]float64    // throttled periods percentages, keyed by namespace/name
+}
+
+func newCPUThrottling() *cpuThrottling {
+	return &cpuThrottling{
+		counters: make(map[string]cfsPeriods),
+		percents: make(map[string]float64),
+	}
+}
+
+// podThrottling returns the percentage of the CFS periods of the pod that were throttled
+// between the last two scrapes, and false when the pod was not sampled twice (or has no CPU limit)
+func (t *cpuThrottling) podThrottling(namespace, name string) (float64, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	percent, ok := t.percents[namespace+"/"+name]
+	return percent, ok
+}
+
+// update replaces the counters with the scraped counters, computing the throttling from the deltas
+func (t *cpuThrottling) update(counters map[string]cfsPeriods) {
+	t.Lock()
+	defer t.Unlock()
+	percents := make(map[string]float64)
+	for key, current := range counters {
+		previous, ok := t.counters[key]
+		if !ok {
+			continue
+		}
+		periods, throttled := current.periods-previous.periods, current.throttled-previous.throttled
+		switch {
+		case periods > 0 && throttled >= 0:
+			percents[key] = throttled * 100 / periods
+		case periods == 0:
+			// no CPU time used during the interval: nothing was throttled
+			percents[key] = 0
+		}
+		// negative deltas (restarted containers) skip a sample
+	}
+	t.counters, t.percents = counters, percents
+}
+
+// startCPUThrottling scrapes, until ctx is done, the cAdvisor metrics of each node kubelet
+// through the API server node proxy.
+func (c *Controller) startCPUThrottling(ctx context.Context) {
+	c.cpuThrottling = newCPUThrottling()
+	go func() {
+		ticker := time.NewTicker(cpuThrottlingInterval)
+		defer ticker.Stop()
+		for {
+			c.scrapeCPUThrottling(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// scrapeCPUThrottling updates the pods throttling with the counters of the nodes currently listed,
+// scraped concurrently
+func (c *Controller) scrapeCPUThrottling(ctx context.Context) {
+	nodes, err := c.nodeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return
+	}
+	var mu sync.Mutex
+	counters := make(map[string]cfsPeriods)
+	forEachNode(ctx, nodes, func(ctx context.Context, node *coreV1.Node) {
+		nodeCounters, err := c.getCFSPeriods(ctx, node)
+		if err != nil {
+			klog.V(2).Infof("cpu throttling: node %s: %s", node.Name, err)
+			c.recordAPIError("nodes/proxy", err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for key, sample := range nodeCounters {
+			counters[key] = sample
+		}
+	})
+	c.cpuThrottling.update(counters)
+}
+
+// getCFSPeriods returns the CFS periods of the pods of the node kubelet cAdvisor metrics
+func (c *Controller) getCFSPeriods(ctx context.Context, node *coreV1.Node) (map[string]cfsPeriods, error) {
+	data, err := c.client.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(node.Name).SubResource("proxy").Suffix("metrics/cadvisor").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseCFSPeriods(data)
+}
+
+// parseCFSPeriods returns the CFS periods of the pods of cAdvisor metrics, summed over their
+// containers and keyed by namespace/name
+func parseCFSPeriods(data []byte) (map[string]cfsPeriods, error) {
+	counters := make(map[string]cfsPeriods)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		var throttled bool
+		switch {
+		case strings.HasPrefix(line, cfsPeriodsMetric+"{"):
+		case strings.HasPrefix(line, cfsThrottledPeriodsMetric+"{"):
+			throttled = true
+		default:
+			continue
+		}
+		sampleLabels, value, ok := parseSample(line)
+		// skip the pod cgroup samples, without container, already counted by container
+		if !ok || sampleLabels["container"] == "" || sampleLabels["pod"] == "" {
+			continue
+		}
+		key := sampleLabels["namespace"] + "/" + sampleLabels["pod"]
+		sample := counters[key]
+		if throttled {
+			sample.throttled += value
+		} else {
+			sample.periods += value
+		}
+		counters[key] = sample
+	}
+	return counters, scanner.Err()
+}
+
+// parseSample parses the labels and value of a Prometheus text format sample line:
+// name{label="value",...} value [timestamp]
+func parseSample(line string) (map[string]string, float64, bool) {
+	start, end := strings.IndexByte(line, '{'), strings.LastIndexByte(line, '}')
+	if start < 0 || end < start {
+		return nil, 0, false
+	}
+	fields := strings.Fields(line[end+1:])
+	if len(fields) == 0 {
+		return nil, 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	sampleLabels := make(map[string]string)
+	rest := line[start+1 : end]
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 || eq+1 >= len(rest) || rest[eq+1] != '"' {
+			return nil, 0, false
+		}
+		name := strings.TrimSpace(rest[:eq])
+		var val strings.Builder
+		i := eq + 2
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+				if rest[i] == 'n' {
+					val.WriteByte('\n')
+					continue
+				}
+			}
+			val.WriteByte(rest[i])
+		}
+		if i >= len(rest) {
+			return nil, 0, false
+		}
+		sampleLabels[name] = val.String()
+		rest = strings.TrimPrefix(rest[i+1:], ",")
+	}
+	return sampleLabels, value, true
+}
@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		labels map[string]string
+		value  float64
+		ok     bool
+	}{
+		{
+			name:   "sample",
+			line:   `container_cpu_cfs_periods_total{container="app",namespace="default",pod="web-0"} 1200`,
+			labels: map[string]string{"container": "app", "namespace": "default", "pod": "web-0"},
+			value:  1200,
+			ok:     true,
+		},
+		{
+			name:   "timestamp",
+			line:   `container_cpu_cfs_throttled_periods_total{container="app",pod="web-0"} 42 1704207600000`,
+			labels: map[string]string{"container": "app", "pod": "web-0"},
+			value:  42,
+			ok:     true,
+		},
+		{
+			name:   "escaped values",
+			line:   `metric{id="/kubepods/\"burst\"",path="a\\b",msg="line\nbreak",comma="a,b"} 1e3`,
+			labels: map[string]string{"id": `/kubepods/"burst"`, "path": `a\b`, "msg": "line\nbreak", "comma": "a,b"},
+			value:  1000,
+			ok:     true,
+		},
+		{name: "empty labels", line: `metric{} 3`, labels: map[string]string{}, value: 3, ok: true},
+		{name: "no labels", line: `metric 3`},
+		{name: "no value", line: `metric{pod="web-0"}`},
+		{name: "invalid value", line: `metric{pod="web-0"} abc`},
+		{name: "unquoted label", line: `metric{pod=web-0} 3`},
+		{name: "unterminated label", line: `metric{pod="web-0} 3`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			labels, value, ok := parseSample(tc.line)
+			if ok != tc.ok {
+				t.Fatalf("expecting ok %t, got %t", tc.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if value != tc.value || fmt.Sprint(labels) != fmt.Sprint(tc.labels) {
+				t.Errorf("expecting %v %g, got %v %g", tc.labels, tc.value, labels, value)
+			}
+		})
+	}
+}
+
+func TestParseCFSPeriods(t *testing.T) {
+	metrics := `# HELP container_cpu_cfs_periods_total Number of elapsed enforcement period intervals.
+# TYPE container_cpu_cfs_periods_total counter
+container_cpu_cfs_periods_total{container="",namespace="default",pod="web-0"} 5000
+container_cpu_cfs_periods_total{container="app",namespace="default",pod="web-0"} 3000
+container_cpu_cfs_periods_total{container="sidecar",namespace="default",pod="web-0"} 1000
+container_cpu_cfs_throttled_periods_total{container="app",namespace="default",pod="web-0"} 600
+container_cpu_cfs_periods_total{container="app",namespace="kube-system",pod="dns-0"} 200
+container_cpu_usage_seconds_total{container="app",namespace="default",pod="web-0"} 12.5
+`
+	counters, err := parseCFSPeriods([]byte(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]cfsPeriods{
+		"default/web-0":     {periods: 4000, throttled: 600},
+		"kube-system/dns-0": {periods: 200},
+	}
+	if fmt.Sprint(counters) != fmt.Sprint(expected) {
+		t.Errorf("expecting counters %v, got %v", expected, counters)
+	}
+}
+
+func TestCPUThrottlingUpdate(t *testing.T) {
+	throttling := newCPUThrottling()
+	throttling.update(map[string]cfsPeriods{
+		"default/web-0": {periods: 1000, throttled: 100},
+		"default/api-0": {periods: 500, throttled: 50},
+		"default/job-0": {periods: 300, throttled: 0},
+		"default/old-0": {periods: 300, throttled: 30},
+	})
+	if _, ok := throttling.podThrottling("default", "web-0"); ok {
+		t.Fatal("expecting no throttling after a single scrape")
+	}
+
+	throttling.update(map[string]cfsPeriods{
+		"default/web-0": {periods: 1200, throttled: 150}, // 50 of 200 periods throttled
+		"default/api-0": {periods: 100, throttled: 10},   // restarted container: counters reset
+		"default/job-0": {periods: 300, throttled: 0},    // idle
+		"default/new-0": {periods: 100, throttled: 10},   // first scrape
+	})
+
+	tests := []struct {
+		name     string
+		expected float64
+		ok       bool
+	}{
+		{name: "web-0", expected: 25, ok: true},
+		{name: "api-0"},
+		{name: "job-0", expected: 0, ok: true},
+		{name: "new-0"},
+		{name: "old-0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			percent, ok := throttling.podThrottling("default", tc.name)
+			if ok != tc.ok || percent != tc.expected {
+				t.Errorf("expecting throttling %g (%t), got %g (%t)", tc.expected, tc.ok, percent, ok)
+			}
+		})
+	}
+
+	// the sample after a counter reset is computed from the reset counters
+	throttling.update(map[string]cfsPeriods{"default/api-0": {periods: 300, throttled: 60}})
+	if percent, ok := throttling.podThrottling("default", "api-0"); !ok || percent != 25 {
+		t.Errorf("expecting throttling 25 after the counter reset, got %g (%t)", percent, ok)
+	}
+}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -11,9 +12,15 @@ import (
 
 // GetNodeMetrics returns metrics for specified node
 func (c *Controller) GetNodeMetrics(ctx context.Context, nodeName string) (*metricsV1beta1.NodeMetrics, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err := c.client.AssertMetricsAvailable(); err != nil {
 		return nil, fmt.Errorf("node metrics: %s", err)
 	}
+	if c.kubeletStats != nil {
+		return c.kubeletStats.nodeMetrics(nodeName)
+	}
 
 	metrics, err := c.nodeMetricsInformer.Lister().Get(nodeName)
 	if err != nil {
@@ -25,11 +32,17 @@ func (c *Controller) GetNodeMetrics(ctx context.Context, nodeName string) (*metr
 
 // GetPodMetricsByName returns pod metrics for specified pod
 func (c *Controller) GetPodMetricsByName(ctx context.Context, pod *v1.Pod) (*metricsV1beta1.PodMetrics, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err := c.client.AssertMetricsAvailable(); err != nil {
 		return nil, fmt.Errorf("pod metrics by name: %s", err)
 	}
+	if c.kubeletStats != nil {
+		return c.kubeletStats.podMetrics(pod.Namespace, pod.Name)
+	}
 
-	metrics, err := c.podMetricsInformer.Lister().Get(pod)
+	metrics, err := c.podMetricsLister.Get(pod)
 	if err != nil {
 		return nil, err
 	}
@@ -39,14 +52,64 @@ func (c *Controller) GetPodMetricsByName(ctx context.Context, pod *v1.Pod) (*met
 
 // GetAllPodMetrics retrieve all available pod emtrics
 func (c *Controller) GetAllPodMetrics(ctx context.Context) ([]*metricsV1beta1.PodMetrics, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err := c.client.AssertMetricsAvailable(); err != nil {
 		return nil, fmt.Errorf("all pod metrics: %s", err)
 	}
+	if c.kubeletStats != nil {
+		return c.kubeletStats.allPodMetrics(), nil
+	}
 
-	metricsList, err := c.podMetricsInformer.Lister().List(labels.Everything())
+	metricsList, err := c.podMetricsLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
 	return metricsList, nil
 }
+
+// GetNewestMetricsTime returns the timestamp of the newest cached node or pod metrics sample.
+// A stale timestamp indicates that metrics-server stopped scraping, or ktop stopped receiving, metrics.
+func (c *Controller) GetNewestMetricsTime(ctx context.Context) (time.Time, error) {
+	if ctx.Err() != nil {
+		return time.Time{}, ctx.Err()
+	}
+	if err := c.client.AssertMetricsAvailable(); err != nil {
+		return time.Time{}, fmt.Errorf("newest metrics: %s", err)
+	}
+
+	var nodeMetrics []*metricsV1beta1.NodeMetrics
+	var podMetrics []*metricsV1beta1.PodMetrics
+	switch {
+	case c.kubeletStats != nil:
+		nodeMetrics, podMetrics = c.kubeletStats.allNodeMetrics(), c.kubeletStats.allPodMetrics()
+	case c.nodeMetricsInformer == nil || c.podMetricsLister == nil:
+		return time.Time{}, fmt.Errorf("newest metrics: metrics informers not started")
+	default:
+		var err error
+		if nodeMetrics, err = c.nodeMetricsInformer.Lister().List(labels.Everything()); err != nil {
+			return time.Time{}, err
+		}
+		if podMetrics, err = c.podMetricsLister.List(labels.Everything()); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	var newest time.Time
+	for _, metrics := range nodeMetrics {
+		if metrics.Timestamp.After(newest) {
+			newest = metrics.Timestamp.Time
+		}
+	}
+	for _, metrics := range podMetrics {
+		if metrics.Timestamp.After(newest) {
+			newest = metrics.Timestamp.Time
+		}
+	}
+	if newest.IsZero() {
+		return time.Time{}, fmt.Errorf("newest metrics: no metrics samples")
+	}
+	return newest, nil
+}
@@ -25,7 +25,7 @@ func NewNodeMetricsInformer(client metricsclient.Interface, resyncPeriod time.Du
 				return client.MetricsV1beta1().NodeMetricses().List(context.TODO(), options)
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-
+				allowWatchBookmarks(&options)
 				return client.MetricsV1beta1().NodeMetricses().Watch(context.TODO(), options)
 			},
 		},
@@ -61,6 +61,7 @@ func NewPodMetricsInformer(client metricsclient.Interface, resyncPeriod time.Dur
 				return client.MetricsV1beta1().PodMetricses(namespace).List(context.TODO(), options)
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				allowWatchBookmarks(&options)
 				return client.MetricsV1beta1().PodMetricses(namespace).Watch(context.TODO(), options)
 			},
 		},
@@ -0,0 +1,17 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetNamespaceLifecycle returns the models of the Terminating, then recently created, namespaces
+func (c *Controller) GetNamespaceLifecycle(ctx context.Context) ([]model.NamespaceModel, error) {
+	namespaces, err := c.GetNamespaceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model.NamespaceLifecycle(namespaces, time.Now()), nil
+}
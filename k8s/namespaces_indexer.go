@@ -0,0 +1,138 @@
+package k8s
+
+import (
+	"errors"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// errReadOnlyIndexer is returned by the write methods of a namespacesIndexer
+var errReadOnlyIndexer = errors.New("namespaces indexer is read-only")
+
+// namespacesIndexer is a read-only cache.Indexer over the indexers of the informers of a
+// resource, one per namespace of the client scope, so that a single lister serves the
+// objects of all the namespaces. The informers keep writing to their own indexer.
+type namespacesIndexer []cache.Indexer
+
+// newNamespacesIndexer returns the indexer of the informers: the indexer of the single
+// informer, or a namespacesIndexer over the indexers of several informers
+func newNamespacesIndexer(informers []cache.SharedIndexInformer) cache.Indexer {
+	if len(informers) == 1 {
+		return informers[0].GetIndexer()
+	}
+	indexer := make(namespacesIndexer, 0, len(informers))
+	for _, informer := range informers {
+		indexer = append(indexer, informer.GetIndexer())
+	}
+	return indexer
+}
+
+func (n namespacesIndexer) Add(obj interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (n namespacesIndexer) Update(obj interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (n namespacesIndexer) Delete(obj interface{}) error {
+	return errReadOnlyIndexer
+}
+
+func (n namespacesIndexer) Replace(list []interface{}, resourceVersion string) error {
+	return errReadOnlyIndexer
+}
+
+func (n namespacesIndexer) Resync() error {
+	return nil
+}
+
+func (n namespacesIndexer) AddIndexers(newIndexers cache.Indexers) error {
+	return errReadOnlyIndexer
+}
+
+func (n namespacesIndexer) List() []interface{} {
+	var items []interface{}
+	for _, indexer := range n {
+		items = append(items, indexer.List()...)
+	}
+	return items
+}
+
+func (n namespacesIndexer) ListKeys() []string {
+	var keys []string
+	for _, indexer := range n {
+		keys = append(keys, indexer.ListKeys()...)
+	}
+	return keys
+}
+
+func (n namespacesIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return n.GetByKey(key)
+}
+
+// GetByKey returns the object of key from the first indexer holding it
+func (n namespacesIndexer) GetByKey(key string) (interface{}, bool, error) {
+	for _, indexer := range n {
+		item, exists, err := indexer.GetByKey(key)
+		if err != nil || exists {
+			return item, exists, err
+		}
+	}
+	return nil, false, nil
+}
+
+func (n namespacesIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	var items []interface{}
+	for _, indexer := range n {
+		indexed, err := indexer.Index(indexName, obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, indexed...)
+	}
+	return items, nil
+}
+
+func (n namespacesIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var keys []string
+	for _, indexer := range n {
+		indexed, err := indexer.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, indexed...)
+	}
+	return keys, nil
+}
+
+func (n namespacesIndexer) ListIndexFuncValues(indexName string) []string {
+	var values []string
+	for _, indexer := range n {
+		values = append(values, indexer.ListIndexFuncValues(indexName)...)
+	}
+	return values
+}
+
+func (n namespacesIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	var items []interface{}
+	for _, indexer := range n {
+		indexed, err := indexer.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, indexed...)
+	}
+	return items, nil
+}
+
+func (n namespacesIndexer) GetIndexers() cache.Indexers {
+	if len(n) == 0 {
+		return cache.Indexers{}
+	}
+	return n[0].GetIndexers()
+}
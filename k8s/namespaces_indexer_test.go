@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// testIndexer returns a namespace indexed indexer holding pods of names in namespace
+func testIndexer(t *testing.T, namespace string, names ...string) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, name := range names {
+		pod := &coreV1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if err := indexer.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return indexer
+}
+
+// podKeys returns the sorted namespace/name keys of the pods of items
+func podKeys(items []interface{}) string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		pod := item.(*coreV1.Pod)
+		keys = append(keys, pod.Namespace+"/"+pod.Name)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func TestNamespacesIndexer(t *testing.T) {
+	indexer := namespacesIndexer{testIndexer(t, "default", "api-0", "web-0"), testIndexer(t, "kube-system", "dns-0")}
+
+	t.Run("list", func(t *testing.T) {
+		if keys := podKeys(indexer.List()); keys != "default/api-0,default/web-0,kube-system/dns-0" {
+			t.Errorf("unexpected list %s", keys)
+		}
+		keys := indexer.ListKeys()
+		sort.Strings(keys)
+		if strings.Join(keys, ",") != "default/api-0,default/web-0,kube-system/dns-0" {
+			t.Errorf("unexpected keys %v", keys)
+		}
+	})
+
+	t.Run("by index", func(t *testing.T) {
+		tests := []struct {
+			namespace string
+			expected  string
+		}{
+			{namespace: "default", expected: "default/api-0,default/web-0"},
+			{namespace: "kube-system", expected: "kube-system/dns-0"},
+			{namespace: "monitoring", expected: ""},
+		}
+		for _, tc := range tests {
+			items, err := indexer.ByIndex(cache.NamespaceIndex, tc.namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keys := podKeys(items); keys != tc.expected {
+				t.Errorf("expecting %s pods %q, got %q", tc.namespace, tc.expected, keys)
+			}
+		}
+		if _, err := indexer.ByIndex("missing", "default"); err == nil {
+			t.Error("expecting an error for a missing index")
+		}
+	})
+
+	t.Run("get by key", func(t *testing.T) {
+		tests := []struct {
+			key    string
+			exists bool
+		}{
+			{key: "default/web-0", exists: true},
+			{key: "kube-system/dns-0", exists: true},
+			{key: "kube-system/web-0"},
+		}
+		for _, tc := range tests {
+			item, exists, err := indexer.GetByKey(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != tc.exists {
+				t.Fatalf("expecting key %s exists %t, got %t", tc.key, tc.exists, exists)
+			}
+			if exists && podKeys([]interface{}{item}) != tc.key {
+				t.Errorf("expecting pod %s, got %s", tc.key, podKeys([]interface{}{item}))
+			}
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		pod := &coreV1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "new-0"}}
+		writes := map[string]error{
+			"add":          indexer.Add(pod),
+			"update":       indexer.Update(pod),
+			"delete":       indexer.Delete(pod),
+			"replace":      indexer.Replace([]interface{}{pod}, "1"),
+			"add indexers": indexer.AddIndexers(cache.Indexers{"node": cache.MetaNamespaceIndexFunc}),
+		}
+		for name, err := range writes {
+			if err != errReadOnlyIndexer {
+				t.Errorf("expecting %s to return %v, got %v", name, errReadOnlyIndexer, err)
+			}
+		}
+		if len(indexer.List()) != 3 {
+			t.Errorf("expecting the indexers unchanged, got %d pods", len(indexer.List()))
+		}
+	})
+}
+
+func TestSplitNamespaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		expected  []string
+	}{
+		{name: "all namespaces", namespace: AllNamespaces, expected: []string{AllNamespaces}},
+		{name: "single", namespace: "default", expected: []string{"default"}},
+		{name: "trimmed", namespace: " default , kube-system ", expected: []string{"default", "kube-system"}},
+		{name: "duplicates", namespace: "default,kube-system,default", expected: []string{"default", "kube-system"}},
+		{name: "empty entries", namespace: "default,,kube-system,", expected: []string{"default", "kube-system"}},
+		{name: "only separators", namespace: " , ,", expected: []string{AllNamespaces}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			namespaces := splitNamespaces(tc.namespace)
+			if strings.Join(namespaces, ",") != strings.Join(tc.expected, ",") || len(namespaces) != len(tc.expected) {
+				t.Errorf("expecting namespaces %q, got %q", tc.expected, namespaces)
+			}
+		})
+	}
+}
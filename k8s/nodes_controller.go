@@ -8,10 +8,14 @@ import (
 	"github.com/vladimirvivien/ktop/views/model"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+// GetNode returns the cached node with the specified name
 func (c *Controller) GetNode(ctx context.Context, nodeName string) (*coreV1.Node, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -23,6 +27,7 @@ func (c *Controller) GetNode(ctx context.Context, nodeName string) (*coreV1.Node
 	return node, nil
 }
 
+// GetNodeList returns all cached nodes
 func (c *Controller) GetNodeList(ctx context.Context) ([]*coreV1.Node, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -39,6 +44,7 @@ func (c *Controller) GetNodeList(ctx context.Context) ([]*coreV1.Node, error) {
 	return items, nil
 }
 
+// GetNodeModels returns a model for each node, including the node's pod count and pod requests
 func (c *Controller) GetNodeModels(ctx context.Context) (models []model.NodeModel, err error) {
 	nodes, err := c.GetNodeList(ctx)
 	if err != nil {
@@ -62,10 +68,26 @@ func (c *Controller) GetNodeModels(ctx context.Context) (models []model.NodeMode
 		nodeModel.PodsCount = podsCount
 		nodeModel.RequestedPodMemQty = resource.NewQuantity(0, resource.DecimalSI)
 		nodeModel.RequestedPodCpuQty = resource.NewQuantity(0, resource.DecimalSI)
+		nodeModel.LimitPodMemQty = resource.NewQuantity(0, resource.DecimalSI)
+		nodeModel.LimitPodCpuQty = resource.NewQuantity(0, resource.DecimalSI)
+		memLimited, cpuLimited := true, true
 		for _, pod := range nodePods {
 			summary := model.GetPodContainerSummary(pod)
 			nodeModel.RequestedPodMemQty.Add(*summary.RequestedMemQty)
 			nodeModel.RequestedPodCpuQty.Add(*summary.RequestedCpuQty)
+			memLimited = memLimited && !summary.LimitMemQty.IsZero()
+			cpuLimited = cpuLimited && !summary.LimitCpuQty.IsZero()
+			nodeModel.LimitPodMemQty.Add(*summary.LimitMemQty)
+			nodeModel.LimitPodCpuQty.Add(*summary.LimitCpuQty)
+			requests, _ := model.PodHugePages(pod)
+			nodeModel.RequestedPodHugePages = model.AddResources(nodeModel.RequestedPodHugePages, requests)
+		}
+		// the limits of the node pods are unbounded when one of them is not limited
+		if !memLimited {
+			nodeModel.LimitPodMemQty = nil
+		}
+		if !cpuLimited {
+			nodeModel.LimitPodCpuQty = nil
 		}
 
 		models = append(models, *nodeModel)
@@ -73,6 +95,15 @@ func (c *Controller) GetNodeModels(ctx context.Context) (models []model.NodeMode
 	return
 }
 
+// GetNodePods returns the cached pods scheduled on the specified node
+func (c *Controller) GetNodePods(ctx context.Context, nodeName string) ([]*coreV1.Pod, error) {
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getPodNodes(nodeName, pods), nil
+}
+
 func (c *Controller) assertNodeAuthz(ctx context.Context) error {
 	authzd, err := c.client.IsAuthz(ctx, "nodes", []string{"get", "list"})
 	if err != nil {
@@ -85,9 +116,12 @@ func (c *Controller) assertNodeAuthz(ctx context.Context) error {
 }
 
 func (c *Controller) setupNodeHandler(ctx context.Context, handlerFunc RefreshNodesFunc) {
+	if handlerFunc == nil {
+		return
+	}
 	go func() {
 		c.refreshNodes(ctx, handlerFunc) // initial refresh
-		ticker := time.NewTicker(5 * time.Second)
+		ticker := time.NewTicker(NodesRefreshInterval)
 		defer ticker.Stop()
 		for {
 			select {
@@ -107,8 +141,7 @@ func (c *Controller) refreshNodes(ctx context.Context, handlerFunc RefreshNodesF
 	if err != nil {
 		return err
 	}
-	handlerFunc(ctx, models)
-	return nil
+	return handlerFunc(ctx, models)
 }
 
 func getPodNodes(nodeName string, pods []*coreV1.Pod) []*coreV1.Pod {
@@ -121,3 +154,46 @@ func getPodNodes(nodeName string, pods []*coreV1.Pod) []*coreV1.Pod {
 	}
 	return result
 }
+
+// LabelNode sets the specified labels on a node; labels with a nil value are removed
+func (c *Controller) LabelNode(ctx context.Context, name string, labels map[string]*string) error {
+	patch, err := labelsPatch(labels)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.kubeClient.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// TaintNode adds taints to a node, replacing taints with the same key and effect,
+// and removes the node taints matching the key (and effect, when set) of the remove taints.
+func (c *Controller) TaintNode(ctx context.Context, name string, add, remove []coreV1.Taint) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.client.kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		var taints []coreV1.Taint
+		for _, taint := range node.Spec.Taints {
+			if matchTaint(taint, remove) || matchTaint(taint, add) {
+				continue
+			}
+			taints = append(taints, taint)
+		}
+		node.Spec.Taints = append(taints, add...)
+
+		_, err = c.client.kubeClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// matchTaint returns true if taint has the key, and effect when specified, of one of taints
+func matchTaint(taint coreV1.Taint, taints []coreV1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && (t.Effect == "" || t.Effect == taint.Effect) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetPDBModels returns a model for each pod disruption budget, sorted by namespace and name
+func (c *Controller) GetPDBModels(ctx context.Context) ([]model.PDBModel, error) {
+	pdbs, err := c.GetPDBList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]model.PDBModel, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		models = append(models, model.NewPDBModel(pdb, pods))
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
@@ -6,21 +6,67 @@ import (
 
 	"github.com/vladimirvivien/ktop/views/model"
 	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+// GetPodList returns all cached pods in the client namespace scope
 func (c *Controller) GetPodList(ctx context.Context) ([]*coreV1.Pod, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-	items, err := c.podInformer.Lister().List(labels.Everything())
+	items, err := c.podLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
+// GetPod returns the cached pod with the specified namespace and name
+func (c *Controller) GetPod(ctx context.Context, namespace, name string) (*coreV1.Pod, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// GetFailedPods returns the cached pods in phase Failed, which includes evicted pods
+func (c *Controller) GetFailedPods(ctx context.Context) ([]*coreV1.Pod, error) {
+	pods, err := c.GetPodList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []*coreV1.Pod
+	for _, pod := range pods {
+		if pod.Status.Phase == coreV1.PodFailed {
+			result = append(result, pod)
+		}
+	}
+	return result, nil
+}
+
+// DeletePod deletes the specified pod from the cluster
+func (c *Controller) DeletePod(ctx context.Context, namespace, name string) error {
+	return c.client.kubeClient.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// LabelPod sets the specified labels on a pod; labels with a nil value are removed
+func (c *Controller) LabelPod(ctx context.Context, namespace, name string, labels map[string]*string) error {
+	patch, err := labelsPatch(labels)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.kubeClient.CoreV1().Pods(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// GetPodModels returns a model for each pod, including pod usage and related node resources
 func (c *Controller) GetPodModels(ctx context.Context) (models []model.PodModel, err error) {
 	pods, err := c.GetPodList(ctx)
 	if err != nil {
@@ -28,6 +74,7 @@ func (c *Controller) GetPodModels(ctx context.Context) (models []model.PodModel,
 	}
 	nodeMetricsCache := make(map[string]*metricsV1beta1.NodeMetrics)
 	nodeAllocResMap := make(map[string]coreV1.ResourceList)
+	nodePressuresMap := make(map[string][]string)
 	for _, pod := range pods {
 
 		// retrieve metrics per pod
@@ -46,6 +93,15 @@ func (c *Controller) GetPodModels(ctx context.Context) (models []model.PodModel,
 		}
 		nodeMetrics := nodeMetricsCache[pod.Spec.NodeName]
 
+		// retrieve and cache the pressure conditions of the pod's node
+		if _, ok := nodePressuresMap[pod.Spec.NodeName]; !ok {
+			var pressures []string
+			if node, err := c.GetNode(ctx, pod.Spec.NodeName); err == nil {
+				pressures = model.GetNodePressures(node)
+			}
+			nodePressuresMap[pod.Spec.NodeName] = pressures
+		}
+
 		model := model.NewPodModel(pod, podMetrics, nodeMetrics)
 
 		// retrieve pod's node allocatable resources
@@ -61,6 +117,10 @@ func (c *Controller) GetPodModels(ctx context.Context) (models []model.PodModel,
 		alloc := nodeAllocResMap[pod.Spec.NodeName]
 		model.NodeAllocatableMemQty = alloc.Memory()
 		model.NodeAllocatableCpuQty = alloc.Cpu()
+		model.NodePressures = nodePressuresMap[pod.Spec.NodeName]
+		if c.cpuThrottling != nil {
+			model.ThrottlePercent, model.ThrottleSampled = c.cpuThrottling.podThrottling(pod.Namespace, pod.Name)
+		}
 		models = append(models, *model)
 	}
 	return
@@ -72,7 +132,7 @@ func (c *Controller) installPodsHandler(ctx context.Context, refreshFunc Refresh
 	}
 	go func() {
 		c.refreshPods(ctx, refreshFunc) // initial refresh
-		ticker := time.NewTicker(3 * time.Second)
+		ticker := time.NewTicker(PodsRefreshInterval)
 		defer ticker.Stop()
 		for {
 			select {
@@ -92,6 +152,5 @@ func (c *Controller) refreshPods(ctx context.Context, refreshFunc RefreshPodsFun
 	if err != nil {
 		return err
 	}
-	refreshFunc(ctx, models)
-	return nil
+	return refreshFunc(ctx, models)
 }
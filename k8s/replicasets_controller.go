@@ -0,0 +1,21 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// GetReplicaSetIssues returns the models of the orphaned, old, and excess replica sets,
+// sorted by namespace and name
+func (c *Controller) GetReplicaSetIssues(ctx context.Context) ([]model.ReplicaSetModel, error) {
+	replicaSets, err := c.GetReplicaSetList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := c.GetDeploymentList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model.ReplicaSetIssues(replicaSets, deployments), nil
+}
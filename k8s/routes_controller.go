@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/klog/v2"
+)
+
+// GetRouteModels returns a model for each Ingress and, when the Gateway API is installed, each
+// HTTPRoute, sorted by namespace and name. The backends are linked to the health of their service.
+func (c *Controller) GetRouteModels(ctx context.Context) ([]model.RouteModel, error) {
+	ingresses, err := c.GetIngressList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes := make([]model.RouteModel, 0, len(ingresses))
+	for _, ing := range ingresses {
+		routes = append(routes, model.NewIngressModel(ing))
+	}
+
+	httpRoutes, err := c.client.GetHTTPRoutes(ctx)
+	if err != nil {
+		klog.V(2).Infof("gateway api: %s", err)
+		c.recordAPIError("httproutes", err)
+	}
+	routes = append(routes, httpRoutes...)
+
+	services, err := c.GetServiceModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]model.ServiceModel)
+	for _, svc := range services {
+		byName[svc.Namespace+"/"+svc.Name] = svc
+	}
+	for i := range routes {
+		for j := range routes[i].Backends {
+			backend := &routes[i].Backends[j]
+			key := routes[i].Namespace + "/" + backend.Service
+			if strings.Contains(backend.Service, "/") {
+				key = backend.Service // cross-namespace backend
+			}
+			if svc, ok := byName[key]; ok {
+				backend.ServiceFound = true
+				backend.EndpointsReady = svc.EndpointsReady
+				backend.EndpointsNotReady = svc.EndpointsNotReady
+			}
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+	return routes, nil
+}
@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// GetServiceModels returns a model for each service, sorted by namespace and name
+func (c *Controller) GetServiceModels(ctx context.Context) ([]model.ServiceModel, error) {
+	services, err := c.GetServiceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := c.GetEndpointsList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// endpoints are named after their service
+	byService := make(map[string]*coreV1.Endpoints)
+	for _, ep := range endpoints {
+		byService[ep.Namespace+"/"+ep.Name] = ep
+	}
+
+	models := make([]model.ServiceModel, 0, len(services))
+	for _, svc := range services {
+		models = append(models, model.NewServiceModel(svc, byService[svc.Namespace+"/"+svc.Name]))
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
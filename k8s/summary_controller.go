@@ -12,9 +12,12 @@ import (
 )
 
 func (c *Controller) setupSummaryHandler(ctx context.Context, handlerFunc RefreshSummaryFunc) {
+	if handlerFunc == nil {
+		return
+	}
 	go func() {
 		c.refreshSummary(ctx, handlerFunc)
-		ticker := time.NewTicker(5 * time.Second)
+		ticker := time.NewTicker(SummaryRefreshInterval)
 		defer ticker.Stop()
 		for {
 			select {
@@ -30,25 +33,50 @@ func (c *Controller) setupSummaryHandler(ctx context.Context, handlerFunc Refres
 }
 
 func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSummaryFunc) error {
-	var summary model.ClusterSummary
+	summary, err := c.GetClusterSummary(ctx)
+	if err != nil {
+		return err
+	}
+	return handlerFunc(ctx, summary)
+}
 
+// GetClusterSummary aggregates the cached cluster resources into a cluster summary
+func (c *Controller) GetClusterSummary(ctx context.Context) (summary model.ClusterSummary, err error) {
 	// extract namespace summary
 	namespaces, err := c.GetNamespaceList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.Namespaces = len(namespaces)
+	now := time.Now()
+	for _, ns := range namespaces {
+		m := model.NewNamespaceModel(ns, now)
+		switch {
+		case m.Stuck:
+			summary.NamespacesStuck++
+			summary.NamespacesTerminating++
+		case m.Terminating():
+			summary.NamespacesTerminating++
+		case m.Recent:
+			summary.NamespacesRecent++
+		}
+	}
 
 	nodes, err := c.GetNodeList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.Uptime = metav1.NewTime(time.Now())
 	summary.NodesCount = len(nodes)
 	summary.AllocatableNodeMemTotal = resource.NewQuantity(0, resource.DecimalSI)
 	summary.AllocatableNodeCpuTotal = resource.NewQuantity(0, resource.DecimalSI)
+	summary.CapacityNodeMemTotal = resource.NewQuantity(0, resource.DecimalSI)
+	summary.CapacityNodeCpuTotal = resource.NewQuantity(0, resource.DecimalSI)
 	summary.UsageNodeMemTotal = resource.NewQuantity(0, resource.DecimalSI)
 	summary.UsageNodeCpuTotal = resource.NewQuantity(0, resource.DecimalSI)
+	summary.NodesByRole = make(map[string]int)
+	summary.KubeletVersions = make(map[string]int)
+	apiVersion := c.client.GetServerVersion()
 	for _, node := range nodes {
 		if model.GetNodeReadyStatus(node) == string(coreV1.NodeReady) {
 			summary.NodesReady++
@@ -57,12 +85,28 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 			summary.Uptime = node.CreationTimestamp
 		}
 
+		roles := model.GetNodeRoles(node)
+		if len(roles) == 0 {
+			roles = []string{"<none>"}
+		}
+		for _, role := range roles {
+			summary.NodesByRole[role]++
+		}
+
+		kubeletVersion := node.Status.NodeInfo.KubeletVersion
+		summary.KubeletVersions[kubeletVersion]++
+		if !model.KubeletSkewSupported(apiVersion, kubeletVersion) {
+			summary.SkewedNodes++
+		}
+
 		summary.Pressures += len(model.GetNodePressures(node))
 		summary.ImagesCount += len(node.Status.Images)
 		summary.VolumesInUse += len(node.Status.VolumesInUse)
 
 		summary.AllocatableNodeMemTotal.Add(*node.Status.Allocatable.Memory())
 		summary.AllocatableNodeCpuTotal.Add(*node.Status.Allocatable.Cpu())
+		summary.CapacityNodeMemTotal.Add(*node.Status.Capacity.Memory())
+		summary.CapacityNodeCpuTotal.Add(*node.Status.Capacity.Cpu())
 
 		metrics, err := c.GetNodeMetrics(ctx, node.Name)
 		if err != nil {
@@ -75,14 +119,26 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// extract pods summary
 	pods, err := c.GetPodList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.PodsAvailable = len(pods)
 	summary.RequestedPodMemTotal = resource.NewQuantity(0, resource.DecimalSI)
 	summary.RequestedPodCpuTotal = resource.NewQuantity(0, resource.DecimalSI)
 	for _, pod := range pods {
-		if pod.Status.Phase == coreV1.PodRunning {
+		switch pod.Status.Phase {
+		case coreV1.PodRunning:
 			summary.PodsRunning++
+		case coreV1.PodPending:
+			summary.PodsPending++
+			if podUnschedulable(pod) {
+				summary.PodsUnschedulable++
+			}
+		case coreV1.PodFailed:
+			summary.PodsFailed++
+		case coreV1.PodSucceeded:
+			summary.PodsSucceeded++
+		default:
+			summary.PodsUnknown++
 		}
 		containerSummary := model.GetPodContainerSummary(pod)
 		summary.RequestedPodMemTotal.Add(*containerSummary.RequestedMemQty)
@@ -92,7 +148,7 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// deployments count
 	deps, err := c.GetDeploymentList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	for _, dep := range deps {
 		summary.DeploymentsTotal += int(dep.Status.Replicas)
@@ -102,7 +158,7 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// deamonset count
 	daemonsets, err := c.GetDaemonSetList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	for _, set := range daemonsets {
 		summary.DaemonSetsDesired += int(set.Status.DesiredNumberScheduled)
@@ -112,7 +168,7 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// replicasets count
 	replicasets, err := c.GetReplicaSetList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	for _, replica := range replicasets {
 		summary.ReplicaSetsDesired += int(replica.Status.Replicas)
@@ -122,7 +178,7 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// statefulsets count
 	statefulsets, err := c.GetStatefulSetList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	for _, stateful := range statefulsets {
 		summary.StatefulSetsReady += int(stateful.Status.ReadyReplicas)
@@ -131,18 +187,18 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 	// extract jobs summary
 	jobs, err := c.GetJobList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.JobsCount = len(jobs)
 	cronjobs, err := c.GetCronJobList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.CronJobsCount = len(cronjobs)
 
 	pvs, err := c.GetPVList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.PVCount = len(pvs)
 	summary.PVsTotal = resource.NewQuantity(0, resource.DecimalSI)
@@ -154,7 +210,7 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 
 	pvcs, err := c.GetPVCList(ctx)
 	if err != nil {
-		return err
+		return
 	}
 	summary.PVCCount = len(pvcs)
 	summary.PVCsTotal = resource.NewQuantity(0, resource.DecimalSI)
@@ -164,6 +220,49 @@ func (c *Controller) refreshSummary(ctx context.Context, handlerFunc RefreshSumm
 		}
 	}
 
-	handlerFunc(ctx, summary)
-	return nil
+	// services, endpoints, and ingresses count
+	services, err := c.GetServiceList(ctx)
+	if err != nil {
+		return
+	}
+	summary.ServicesCount = len(services)
+	summary.ServicesByType = make(map[string]int)
+	for _, svc := range services {
+		summary.ServicesByType[string(svc.Spec.Type)]++
+	}
+
+	endpoints, err := c.GetEndpointsList(ctx)
+	if err != nil {
+		return
+	}
+	for _, ep := range endpoints {
+		for _, subset := range ep.Subsets {
+			summary.EndpointsReady += len(subset.Addresses)
+			summary.EndpointsNotReady += len(subset.NotReadyAddresses)
+		}
+	}
+
+	ingresses, err := c.GetIngressList(ctx)
+	if err != nil {
+		return
+	}
+	summary.IngressesCount = len(ingresses)
+
+	quotas, err := c.GetResourceQuotaList(ctx)
+	if err != nil {
+		return
+	}
+	summary.Quota = model.MostConstrainedQuota(quotas)
+
+	return summary, nil
+}
+
+// podUnschedulable returns true if the scheduler reported the pod as unschedulable
+func podUnschedulable(pod *coreV1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == coreV1.PodScheduled && cond.Status == coreV1.ConditionFalse && cond.Reason == coreV1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/vladimirvivien/ktop/k8s"
+	"golang.org/x/net/websocket"
+	"k8s.io/klog/v2"
+)
+
+// Server exposes the models collected by the k8s.Controller as JSON
+// endpoints and as a WebSocket stream:
+//
+//	GET /api/summary  cluster summary
+//	GET /api/nodes    node models
+//	GET /api/pods     pod models
+//	GET /api/stream   WebSocket stream of summary, nodes, and pods messages
+//
+// Requests are served only for the listened host, or localhost, so that other
+// sites cannot read the endpoints through DNS rebinding, and the stream is only
+// opened from pages of the same host.
+type Server struct {
+	addr     string
+	ctrl     *k8s.Controller
+	interval time.Duration
+	server   *http.Server
+}
+
+// Message is the envelope of the data sent over the WebSocket stream
+type Message struct {
+	Kind string      `json:"kind"` // summary, nodes, or pods
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// New returns a server listening on addr (i.e. :8080) which pushes
+// updates to WebSocket clients at the specified interval. Without a host,
+// the server listens on localhost only; the endpoints have no authentication,
+// so listening on other interfaces requires an explicit host (i.e. 0.0.0.0:8080).
+func New(addr string, ctrl *k8s.Controller, interval time.Duration) *Server {
+	addr = listenAddr(addr)
+	s := &Server{addr: addr, ctrl: ctrl, interval: interval}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/summary", s.handleSummary)
+	mux.HandleFunc("/api/nodes", s.handleNodes)
+	mux.HandleFunc("/api/pods", s.handlePods)
+	mux.Handle("/api/stream", websocket.Server{Handler: s.handleStream, Handshake: checkOrigin})
+	s.server = &http.Server{Addr: addr, Handler: s.checkHost(mux)}
+
+	return s
+}
+
+// Addr returns the address the server listens on, with the port listened on once started
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// listenAddr returns addr, with the localhost host when addr has no host
+func listenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+// checkHost rejects the requests for a host other than the listened host or
+// localhost. When listening on all interfaces, the IP addresses of the host are
+// accepted too: a DNS rebinding request is for the name of the rebound site.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	listenHost, _, _ := net.SplitHostPort(s.addr)
+	listenIP := net.ParseIP(listenHost)
+	anyHost := listenHost == "" || (listenIP != nil && listenIP.IsUnspecified())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		}
+		ip := net.ParseIP(host)
+		switch {
+		case host == listenHost, host == "localhost", ip != nil && ip.IsLoopback(), anyHost && ip != nil:
+			next.ServeHTTP(w, r)
+		default:
+			http.Error(w, "host not allowed", http.StatusForbidden)
+		}
+	})
+}
+
+// checkOrigin accepts the WebSocket handshakes without origin, from non-browser
+// clients, or from a page of the requested host only
+func checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin != nil && origin.Host != req.Host {
+		return fmt.Errorf("origin %s not allowed", origin)
+	}
+	config.Origin = origin
+	return nil
+}
+
+// Start listens on the server address and serves requests, in the background,
+// until ctx is done. It returns an error if the address cannot be listened on.
+// The requests, and the WebSocket streams, are cancelled with ctx: the streams
+// are hijacked connections, which the server shutdown does not wait for.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.addr = listener.Addr().String()
+	s.server.BaseContext = func(net.Listener) context.Context { return ctx }
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Errorf("server: %s", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.server.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("server shutdown: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	s.serveJSON(w, r, func(ctx context.Context) (interface{}, error) {
+		return s.ctrl.GetClusterSummary(ctx)
+	})
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	s.serveJSON(w, r, func(ctx context.Context) (interface{}, error) {
+		return s.ctrl.GetNodeModels(ctx)
+	})
+}
+
+func (s *Server) handlePods(w http.ResponseWriter, r *http.Request) {
+	s.serveJSON(w, r, func(ctx context.Context) (interface{}, error) {
+		return s.ctrl.GetPodModels(ctx)
+	})
+}
+
+func (s *Server) serveJSON(w http.ResponseWriter, r *http.Request, get func(context.Context) (interface{}, error)) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.isReady() {
+		http.Error(w, "controller not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	data, err := get(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		klog.Errorf("server: encode response: %s", err)
+	}
+}
+
+func (s *Server) handleStream(ws *websocket.Conn) {
+	defer ws.Close()
+	ctx := ws.Request().Context()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-s.ctrl.Ready():
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.sendUpdates(ctx, ws); err != nil {
+			klog.V(2).Infof("server: stream closed: %s", err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) sendUpdates(ctx context.Context, ws *websocket.Conn) error {
+	summary, err := s.ctrl.GetClusterSummary(ctx)
+	if err != nil {
+		return err
+	}
+	if err := websocket.JSON.Send(ws, Message{Kind: "summary", Time: time.Now(), Data: summary}); err != nil {
+		return err
+	}
+
+	nodes, err := s.ctrl.GetNodeModels(ctx)
+	if err != nil {
+		return err
+	}
+	if err := websocket.JSON.Send(ws, Message{Kind: "nodes", Time: time.Now(), Data: nodes}); err != nil {
+		return err
+	}
+
+	pods, err := s.ctrl.GetPodModels(ctx)
+	if err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, Message{Kind: "pods", Time: time.Now(), Data: pods})
+}
+
+func (s *Server) isReady() bool {
+	select {
+	case <-s.ctrl.Ready():
+		return true
+	default:
+		return false
+	}
+}
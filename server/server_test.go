@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/k8s"
+	"golang.org/x/net/websocket"
+)
+
+func TestListenAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     string
+		expected string
+	}{
+		{name: "port only", addr: ":8080", expected: "localhost:8080"},
+		{name: "all interfaces", addr: "0.0.0.0:8080", expected: "0.0.0.0:8080"},
+		{name: "host", addr: "10.0.0.5:8080", expected: "10.0.0.5:8080"},
+		{name: "IPv6 host", addr: "[::1]:8080", expected: "[::1]:8080"},
+		{name: "invalid", addr: "8080", expected: "8080"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if actual := listenAddr(tc.addr); actual != tc.expected {
+				t.Errorf("expecting address %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStreamShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the controller is never ready: the stream waits for it until cancelled
+	srv := New("127.0.0.1:0", &k8s.Controller{}, time.Second)
+	if err := srv.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := websocket.Dial("ws://"+srv.Addr()+"/api/stream", "", "http://"+srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	cancel()
+
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg Message
+	if err := websocket.JSON.Receive(ws, &msg); !errors.Is(err, io.EOF) {
+		t.Errorf("expecting the stream closed on shutdown, got %v", err)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     string
+		host     string
+		expected int
+	}{
+		{name: "listened host", addr: "10.0.0.5:8080", host: "10.0.0.5:8080", expected: http.StatusOK},
+		{name: "localhost", addr: "localhost:8080", host: "localhost:8080", expected: http.StatusOK},
+		{name: "loopback address", addr: "localhost:8080", host: "127.0.0.1:8080", expected: http.StatusOK},
+		{name: "IPv6 loopback address", addr: "localhost:8080", host: "[::1]:8080", expected: http.StatusOK},
+		{name: "foreign host", addr: "localhost:8080", host: "attacker.example:8080", expected: http.StatusForbidden},
+		{name: "other address", addr: "localhost:8080", host: "10.0.0.5:8080", expected: http.StatusForbidden},
+		{name: "address of all interfaces", addr: "0.0.0.0:8080", host: "10.0.0.5:8080", expected: http.StatusOK},
+		{name: "foreign host on all interfaces", addr: "0.0.0.0:8080", host: "attacker.example:8080", expected: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			srv := &Server{addr: tc.addr}
+			handler := srv.checkHost(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+			req := httptest.NewRequest(http.MethodGet, "/api/pods", nil)
+			req.Host = tc.host
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.expected {
+				t.Errorf("expecting status %d, got %d", tc.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestStreamOrigin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := New("127.0.0.1:0", &k8s.Controller{}, time.Second)
+	if err := srv.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		origin   string
+		expected bool
+	}{
+		{name: "same host", origin: "http://" + srv.Addr(), expected: true},
+		{name: "foreign origin", origin: "http://attacker.example"},
+		{name: "other port", origin: "http://127.0.0.1:1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			ws, err := websocket.Dial("ws://"+srv.Addr()+"/api/stream", "", tc.origin)
+			if err == nil {
+				ws.Close()
+			}
+			if opened := err == nil; opened != tc.expected {
+				t.Errorf("expecting stream opened %t, got %t (%v)", tc.expected, opened, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// UTF8Locale returns true if the locale (LC_ALL, LC_CTYPE, or LANG, the first one set)
+// uses UTF-8. As with tcell, the C and POSIX locales use ASCII, and a locale without
+// a character set, or no locale, is assumed to use UTF-8.
+func UTF8Locale() bool {
+	locale := os.Getenv("LC_ALL")
+	if locale == "" {
+		locale = os.Getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = os.Getenv("LANG")
+	}
+	return utf8Charset(locale)
+}
+
+func utf8Charset(locale string) bool {
+	if locale == "C" || locale == "POSIX" {
+		return false
+	}
+	if i := strings.IndexRune(locale, '@'); i >= 0 {
+		locale = locale[:i]
+	}
+	i := strings.IndexRune(locale, '.')
+	if i < 0 {
+		return true
+	}
+	charset := strings.ToLower(strings.ReplaceAll(locale[i+1:], "-", ""))
+	return charset == "utf8"
+}
+
+// UseASCII replaces the icons, arrows, graph glyphs, and panel borders with ASCII
+// equivalents for terminals without UTF-8 (i.e. serial consoles, minimal containers).
+// It must be called before the views are laid out.
+func UseASCII() {
+	Icons.BargraphChar = '|'
+	Icons.BargraphPeak = ':'
+	Icons.Factory = '#'
+	Icons.Battery = '#'
+	Icons.Package = '#'
+	Icons.Anchor = '#'
+	Icons.Rocket = '>'
+	Icons.Thermometer = '#'
+	Icons.Sun = '*'
+	Icons.Knobs = '#'
+	Icons.Drum = '#'
+	Icons.M = 'M'
+	Icons.Plane = '#'
+	Icons.Controller = '#'
+	Icons.Clock = '@'
+	Icons.TrafficLight = 'C'
+	Icons.Check = '*'
+	Icons.Pin = '^'
+	Icons.GraphBlock = '#'
+	Icons.Warning = '!'
+	Icons.ArrowUp = '^'
+	Icons.ArrowDown = 'v'
+	Icons.ArrowRight = '>'
+	Icons.TreeBranch = '`'
+	Icons.Ellipsis = '~'
+
+	sparkLevels = []rune("_.-=*#")
+	if currentGraphStyle.bar != 0 {
+		currentGraphStyle = graphStyles[GraphStyleASCII]
+	}
+
+	tview.Borders.Horizontal, tview.Borders.Vertical = '-', '|'
+	tview.Borders.TopLeft, tview.Borders.TopRight = '+', '+'
+	tview.Borders.BottomLeft, tview.Borders.BottomRight = '+', '+'
+	tview.Borders.LeftT, tview.Borders.RightT, tview.Borders.TopT, tview.Borders.BottomT = '+', '+', '+', '+'
+	tview.Borders.Cross = '+'
+	tview.Borders.HorizontalFocus, tview.Borders.VerticalFocus = '=', '|'
+	tview.Borders.TopLeftFocus, tview.Borders.TopRightFocus = '+', '+'
+	tview.Borders.BottomLeftFocus, tview.Borders.BottomRightFocus = '+', '+'
+}
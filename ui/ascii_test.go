@@ -0,0 +1,27 @@
+package ui
+
+import "testing"
+
+func TestUTF8Charset(t *testing.T) {
+	testCases := []struct {
+		name     string
+		locale   string
+		expected bool
+	}{
+		{name: "no locale", locale: "", expected: true},
+		{name: "utf-8", locale: "en_US.UTF-8", expected: true},
+		{name: "utf8", locale: "C.utf8", expected: true},
+		{name: "modifier", locale: "de_DE.UTF-8@euro", expected: true},
+		{name: "no charset", locale: "en_US", expected: true},
+		{name: "C", locale: "C", expected: false},
+		{name: "POSIX", locale: "POSIX", expected: false},
+		{name: "latin1", locale: "fr_FR.ISO-8859-1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		if actual := utf8Charset(tc.locale); actual != tc.expected {
+			t.Errorf("expecting UTF-8 %t for locale %q, got %t", tc.expected, tc.locale, actual)
+		}
+	}
+}
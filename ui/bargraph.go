@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"strings"
@@ -42,10 +43,16 @@ func ColorKeysFromSlice(colors []string) ColorKeys {
 }
 
 // BarGraph returns a colorized string, like ||||||||, which represents
-// a bargrah built using scale and ratio values.  Colors provide key mapping
+// a bargrah built using scale and ratio values, drawn with the graph style (see SetGraphStyle).  Colors provide key mapping
 // to colorize the graph basede on the value of ratio.
 // If ratio is zero (nothing to graph), the function returns a series of dots.
 func BarGraph(scale int, ratio Ratio, colors ColorKeys) string {
+	return BarGraphWithPeak(scale, ratio, 0, colors)
+}
+
+// BarGraphWithPeak returns a bar graph, see BarGraph, with a peak marker at the
+// position of the peak ratio, colored using peak, when the peak is above ratio.
+func BarGraphWithPeak(scale int, ratio, peak Ratio, colors ColorKeys) string {
 	if scale == 0 {
 		return ""
 	}
@@ -67,9 +74,7 @@ func BarGraph(scale int, ratio Ratio, colors ColorKeys) string {
 		graph.WriteString("[")
 		graph.WriteString(color)
 		graph.WriteString("]")
-		for j := 0; j < (scale - graphVal); j++ {
-			graph.WriteString(" ")
-		}
+		writePadding(&graph, scale, graphVal, peak, colors, ".")
 		return graph.String()
 	}
 
@@ -96,16 +101,34 @@ func BarGraph(scale int, ratio Ratio, colors ColorKeys) string {
 	graph.WriteString(string(Icons.BargraphRBorder))
 
 	for i := 0; i < int(math.Min(float64(scale), float64(graphVal))); i++ {
-		graph.WriteRune(Icons.BargraphChar)
+		graph.WriteRune(currentGraphStyle.cell(normVal - float64(i)))
 	}
 
-	for j := 0; j < (scale - graphVal); j++ {
-		graph.WriteString(" ")
-	}
+	writePadding(&graph, scale, graphVal, peak, colors, " ")
 
 	return graph.String()
 }
 
+// writePadding writes the empty part of a bar graph, after graphVal bars, with
+// the peak marker when the peak is above the bars
+func writePadding(graph *strings.Builder, scale, graphVal int, peak Ratio, colors ColorKeys, pad string) {
+	peakVal := int(math.Min(math.Ceil(float64(peak)*float64(scale)), float64(scale)))
+	for j := graphVal + 1; j <= scale; j++ {
+		if j == peakVal {
+			graph.WriteString("[" + ratioColor(peak, colors) + "]")
+			graph.WriteRune(currentGraphStyle.peakMarker())
+			continue
+		}
+		graph.WriteString(pad)
+	}
+}
+
+// Percentage returns ratio as a percentage in the color of colors keyed by the largest key
+// not above the percentage, i.e. "[yellow]62%[white]"
+func Percentage(ratio Ratio, colors ColorKeys) string {
+	return fmt.Sprintf("[%s]%1.0f%%[white]", ratioColor(ratio, colors), ratio*100)
+}
+
 // GetRatio returns a ration between val0/val1.
 // If val <= 0, it return 0.
 func GetRatio(val0, val1 float64) Ratio {
@@ -114,3 +114,86 @@ func TestBarGraph(t *testing.T) {
 		}
 	}
 }
+
+func TestBarGraphWithPeak(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scale     int
+		ratio     Ratio
+		peak      Ratio
+		colorKeys ColorKeys
+		expected  string
+	}{
+		{name: "no peak", scale: 5, ratio: 0.4, colorKeys: ColorKeys{0: "green"}, expected: "[green]||   "},
+		{name: "peak below ratio", scale: 5, ratio: 0.4, peak: 0.2, colorKeys: ColorKeys{0: "green"}, expected: "[green]||   "},
+		{name: "peak above ratio", scale: 5, ratio: 0.4, peak: 0.8, colorKeys: ColorKeys{0: "green", 50: "red"}, expected: "[green]|| [red]┊ "},
+		{name: "peak over scale", scale: 5, ratio: 0.2, peak: 2, colorKeys: ColorKeys{0: "green"}, expected: "[green]|   [green]┊"},
+		{name: "peak without ratio", scale: 3, ratio: 0, peak: 0.5, colorKeys: ColorKeys{}, expected: "[silver].[white]┊."},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		actual := BarGraphWithPeak(tc.scale, tc.ratio, tc.peak, tc.colorKeys)
+		if actual != tc.expected {
+			t.Errorf("expecting graph [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
+
+func TestBarGraphStyles(t *testing.T) {
+	defer SetGraphStyle(GraphStyleBars)
+
+	testCases := []struct {
+		name     string
+		style    string
+		ratio    Ratio
+		peak     Ratio
+		expected string
+		err      bool
+	}{
+		{name: "default", style: "", ratio: 0.55, expected: "[green]|||  "},
+		{name: "blocks", style: GraphStyleBlocks, ratio: 0.55, expected: "[green]██▊  "},
+		{name: "blocks full", style: GraphStyleBlocks, ratio: 1, expected: "[green]█████"},
+		{name: "braille", style: GraphStyleBraille, ratio: 0.55, expected: "[green]⣿⣿⣧  "},
+		{name: "ascii", style: GraphStyleASCII, ratio: 0.55, peak: 1, expected: "[green]### [green]!"},
+		{name: "unsupported", style: "dots", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		if err := SetGraphStyle(tc.style); err != nil {
+			if !tc.err {
+				t.Errorf("unexpected error: %s", err)
+			}
+			continue
+		}
+		if tc.err {
+			t.Errorf("expecting an error for style %s", tc.style)
+			continue
+		}
+		actual := BarGraphWithPeak(5, tc.ratio, tc.peak, ColorKeys{0: "green"})
+		if actual != tc.expected {
+			t.Errorf("expecting graph [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
+
+func TestPercentage(t *testing.T) {
+	colors := ColorKeys{0: "green", 50: "yellow", 90: "red"}
+	testCases := []struct {
+		name     string
+		ratio    Ratio
+		expected string
+	}{
+		{name: "zero", ratio: 0, expected: "[green]0%[white]"},
+		{name: "yellow", ratio: 0.62, expected: "[yellow]62%[white]"},
+		{name: "over 100%", ratio: 1.5, expected: "[red]150%[white]"},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		if actual := Percentage(tc.ratio, colors); actual != tc.expected {
+			t.Errorf("expecting [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Bar graph styles
+const (
+	// GraphStyleBars draws bar graphs with vertical bars, i.e. ||||| (default)
+	GraphStyleBars = "bars"
+	// GraphStyleBlocks draws bar graphs with solid blocks, with eighth blocks for the last cell
+	GraphStyleBlocks = "blocks"
+	// GraphStyleBraille draws bar graphs with braille dots, with partial dots for the last cell
+	GraphStyleBraille = "braille"
+	// GraphStyleASCII draws bar graphs with ASCII hashes, i.e. #####
+	GraphStyleASCII = "ascii"
+)
+
+// graphStyle is the rendering of the bar graph cells
+type graphStyle struct {
+	bar     rune   // rune of a filled cell, Icons.BargraphChar when zero
+	partial []rune // runes of a partially filled last cell, from the least filled
+	peak    rune   // peak marker, Icons.BargraphPeak when zero
+}
+
+var graphStyles = map[string]graphStyle{
+	GraphStyleBars:    {},
+	GraphStyleBlocks:  {bar: '█', partial: []rune("▏▎▍▌▋▊▉")},
+	GraphStyleBraille: {bar: '⣿', partial: []rune("⡀⡄⡆⡇⣇⣧⣷")},
+	GraphStyleASCII:   {bar: '#', peak: '!'},
+}
+
+// currentGraphStyle is the style of the bar graphs, set with SetGraphStyle
+var currentGraphStyle = graphStyles[GraphStyleBars]
+
+// SetGraphStyle sets the style of the bar graphs: bars, blocks, braille, or ascii.
+// An empty name selects the default bars style.
+func SetGraphStyle(name string) error {
+	if name == "" {
+		name = GraphStyleBars
+	}
+	style, ok := graphStyles[name]
+	if !ok {
+		var names []string
+		for name := range graphStyles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unsupported graph style %q (%s)", name, strings.Join(names, ", "))
+	}
+	currentGraphStyle = style
+	return nil
+}
+
+// cell returns the rune of a graph cell filled by fill, from 0 to 1 (or more for a filled cell)
+func (s graphStyle) cell(fill float64) rune {
+	bar := s.bar
+	if bar == 0 {
+		bar = Icons.BargraphChar
+	}
+	if fill >= 1 || len(s.partial) == 0 {
+		return bar
+	}
+	level := int(math.Ceil(fill*float64(len(s.partial)+1))) - 1
+	if level >= len(s.partial) {
+		return bar
+	}
+	return s.partial[level]
+}
+
+func (s graphStyle) peakMarker() rune {
+	if s.peak == 0 {
+		return Icons.BargraphPeak
+	}
+	return s.peak
+}
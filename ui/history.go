@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"math"
+	"strings"
+)
+
+// HistoryGraph returns a colorized, multi-line, column graph of samples with the most
+// recent sample on the right. Each column is a sample scaled against max, colored using
+// its ratio to max; only the last width samples are graphed and fewer samples are
+// padded on the left with empty columns.
+func HistoryGraph(samples []float64, max float64, width, height int, colors ColorKeys) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	pad := width - len(samples)
+
+	// filled lines and color of each column
+	levels := make([]int, len(samples))
+	columnColors := make([]string, len(samples))
+	for i, sample := range samples {
+		ratio := GetRatio(sample, max)
+		levels[i] = int(math.Min(math.Ceil(float64(ratio)*float64(height)), float64(height)))
+		columnColors[i] = ratioColor(ratio, colors)
+	}
+
+	var graph strings.Builder
+	for line := height; line > 0; line-- {
+		graph.WriteString(strings.Repeat(" ", pad))
+		color := ""
+		for i, level := range levels {
+			if level < line {
+				graph.WriteString(" ")
+				continue
+			}
+			if columnColors[i] != color {
+				color = columnColors[i]
+				graph.WriteString("[" + color + "]")
+			}
+			graph.WriteRune(Icons.GraphBlock)
+		}
+		if line > 1 {
+			graph.WriteString("\n")
+		}
+	}
+	return graph.String()
+}
+
+// sparkLevels are the runes of a sparkline, from the lowest to the highest level
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns a colorized, single-line, graph of samples with the most recent
+// sample on the right. Each sample is scaled against max and colored using its ratio
+// to max; only the last width samples are graphed and fewer samples are padded on the
+// left with spaces.
+func Sparkline(samples []float64, max float64, width int, colors ColorKeys) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+
+	var graph strings.Builder
+	graph.WriteString(strings.Repeat(" ", width-len(samples)))
+	color := ""
+	for _, sample := range samples {
+		ratio := GetRatio(sample, max)
+		level := int(math.Min(float64(ratio)*float64(len(sparkLevels)), float64(len(sparkLevels)-1)))
+		if level < 0 {
+			level = 0
+		}
+		if c := ratioColor(ratio, colors); c != color {
+			color = c
+			graph.WriteString("[" + color + "]")
+		}
+		graph.WriteRune(sparkLevels[level])
+	}
+	return graph.String()
+}
+
+// ratioColor returns the color of colors keyed by the largest key not above ratio
+func ratioColor(ratio Ratio, colors ColorKeys) string {
+	color := colorNoKeys
+	key := int(float64(ratio) * 100)
+	for _, k := range colors.Keys() {
+		if key >= k {
+			color = colors[k]
+		}
+	}
+	return color
+}
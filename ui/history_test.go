@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+)
+
+func TestHistoryGraph(t *testing.T) {
+	testCases := []struct {
+		name    string
+		samples []float64
+		max     float64
+		width   int
+		height  int
+		colors  ColorKeys
+		graph   string
+	}{
+		{name: "no size", samples: []float64{1}, max: 1, width: 0, height: 2, graph: ""},
+		{name: "no samples", max: 1, width: 3, height: 2, graph: "   \n   "},
+		{name: "padded", samples: []float64{1, 2}, max: 2, width: 3, height: 2, graph: "  [white]█\n [white]██"},
+		{name: "last samples", samples: []float64{2, 0, 2}, max: 2, width: 2, height: 1, graph: " [white]█"},
+		{name: "over max", samples: []float64{4}, max: 2, width: 1, height: 2, graph: "[white]█\n[white]█"},
+		{name: "colors", samples: []float64{1, 2}, max: 2, width: 2, height: 1, colors: ColorKeys{0: "green", 90: "red"}, graph: "[green]█[red]█"},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		graph := HistoryGraph(tc.samples, tc.max, tc.width, tc.height, tc.colors)
+		if graph != tc.graph {
+			t.Errorf("expecting graph %q, got %q", tc.graph, graph)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		name    string
+		samples []float64
+		max     float64
+		width   int
+		colors  ColorKeys
+		graph   string
+	}{
+		{name: "no size", samples: []float64{1}, max: 1, width: 0, graph: ""},
+		{name: "no samples", max: 1, width: 3, graph: "   "},
+		{name: "padded", samples: []float64{0, 2}, max: 2, width: 3, graph: " [white]▁█"},
+		{name: "last samples", samples: []float64{2, 1, 2}, max: 2, width: 2, graph: "[white]▅█"},
+		{name: "over max", samples: []float64{4}, max: 2, width: 1, graph: "[white]█"},
+		{name: "colors", samples: []float64{1, 2}, max: 2, width: 2, colors: ColorKeys{0: "green", 90: "red"}, graph: "[green]▅[red]█"},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		graph := Sparkline(tc.samples, tc.max, tc.width, tc.colors)
+		if graph != tc.graph {
+			t.Errorf("expecting graph %q, got %q", tc.graph, graph)
+		}
+	}
+}
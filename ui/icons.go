@@ -5,6 +5,7 @@ var (
 		BargraphChar    rune
 		BargraphRBorder rune
 		BargraphLBorder rune
+		BargraphPeak    rune
 		Factory         rune
 		Battery         rune
 		Package         rune
@@ -19,10 +20,20 @@ var (
 		Controller      rune
 		Clock rune
 		TrafficLight rune
+		Check        rune
+		Pin          rune
+		GraphBlock   rune
+		Warning      rune
+		ArrowUp      rune
+		ArrowDown    rune
+		ArrowRight   rune
+		TreeBranch   rune
+		Ellipsis     rune
 	}{
 		BargraphChar:    '|',
 		BargraphLBorder: '[',
 		BargraphRBorder: ']',
+		BargraphPeak:    '┊',
 		Factory:         '🏭',
 		Battery:         '🔋',
 		Package:         '📦',
@@ -37,5 +48,14 @@ var (
 		Controller:      '🛂',
 		Clock: '⏰',
 		TrafficLight: '🚦',
+		Check:        '✔',
+		Pin:          '📌',
+		GraphBlock:   '█',
+		Warning:      '⚠',
+		ArrowUp:      '↑',
+		ArrowDown:    '↓',
+		ArrowRight:   '→',
+		TreeBranch:   '└',
+		Ellipsis:     '…',
 	}
 )
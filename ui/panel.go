@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
@@ -22,3 +23,22 @@ type PanelController interface {
 	Panel
 	Run(context.Context) error
 }
+
+// InputHandler is implemented by panels that handle key events dispatched
+// by the application (i.e. page key bindings). HandleInput returns nil when
+// the event is consumed, otherwise the event is passed on to the focused view.
+type InputHandler interface {
+	HandleInput(event *tcell.EventKey) *tcell.EventKey
+}
+
+// KeyHint is a key binding shown in the hint line, i.e. {Key: "d", Name: "describe"}
+type KeyHint struct {
+	Key  string
+	Name string
+}
+
+// KeyHinter is implemented by panels with key bindings depending on the focused view.
+// KeyHints returns the key bindings of the focused view shown in the hint line.
+type KeyHinter interface {
+	KeyHints() []KeyHint
+}
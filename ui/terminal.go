@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ColorMode is the color capability of the terminal
+type ColorMode int
+
+const (
+	// ColorModeNone is a monochrome terminal, or colors disabled with NO_COLOR
+	ColorModeNone ColorMode = iota
+	// ColorMode16 is a terminal with the 16 basic ANSI colors (i.e. the Linux console)
+	ColorMode16
+	// ColorMode256 is a terminal with the 256 colors palette (i.e. xterm-256color)
+	ColorMode256
+	// ColorModeTrue is a terminal with 24-bit colors
+	ColorModeTrue
+)
+
+// colorModeNames are the names of the color modes, used by ParseColorMode
+var colorModeNames = map[string]ColorMode{
+	"none":      ColorModeNone,
+	"16":        ColorMode16,
+	"256":       ColorMode256,
+	"truecolor": ColorModeTrue,
+}
+
+// ParseColorMode returns the color mode named name: none, 16, 256, or truecolor; auto,
+// or an empty name, detects the color mode of the terminal (see DetectColorMode).
+func ParseColorMode(name string) (ColorMode, error) {
+	if name == "" || name == "auto" {
+		return DetectColorMode(), nil
+	}
+	mode, ok := colorModeNames[name]
+	if !ok {
+		return ColorModeNone, fmt.Errorf("unsupported color mode %q (auto, truecolor, 256, 16, or none)", name)
+	}
+	return mode, nil
+}
+
+// DetectColorMode returns the color mode of the terminal, from the environment: NO_COLOR, the
+// dumb and monochrome (i.e. vt100, xterm-mono) terminals have no colors, COLORTERM=truecolor
+// (or 24bit) and the direct color terminals have 24-bit colors, the 256color terminals have
+// 256 colors, and the other terminals have the 16 basic colors.
+func DetectColorMode() ColorMode {
+	return detectColorMode(os.Getenv)
+}
+
+func detectColorMode(getenv func(string) string) ColorMode {
+	term := strings.ToLower(getenv("TERM"))
+	if getenv("NO_COLOR") != "" || term == "dumb" || term == "vt100" || term == "vt220" || strings.HasSuffix(term, "-mono") {
+		return ColorModeNone
+	}
+	switch colorTerm := strings.ToLower(getenv("COLORTERM")); {
+	case colorTerm == "truecolor" || colorTerm == "24bit" || strings.HasSuffix(term, "-direct"):
+		return ColorModeTrue
+	case strings.Contains(term, "256color"):
+		return ColorMode256
+	}
+	return ColorMode16
+}
+
+// UseColorMode adapts the drawing to the color mode of the terminal: with the basic colors,
+// the block and braille bar graphs, often missing from the console fonts, are drawn with bars,
+// and without colors, the bar graphs are drawn with ASCII hashes, visible without the usage
+// colors. It must be called after SetGraphStyle, before the views are laid out.
+func UseColorMode(mode ColorMode) {
+	switch mode {
+	case ColorModeNone:
+		currentGraphStyle = graphStyles[GraphStyleASCII]
+	case ColorMode16:
+		if currentGraphStyle.partial != nil {
+			currentGraphStyle = graphStyles[GraphStyleBars]
+		}
+	}
+}
+
+// MonochromeScreen returns screen drawing without colors: the attributes (i.e. bold) are kept,
+// and the cells with a background color, like the selected rows, are drawn in reverse video.
+// It is used for ColorModeNone, where tcell would otherwise draw the colors of the views.
+func MonochromeScreen(screen tcell.Screen) tcell.Screen {
+	return monochromeScreen{Screen: screen}
+}
+
+type monochromeScreen struct {
+	tcell.Screen
+}
+
+func (s monochromeScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	s.Screen.SetContent(x, y, mainc, combc, monochromeStyle(style))
+}
+
+// monochromeStyle returns style without colors, in reverse video with a background color
+func monochromeStyle(style tcell.Style) tcell.Style {
+	_, bg, attrs := style.Decompose()
+	if bg != tcell.ColorDefault && bg != tcell.ColorBlack {
+		attrs ^= tcell.AttrReverse
+	}
+	return tcell.StyleDefault.Attributes(attrs)
+}
+
+// PaletteScreen returns screen drawing the colors with the nearest colors of the palette of
+// mode: the 256 colors palette for ColorMode256, and the 16 basic colors for ColorMode16.
+// It is used for these modes, where the 24-bit and 256-palette colors of the views would
+// otherwise be drawn as is, or approximated by the terminal.
+func PaletteScreen(screen tcell.Screen, mode ColorMode) tcell.Screen {
+	size := 256
+	if mode == ColorMode16 {
+		size = 16
+	}
+	palette := make([]tcell.Color, size)
+	for i := range palette {
+		palette[i] = tcell.PaletteColor(i)
+	}
+	return &paletteScreen{Screen: screen, palette: palette, colors: make(map[tcell.Color]tcell.Color)}
+}
+
+type paletteScreen struct {
+	tcell.Screen
+	palette []tcell.Color
+
+	// colors caches the palette colors of the drawn colors, searched with tcell.FindColor
+	mu     sync.Mutex
+	colors map[tcell.Color]tcell.Color
+}
+
+func (s *paletteScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	fg, bg, attrs := style.Decompose()
+	style = tcell.StyleDefault.Foreground(s.paletteColor(fg)).Background(s.paletteColor(bg)).Attributes(attrs)
+	s.Screen.SetContent(x, y, mainc, combc, style)
+}
+
+// paletteColor returns the nearest color of the palette of color; the default color and the
+// colors of the palette are returned as is
+func (s *paletteScreen) paletteColor(color tcell.Color) tcell.Color {
+	if !color.Valid() || (!color.IsRGB() && int(color-tcell.ColorValid) < len(s.palette)) {
+		return color
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	match, ok := s.colors[color]
+	if !ok {
+		match = tcell.FindColor(color.TrueColor(), s.palette)
+		s.colors[color] = match
+	}
+	return match
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDetectColorMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected ColorMode
+	}{
+		{name: "truecolor", env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, expected: ColorModeTrue},
+		{name: "24bit", env: map[string]string{"TERM": "xterm", "COLORTERM": "24bit"}, expected: ColorModeTrue},
+		{name: "direct", env: map[string]string{"TERM": "xterm-direct"}, expected: ColorModeTrue},
+		{name: "256 colors", env: map[string]string{"TERM": "screen-256color"}, expected: ColorMode256},
+		{name: "linux console", env: map[string]string{"TERM": "linux"}, expected: ColorMode16},
+		{name: "no TERM", env: map[string]string{}, expected: ColorMode16},
+		{name: "dumb", env: map[string]string{"TERM": "dumb"}, expected: ColorModeNone},
+		{name: "mono", env: map[string]string{"TERM": "xterm-mono"}, expected: ColorModeNone},
+		{name: "NO_COLOR", env: map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor", "NO_COLOR": "1"}, expected: ColorModeNone},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		getenv := func(key string) string { return tc.env[key] }
+		if actual := detectColorMode(getenv); actual != tc.expected {
+			t.Errorf("expecting color mode %d, got %d", tc.expected, actual)
+		}
+	}
+}
+
+func TestMonochromeStyle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		style    tcell.Style
+		expected tcell.AttrMask
+	}{
+		{name: "foreground", style: tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true), expected: tcell.AttrBold},
+		{name: "background", style: tcell.StyleDefault.Background(tcell.ColorDarkGreen), expected: tcell.AttrReverse},
+		{name: "black background", style: tcell.StyleDefault.Background(tcell.ColorBlack), expected: tcell.AttrNone},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		fg, bg, attrs := monochromeStyle(tc.style).Decompose()
+		if fg != tcell.ColorDefault || bg != tcell.ColorDefault || attrs != tc.expected {
+			t.Errorf("expecting attributes %d without colors, got %v %v %d", tc.expected, fg, bg, attrs)
+		}
+	}
+}
+
+func TestPaletteColor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mode     ColorMode
+		color    tcell.Color
+		expected tcell.Color
+	}{
+		{name: "default", mode: ColorMode16, color: tcell.ColorDefault, expected: tcell.ColorDefault},
+		{name: "basic color", mode: ColorMode16, color: tcell.ColorRed, expected: tcell.ColorRed},
+		{name: "named in 16 colors", mode: ColorMode16, color: tcell.ColorDarkGreen, expected: tcell.ColorGreen},
+		{name: "24-bit in 16 colors", mode: ColorMode16, color: tcell.NewRGBColor(250, 250, 5), expected: tcell.ColorYellow},
+		{name: "256 palette", mode: ColorMode256, color: tcell.PaletteColor(208), expected: tcell.PaletteColor(208)},
+		{name: "named in 256 colors", mode: ColorMode256, color: tcell.ColorDarkGreen, expected: tcell.PaletteColor(22)},
+		{name: "24-bit in 256 colors", mode: ColorMode256, color: tcell.NewRGBColor(0, 0, 0x87), expected: tcell.PaletteColor(18)},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		screen := PaletteScreen(nil, tc.mode).(*paletteScreen)
+		if actual := screen.paletteColor(tc.color); actual != tc.expected {
+			t.Errorf("expecting color %v, got %v", tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	colorYAMLKey     = "aqua"
+	colorYAMLValue   = "white"
+	colorYAMLComment = "gray"
+)
+
+var yamlKeyValue = regexp.MustCompile(`^(\s*)(- )?([^:#\s][^:#]*):(\s.*|)$`)
+
+// HighlightYAML returns text with tview color tags added to YAML keys,
+// values, and comments. Other tview tags in text are escaped.
+func HighlightYAML(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			lines[i] = "[" + colorYAMLComment + "]" + tview.Escape(line)
+		case yamlKeyValue.MatchString(line):
+			parts := yamlKeyValue.FindStringSubmatch(line)
+			lines[i] = parts[1] + parts[2] +
+				"[" + colorYAMLKey + "]" + tview.Escape(parts[3]) +
+				"[" + colorYAMLValue + "]:" + tview.Escape(parts[4])
+		default:
+			lines[i] = "[" + colorYAMLValue + "]" + tview.Escape(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,94 @@
+package columns
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	pendingValue = "..."
+	errorValue   = "<err>"
+	maxInflight  = 4
+)
+
+type cacheEntry struct {
+	value    string
+	fetched  time.Time
+	inflight bool
+	// requested since the last prune
+	requested bool
+}
+
+// valueCache caches column values that are fetched in the background, so
+// that providers never block the UI while a request is in flight.
+type valueCache struct {
+	sync.Mutex
+	defaultVal string
+	ttl        time.Duration
+	entries    map[string]*cacheEntry
+	inflight   chan struct{}
+}
+
+func newValueCache(defaultVal string, ttl time.Duration) *valueCache {
+	return &valueCache{
+		defaultVal: defaultVal,
+		ttl:        ttl,
+		entries:    make(map[string]*cacheEntry),
+		inflight:   make(chan struct{}, maxInflight),
+	}
+}
+
+// get returns the cached value of key and, when the value is missing or older
+// than the cache TTL, starts fetching a new value in the background.
+func (c *valueCache) get(key string, fetch func() (string, error)) string {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		entry = &cacheEntry{value: pendingValue}
+		c.entries[key] = entry
+	}
+	entry.requested = true
+	if !entry.inflight && time.Since(entry.fetched) > c.ttl {
+		select {
+		case c.inflight <- struct{}{}:
+			entry.inflight = true
+			go c.fetch(entry, fetch)
+		default: // too many requests in flight, retry on next refresh
+		}
+	}
+	return entry.value
+}
+
+// prune removes the values of the keys not requested since the last prune, i.e. of the
+// objects deleted, or no longer listed, since the previous list was drawn
+func (c *valueCache) prune() {
+	c.Lock()
+	defer c.Unlock()
+	for key, entry := range c.entries {
+		if !entry.requested {
+			delete(c.entries, key)
+			continue
+		}
+		entry.requested = false
+	}
+}
+
+func (c *valueCache) fetch(entry *cacheEntry, fetch func() (string, error)) {
+	defer func() { <-c.inflight }()
+
+	value, err := fetch()
+	if err != nil {
+		value = errorValue
+	}
+	if value == "" {
+		value = c.defaultVal
+	}
+
+	c.Lock()
+	entry.value = value
+	entry.fetched = time.Now()
+	entry.inflight = false
+	c.Unlock()
+}
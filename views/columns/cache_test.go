@@ -0,0 +1,70 @@
+package columns
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestValueCachePrune(t *testing.T) {
+	cache := newValueCache("-", time.Minute)
+	fetch := func() (string, error) { return "value", nil }
+	keys := func() string {
+		cache.Lock()
+		defer cache.Unlock()
+		var keys []string
+		for key := range cache.entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	}
+
+	testCases := []struct {
+		name      string
+		requested []string
+		expected  string
+	}{
+		{name: "listed objects", requested: []string{"default/web-0", "default/web-1"}, expected: "default/web-0,default/web-1"},
+		{name: "deleted object", requested: []string{"default/web-0", "default/web-2"}, expected: "default/web-0,default/web-2"},
+		{name: "no objects", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		for _, key := range tc.requested {
+			cache.get(key, fetch)
+		}
+		cache.prune()
+		if actual := keys(); actual != tc.expected {
+			t.Errorf("expecting cached keys [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
+
+func TestRegistryPrunePods(t *testing.T) {
+	registry, err := FromConfig([]config.ColumnConfig{
+		{Name: "OWNER", Target: config.ColumnTargetPod, Source: config.ColumnSourceHTTP, URL: "http://127.0.0.1:0/{{.Name}}", Selector: "app=web"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := registry.pods["OWNER"].(*selectorProvider).Provider.(*HTTPProvider).cache
+
+	web := map[string]string{"app": "web"}
+	registry.PodValue("OWNER", model.PodModel{Name: "web-0", Labels: web})
+	registry.PodValue("OWNER", model.PodModel{Name: "web-1", Labels: web})
+	registry.PrunePods()
+	registry.PodValue("OWNER", model.PodModel{Name: "web-0", Labels: web})
+	registry.PrunePods()
+
+	cache.Lock()
+	defer cache.Unlock()
+	if _, found := cache.entries["http://127.0.0.1:0/web-0"]; !found || len(cache.entries) != 1 {
+		t.Errorf("expecting the value of web-0 cached only, got %d values", len(cache.entries))
+	}
+}
@@ -0,0 +1,79 @@
+package columns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const httpMaxBodySize = 4096
+
+// HTTPProvider retrieves column values from an HTTP endpoint.
+// Values are fetched in the background and cached for the provider TTL,
+// so Value never blocks the UI while a request is in flight.
+type HTTPProvider struct {
+	name   string
+	url    *template.Template
+	client *http.Client
+	cache  *valueCache
+}
+
+// NewHTTPProvider returns a provider that renders urlTmpl with the column Object
+// and uses the first line of the response body as the column value.
+func NewHTTPProvider(name, urlTmpl, defaultVal string, timeout, ttl time.Duration) (*HTTPProvider, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(urlTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("url template: %w", err)
+	}
+	return &HTTPProvider{
+		name:   name,
+		url:    tmpl,
+		client: &http.Client{Timeout: timeout},
+		cache:  newValueCache(defaultVal, ttl),
+	}, nil
+}
+
+func (p *HTTPProvider) Name() string {
+	return p.name
+}
+
+func (p *HTTPProvider) Value(obj Object) string {
+	var url strings.Builder
+	if err := p.url.Execute(&url, obj); err != nil {
+		return errorValue
+	}
+	key := url.String()
+	return p.cache.get(key, func() (string, error) {
+		return p.get(key)
+	})
+}
+
+// Prune drops the values of the objects not requested since the last call
+func (p *HTTPProvider) Prune() {
+	p.cache.prune()
+}
+
+func (p *HTTPProvider) get(url string) (string, error) {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, httpMaxBodySize))
+	if scanner.Scan() {
+		return strings.TrimSpace(scanner.Text()), nil
+	}
+	return "", scanner.Err()
+}
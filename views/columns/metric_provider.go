@@ -0,0 +1,99 @@
+package columns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// MetricsClient retrieves metric values from the custom and external metrics APIs
+type MetricsClient interface {
+	GetCustomMetric(ctx context.Context, resourceName, namespace, name, metric, metricSelector string) (*resource.Quantity, error)
+	GetExternalMetric(ctx context.Context, namespace, metric, metricSelector string) (*resource.Quantity, error)
+}
+
+// MetricProvider retrieves column values from the custom metrics API, for the object
+// itself, or from the external metrics API, for the object namespace.
+// Like HTTPProvider, values are fetched in the background and cached for the provider TTL.
+type MetricProvider struct {
+	name     string
+	metric   string
+	external bool
+	selector *template.Template
+	timeout  time.Duration
+	client   MetricsClient
+	cache    *valueCache
+}
+
+// NewMetricProvider returns a provider that uses the value of metric as the column value.
+// The selectorTmpl is rendered with the column Object as the metric label selector.
+func NewMetricProvider(client MetricsClient, name, metric, selectorTmpl, defaultVal string, external bool, timeout, ttl time.Duration) (*MetricProvider, error) {
+	if client == nil {
+		return nil, fmt.Errorf("metrics client not available")
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(selectorTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("metric selector template: %w", err)
+	}
+	return &MetricProvider{
+		name:     name,
+		metric:   metric,
+		external: external,
+		selector: tmpl,
+		timeout:  timeout,
+		client:   client,
+		cache:    newValueCache(defaultVal, ttl),
+	}, nil
+}
+
+func (p *MetricProvider) Name() string {
+	return p.name
+}
+
+func (p *MetricProvider) Value(obj Object) string {
+	var selector strings.Builder
+	if err := p.selector.Execute(&selector, obj); err != nil {
+		return errorValue
+	}
+
+	// external metrics do not belong to an object: objects with the same selector share the value
+	key := obj.Namespace + "|" + selector.String()
+	if !p.external {
+		key = obj.Kind + "|" + obj.Namespace + "|" + obj.Name + "|" + selector.String()
+	}
+	return p.cache.get(key, func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		defer cancel()
+
+		var value *resource.Quantity
+		var err error
+		if p.external {
+			value, err = p.client.GetExternalMetric(ctx, obj.Namespace, p.metric, selector.String())
+		} else {
+			value, err = p.client.GetCustomMetric(ctx, strings.ToLower(obj.Kind)+"s", obj.Namespace, obj.Name, p.metric, selector.String())
+		}
+		if err != nil {
+			return "", err
+		}
+		return formatMetricValue(value), nil
+	})
+}
+
+// Prune drops the values of the objects not requested since the last call
+func (p *MetricProvider) Prune() {
+	p.cache.prune()
+}
+
+// formatMetricValue returns the metric value as a whole number when possible,
+// otherwise with two decimals (i.e. 1500m is displayed as 1.50)
+func formatMetricValue(value *resource.Quantity) string {
+	milli := value.MilliValue()
+	if milli%1000 == 0 {
+		return fmt.Sprintf("%d", milli/1000)
+	}
+	return fmt.Sprintf("%.2f", float64(milli)/1000)
+}
@@ -0,0 +1,252 @@
+package columns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Object carries the object metadata that providers compute column values from
+type Object struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Provider computes the value of a custom column for an object
+type Provider interface {
+	Name() string
+	Value(obj Object) string
+}
+
+// Pruner is implemented by the providers caching values by object: Prune drops the values of
+// the objects not requested since the last call, so that deleted objects do not stay cached
+type Pruner interface {
+	Prune()
+}
+
+// Registry keeps track of column providers registered for pods and nodes
+type Registry struct {
+	pods  map[string]Provider
+	nodes map[string]Provider
+
+	podCols  []string
+	nodeCols []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		pods:  make(map[string]Provider),
+		nodes: make(map[string]Provider),
+	}
+}
+
+// FromConfig builds a registry with the providers declared in the column configurations.
+// The metrics client is used by the metric sources and can be nil when none is declared.
+func FromConfig(cfgs []config.ColumnConfig, metrics MetricsClient) (*Registry, error) {
+	reg := NewRegistry()
+	for _, cfg := range cfgs {
+		prov, err := newProvider(cfg, metrics)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", cfg.Name, err)
+		}
+		if cfg.Selector != "" {
+			selector, err := labels.Parse(cfg.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: selector: %w", cfg.Name, err)
+			}
+			prov = &selectorProvider{Provider: prov, selector: selector, defaultVal: cfg.Default}
+		}
+		if err := reg.Register(cfg.Target, prov); err != nil {
+			return nil, err
+		}
+	}
+	return reg, nil
+}
+
+// Register adds provider p as a column of the specified target (pod or node)
+func (r *Registry) Register(target string, p Provider) error {
+	name := strings.ToUpper(p.Name())
+	switch target {
+	case config.ColumnTargetPod:
+		if _, found := r.pods[name]; found {
+			return fmt.Errorf("pod column %s already registered", name)
+		}
+		r.pods[name] = p
+		r.podCols = append(r.podCols, name)
+	case config.ColumnTargetNode:
+		if _, found := r.nodes[name]; found {
+			return fmt.Errorf("node column %s already registered", name)
+		}
+		r.nodes[name] = p
+		r.nodeCols = append(r.nodeCols, name)
+	default:
+		return fmt.Errorf("unsupported column target %q", target)
+	}
+	return nil
+}
+
+// PodColumns returns the names of the registered pod columns
+func (r *Registry) PodColumns() []string {
+	if r == nil {
+		return nil
+	}
+	return r.podCols
+}
+
+// NodeColumns returns the names of the registered node columns
+func (r *Registry) NodeColumns() []string {
+	if r == nil {
+		return nil
+	}
+	return r.nodeCols
+}
+
+// PodValue returns the value of custom column col for the pod
+func (r *Registry) PodValue(col string, pod model.PodModel) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	prov, found := r.pods[col]
+	if !found {
+		return "", false
+	}
+	return prov.Value(Object{
+		Kind:        "Pod",
+		Namespace:   pod.Namespace,
+		Name:        pod.Name,
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}), true
+}
+
+// NodeValue returns the value of custom column col for the node
+func (r *Registry) NodeValue(col string, node model.NodeModel) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	prov, found := r.nodes[col]
+	if !found {
+		return "", false
+	}
+	return prov.Value(Object{
+		Kind:        "Node",
+		Name:        node.Name,
+		Labels:      node.Labels,
+		Annotations: node.Annotations,
+	}), true
+}
+
+// PrunePods drops the values cached by the pod column providers for the pods not drawn since
+// the last call; it is called once the pods are drawn
+func (r *Registry) PrunePods() {
+	if r == nil {
+		return
+	}
+	prune(r.pods)
+}
+
+// PruneNodes drops the values cached by the node column providers for the nodes not drawn
+// since the last call; it is called once the nodes are drawn
+func (r *Registry) PruneNodes() {
+	if r == nil {
+		return
+	}
+	prune(r.nodes)
+}
+
+func prune(providers map[string]Provider) {
+	for _, p := range providers {
+		if pruner, ok := p.(Pruner); ok {
+			pruner.Prune()
+		}
+	}
+}
+
+func newProvider(cfg config.ColumnConfig, metrics MetricsClient) (Provider, error) {
+	switch cfg.Source {
+	case config.ColumnSourceLabel:
+		return &metadataProvider{name: cfg.Name, key: cfg.Key, defaultVal: cfg.Default, labels: true}, nil
+	case config.ColumnSourceAnnotation:
+		return &metadataProvider{name: cfg.Name, key: cfg.Key, defaultVal: cfg.Default}, nil
+	case config.ColumnSourceHTTP:
+		timeout, err := parseDuration(cfg.Timeout, 2*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+		ttl, err := parseDuration(cfg.TTL, time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("ttl: %w", err)
+		}
+		return NewHTTPProvider(cfg.Name, cfg.URL, cfg.Default, timeout, ttl)
+	case config.ColumnSourceCustomMetric, config.ColumnSourceExternalMetric:
+		timeout, err := parseDuration(cfg.Timeout, 2*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+		ttl, err := parseDuration(cfg.TTL, time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("ttl: %w", err)
+		}
+		external := cfg.Source == config.ColumnSourceExternalMetric
+		return NewMetricProvider(metrics, cfg.Name, cfg.Metric, cfg.MetricSelector, cfg.Default, external, timeout, ttl)
+	default:
+		return nil, fmt.Errorf("unsupported source %q", cfg.Source)
+	}
+}
+
+func parseDuration(val string, defaultVal time.Duration) (time.Duration, error) {
+	if val == "" {
+		return defaultVal, nil
+	}
+	return time.ParseDuration(val)
+}
+
+// metadataProvider returns the value of a label or an annotation
+type metadataProvider struct {
+	name       string
+	key        string
+	defaultVal string
+	labels     bool
+}
+
+func (p *metadataProvider) Name() string {
+	return p.name
+}
+
+func (p *metadataProvider) Value(obj Object) string {
+	values := obj.Annotations
+	if p.labels {
+		values = obj.Labels
+	}
+	if val, ok := values[p.key]; ok {
+		return val
+	}
+	return p.defaultVal
+}
+
+// selectorProvider restricts a provider to the objects with labels matching a selector
+type selectorProvider struct {
+	Provider
+	selector   labels.Selector
+	defaultVal string
+}
+
+func (p *selectorProvider) Value(obj Object) string {
+	if !p.selector.Matches(labels.Set(obj.Labels)) {
+		return p.defaultVal
+	}
+	return p.Provider.Value(obj)
+}
+
+func (p *selectorProvider) Prune() {
+	if pruner, ok := p.Provider.(Pruner); ok {
+		pruner.Prune()
+	}
+}
@@ -0,0 +1,124 @@
+package columns
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRegistryPodValue(t *testing.T) {
+	registry, err := FromConfig([]config.ColumnConfig{
+		{Name: "team", Target: config.ColumnTargetPod, Source: config.ColumnSourceLabel, Key: "team", Default: "-"},
+		{Name: "OWNER", Target: config.ColumnTargetPod, Source: config.ColumnSourceAnnotation, Key: "example.com/owner"},
+		{Name: "ZONE", Target: config.ColumnTargetNode, Source: config.ColumnSourceLabel, Key: "zone"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := model.PodModel{
+		Name:        "api-0",
+		Labels:      map[string]string{"team": "payments"},
+		Annotations: map[string]string{"example.com/owner": "alice"},
+	}
+
+	testCases := []struct {
+		name     string
+		column   string
+		pod      model.PodModel
+		found    bool
+		expected string
+	}{
+		{name: "label", column: "TEAM", pod: pod, found: true, expected: "payments"},
+		{name: "label default", column: "TEAM", pod: model.PodModel{Name: "db-0"}, found: true, expected: "-"},
+		{name: "annotation", column: "OWNER", pod: pod, found: true, expected: "alice"},
+		{name: "node column", column: "ZONE", pod: pod, found: false},
+		{name: "unknown column", column: "NOPE", pod: pod, found: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		actual, found := registry.PodValue(tc.column, tc.pod)
+		if found != tc.found {
+			t.Errorf("expecting found %t, got %t", tc.found, found)
+		}
+		if actual != tc.expected {
+			t.Errorf("expecting value [%s], got [%s]", tc.expected, actual)
+		}
+	}
+
+	if cols := registry.PodColumns(); len(cols) != 2 || cols[0] != "TEAM" || cols[1] != "OWNER" {
+		t.Errorf("unexpected pod columns %v", cols)
+	}
+	if cols := registry.NodeColumns(); len(cols) != 1 || cols[0] != "ZONE" {
+		t.Errorf("unexpected node columns %v", cols)
+	}
+}
+
+func TestRegistryDuplicate(t *testing.T) {
+	_, err := FromConfig([]config.ColumnConfig{
+		{Name: "TEAM", Target: config.ColumnTargetPod, Source: config.ColumnSourceLabel, Key: "team"},
+		{Name: "team", Target: config.ColumnTargetPod, Source: config.ColumnSourceAnnotation, Key: "team"},
+	}, nil)
+	if err == nil {
+		t.Error("expecting error for duplicate column")
+	}
+}
+
+func TestRegistrySelector(t *testing.T) {
+	registry, err := FromConfig([]config.ColumnConfig{
+		{Name: "TIER", Target: config.ColumnTargetPod, Source: config.ColumnSourceLabel, Key: "tier", Selector: "app=payments", Default: "-"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{name: "matching", labels: map[string]string{"app": "payments", "tier": "api"}, expected: "api"},
+		{name: "not matching", labels: map[string]string{"app": "orders", "tier": "api"}, expected: "-"},
+		{name: "no labels", expected: "-"},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		actual, _ := registry.PodValue("TIER", model.PodModel{Name: "api-0", Labels: tc.labels})
+		if actual != tc.expected {
+			t.Errorf("expecting value [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
+
+func TestRegistryMetricWithoutClient(t *testing.T) {
+	_, err := FromConfig([]config.ColumnConfig{
+		{Name: "RPS", Target: config.ColumnTargetPod, Source: config.ColumnSourceCustomMetric, Metric: "http_requests_per_second"},
+	}, nil)
+	if err == nil {
+		t.Error("expecting error for metric column without metrics client")
+	}
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "whole", value: "42", expected: "42"},
+		{name: "milli", value: "1500m", expected: "1.50"},
+		{name: "kilo", value: "2k", expected: "2000"},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("running test %s", tc.name)
+		value := resource.MustParse(tc.value)
+		if actual := formatMetricValue(&value); actual != tc.expected {
+			t.Errorf("expecting value [%s], got [%s]", tc.expected, actual)
+		}
+	}
+}
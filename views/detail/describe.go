@@ -0,0 +1,457 @@
+package detail
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const describeFieldWidth = 24
+
+// describer builds kubectl-describe like text, with tview color tags
+type describer struct {
+	buf strings.Builder
+}
+
+func (d *describer) section(name string) {
+	fmt.Fprintf(&d.buf, "[yellow]%s:[white]\n", tview.Escape(name))
+}
+
+func (d *describer) subsection(level int, name string) {
+	fmt.Fprintf(&d.buf, "%s[green]%s:[white]\n", strings.Repeat("  ", level), tview.Escape(name))
+}
+
+func (d *describer) field(level int, name string, format string, args ...interface{}) {
+	indent := strings.Repeat("  ", level)
+	label := indent
+	if name != "" {
+		label = fmt.Sprintf("%s%s:", indent, name)
+	}
+	fmt.Fprintf(&d.buf, "[aqua]%-*s[white] %s\n", describeFieldWidth, tview.Escape(label), tview.Escape(fmt.Sprintf(format, args...)))
+}
+
+func (d *describer) line(level int, format string, args ...interface{}) {
+	fmt.Fprintf(&d.buf, "%s%s\n", strings.Repeat("  ", level), tview.Escape(fmt.Sprintf(format, args...)))
+}
+
+func (d *describer) mapField(level int, name string, values map[string]string) {
+	if len(values) == 0 {
+		d.field(level, name, "<none>")
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		if i == 0 {
+			d.field(level, name, "%s=%s", k, values[k])
+			continue
+		}
+		d.field(level, "", "%s=%s", k, values[k])
+	}
+}
+
+func (d *describer) listField(level int, name string, values []string) {
+	if len(values) == 0 {
+		d.field(level, name, "<none>")
+		return
+	}
+	for i, v := range values {
+		if i == 0 {
+			d.field(level, name, "%s", v)
+			continue
+		}
+		d.field(level, "", "%s", v)
+	}
+}
+
+func (d *describer) events(events []*coreV1.Event) {
+	if len(events) == 0 {
+		d.field(0, "Events", "<none>")
+		return
+	}
+	d.section("Events")
+	d.line(1, "%-8s %-22s %-8s %-24s %s", "Type", "Reason", "Age", "From", "Message")
+	for _, event := range events {
+		age := duration.HumanDuration(time.Since(k8s.EventTime(event)))
+		if event.Count > 1 {
+			age = fmt.Sprintf("%s (x%d)", age, event.Count)
+		}
+		from := event.Source.Component
+		if from == "" {
+			from = event.ReportingController
+		}
+		d.line(1, "%-8s %-22s %-8s %-24s %s", event.Type, event.Reason, age, from, strings.TrimSpace(event.Message))
+	}
+}
+
+func (d *describer) String() string {
+	return d.buf.String()
+}
+
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC1123Z), duration.HumanDuration(time.Since(t.Time)))
+}
+
+// DescribePod returns a kubectl-describe like text for pod, with tview color tags
+func DescribePod(pod *coreV1.Pod, events []*coreV1.Event) string {
+	d := new(describer)
+	d.field(0, "Name", "%s", pod.Name)
+	d.field(0, "Namespace", "%s", pod.Namespace)
+	if pod.Spec.Priority != nil {
+		d.field(0, "Priority", "%d", *pod.Spec.Priority)
+	}
+	d.field(0, "Service Account", "%s", pod.Spec.ServiceAccountName)
+	d.field(0, "Node", "%s", pod.Spec.NodeName)
+	d.field(0, "Scheduler", "%s", pod.Spec.SchedulerName)
+	if pod.Status.StartTime != nil {
+		d.field(0, "Start Time", "%s", formatTime(*pod.Status.StartTime))
+	}
+	d.mapField(0, "Labels", pod.Labels)
+	d.field(0, "Annotations", "%d", len(pod.Annotations))
+	if pod.DeletionTimestamp != nil {
+		d.field(0, "Status", "Terminating (since %s)", formatTime(*pod.DeletionTimestamp))
+	} else {
+		d.field(0, "Status", "%s", pod.Status.Phase)
+	}
+	if pod.Status.Reason != "" {
+		d.field(0, "Reason", "%s", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		d.field(0, "Message", "%s", pod.Status.Message)
+	}
+	if lastRestart := model.LastRestartTime(pod); !lastRestart.IsZero() {
+		d.field(0, "Last Restart", "%s", formatTime(lastRestart))
+	}
+	d.listField(0, "IPs", model.PodIPs(pod))
+	var owners []string
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			owners = append(owners, fmt.Sprintf("%s/%s", ref.Kind, ref.Name))
+		}
+	}
+	d.listField(0, "Controlled By", owners)
+	d.field(0, "QoS Class", "%s", pod.Status.QOSClass)
+	runtimeClass := "<default>"
+	if pod.Spec.RuntimeClassName != nil {
+		runtimeClass = *pod.Spec.RuntimeClassName
+	}
+	d.field(0, "Runtime Class", "%s", runtimeClass)
+	d.field(0, "Overhead", "%s", formatResources(pod.Spec.Overhead))
+	summary := model.GetPodContainerSummary(pod)
+	d.field(0, "Pod Requests", "cpu=%s, memory=%s (containers and overhead)", summary.RequestedCpuQty, summary.RequestedMemQty)
+	d.listField(0, "Security", model.PodSecurityFlags(pod))
+
+	statuses := make(map[string]coreV1.ContainerStatus)
+	for _, stat := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statuses[stat.Name] = stat
+	}
+	if len(pod.Spec.InitContainers) > 0 {
+		d.section("Init Containers")
+		for _, container := range pod.Spec.InitContainers {
+			describeContainer(d, container, statuses[container.Name])
+		}
+	}
+	d.section("Containers")
+	for _, container := range pod.Spec.Containers {
+		describeContainer(d, container, statuses[container.Name])
+	}
+
+	d.section("Conditions")
+	for _, cond := range pod.Status.Conditions {
+		d.field(1, string(cond.Type), "%s", cond.Status)
+	}
+
+	d.section("Volumes")
+	for _, vol := range pod.Spec.Volumes {
+		d.field(1, vol.Name, "%s", volumeSource(vol.VolumeSource))
+	}
+
+	d.section("Scheduling")
+	d.mapField(1, "Node-Selectors", pod.Spec.NodeSelector)
+	var tolerations []string
+	for _, toleration := range pod.Spec.Tolerations {
+		tolerations = append(tolerations, formatToleration(toleration))
+	}
+	d.listField(1, "Tolerations", tolerations)
+	describeAffinity(d, pod.Spec.Affinity)
+
+	d.events(events)
+	return d.String()
+}
+
+func describeContainer(d *describer, container coreV1.Container, status coreV1.ContainerStatus) {
+	d.subsection(1, container.Name)
+	d.field(2, "Image", "%s", container.Image)
+	var ports []string
+	for _, port := range container.Ports {
+		ports = append(ports, fmt.Sprintf("%d/%s", port.ContainerPort, port.Protocol))
+	}
+	d.field(2, "Ports", "%s", strings.Join(ports, ", "))
+	d.field(2, "State", "%s", containerState(status.State))
+	if status.LastTerminationState.Terminated != nil {
+		d.field(2, "Last State", "%s", containerState(status.LastTerminationState))
+	}
+	d.field(2, "Ready", "%t", status.Ready)
+	d.field(2, "Restart Count", "%d", status.RestartCount)
+	d.field(2, "Requests", "%s", formatResources(container.Resources.Requests))
+	d.field(2, "Limits", "%s", formatResources(container.Resources.Limits))
+	var mounts []string
+	for _, mount := range container.VolumeMounts {
+		ro := "rw"
+		if mount.ReadOnly {
+			ro = "ro"
+		}
+		mounts = append(mounts, fmt.Sprintf("%s from %s (%s)", mount.MountPath, mount.Name, ro))
+	}
+	d.listField(2, "Mounts", mounts)
+}
+
+func containerState(state coreV1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running, started %s", formatTime(state.Running.StartedAt))
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s) %s", state.Waiting.Reason, state.Waiting.Message)
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s), exit code %d, finished %s", state.Terminated.Reason, state.Terminated.ExitCode, formatTime(state.Terminated.FinishedAt))
+	default:
+		return "<unknown>"
+	}
+}
+
+func formatResources(list coreV1.ResourceList) string {
+	if len(list) == 0 {
+		return "<none>"
+	}
+	names := make([]string, 0, len(list))
+	for name := range list {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	var result []string
+	for _, name := range names {
+		qty := list[coreV1.ResourceName(name)]
+		result = append(result, fmt.Sprintf("%s=%s", name, qty.String()))
+	}
+	return strings.Join(result, ", ")
+}
+
+func volumeSource(src coreV1.VolumeSource) string {
+	switch {
+	case src.ConfigMap != nil:
+		return fmt.Sprintf("ConfigMap (%s)", src.ConfigMap.Name)
+	case src.Secret != nil:
+		return fmt.Sprintf("Secret (%s)", src.Secret.SecretName)
+	case src.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("PersistentVolumeClaim (%s)", src.PersistentVolumeClaim.ClaimName)
+	case src.EmptyDir != nil:
+		return fmt.Sprintf("EmptyDir (medium: %q)", src.EmptyDir.Medium)
+	case src.HostPath != nil:
+		return fmt.Sprintf("HostPath (%s)", src.HostPath.Path)
+	case src.Projected != nil:
+		return "Projected"
+	case src.DownwardAPI != nil:
+		return "DownwardAPI"
+	case src.CSI != nil:
+		return fmt.Sprintf("CSI (%s)", src.CSI.Driver)
+	case src.NFS != nil:
+		return fmt.Sprintf("NFS (%s:%s)", src.NFS.Server, src.NFS.Path)
+	case src.Ephemeral != nil:
+		return "Ephemeral"
+	default:
+		return "Other"
+	}
+}
+
+func formatToleration(t coreV1.Toleration) string {
+	var b strings.Builder
+	b.WriteString(t.Key)
+	if t.Operator == coreV1.TolerationOpEqual || (t.Operator == "" && t.Value != "") {
+		b.WriteString("=" + t.Value)
+	}
+	if t.Operator == coreV1.TolerationOpExists && t.Key == "" {
+		b.WriteString("op=Exists")
+	}
+	if t.Effect != "" {
+		b.WriteString(":" + string(t.Effect))
+	}
+	if t.TolerationSeconds != nil {
+		fmt.Fprintf(&b, " for %ds", *t.TolerationSeconds)
+	}
+	return b.String()
+}
+
+// describeAffinity adds the node affinity, pod affinity, and pod anti-affinity terms of affinity
+func describeAffinity(d *describer, affinity *coreV1.Affinity) {
+	if affinity == nil {
+		affinity = &coreV1.Affinity{}
+	}
+
+	var nodeTerms []string
+	if node := affinity.NodeAffinity; node != nil {
+		if required := node.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			for _, term := range required.NodeSelectorTerms {
+				nodeTerms = append(nodeTerms, "required: "+formatNodeSelectorTerm(term))
+			}
+		}
+		for _, term := range node.PreferredDuringSchedulingIgnoredDuringExecution {
+			nodeTerms = append(nodeTerms, fmt.Sprintf("preferred (weight %d): %s", term.Weight, formatNodeSelectorTerm(term.Preference)))
+		}
+	}
+	d.listField(1, "Node Affinity", nodeTerms)
+
+	var podTerms, antiTerms []string
+	if pod := affinity.PodAffinity; pod != nil {
+		podTerms = formatPodAffinityTerms(pod.RequiredDuringSchedulingIgnoredDuringExecution, pod.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if anti := affinity.PodAntiAffinity; anti != nil {
+		antiTerms = formatPodAffinityTerms(anti.RequiredDuringSchedulingIgnoredDuringExecution, anti.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	d.listField(1, "Pod Affinity", podTerms)
+	d.listField(1, "Pod Anti-Affinity", antiTerms)
+}
+
+// formatNodeSelectorTerm returns the requirements of term, i.e. "zone in (a,b), gpu exists"
+func formatNodeSelectorTerm(term coreV1.NodeSelectorTerm) string {
+	var requirements []string
+	for _, req := range append(append([]coreV1.NodeSelectorRequirement{}, term.MatchExpressions...), term.MatchFields...) {
+		switch req.Operator {
+		case coreV1.NodeSelectorOpExists, coreV1.NodeSelectorOpDoesNotExist:
+			requirements = append(requirements, fmt.Sprintf("%s %s", req.Key, strings.ToLower(string(req.Operator))))
+		default:
+			requirements = append(requirements, fmt.Sprintf("%s %s (%s)", req.Key, strings.ToLower(string(req.Operator)), strings.Join(req.Values, ",")))
+		}
+	}
+	return strings.Join(requirements, ", ")
+}
+
+// formatPodAffinityTerms returns the required, then preferred, pod affinity terms
+func formatPodAffinityTerms(required []coreV1.PodAffinityTerm, preferred []coreV1.WeightedPodAffinityTerm) []string {
+	var terms []string
+	for _, term := range required {
+		terms = append(terms, "required: "+formatPodAffinityTerm(term))
+	}
+	for _, term := range preferred {
+		terms = append(terms, fmt.Sprintf("preferred (weight %d): %s", term.Weight, formatPodAffinityTerm(term.PodAffinityTerm)))
+	}
+	return terms
+}
+
+// formatPodAffinityTerm returns the pod selector and topology of term, i.e. "app=web per kubernetes.io/hostname"
+func formatPodAffinityTerm(term coreV1.PodAffinityTerm) string {
+	text := fmt.Sprintf("%s per %s", metav1.FormatLabelSelector(term.LabelSelector), term.TopologyKey)
+	if len(term.Namespaces) > 0 {
+		text += fmt.Sprintf(" in %s", strings.Join(term.Namespaces, ","))
+	}
+	if term.NamespaceSelector != nil {
+		text += fmt.Sprintf(" in namespaces %s", metav1.FormatLabelSelector(term.NamespaceSelector))
+	}
+	return text
+}
+
+// DescribeNode returns a kubectl-describe like text for node, with tview color tags.
+// The swap section is omitted when swap is nil (kubelet not reachable).
+func DescribeNode(node *coreV1.Node, pods []*coreV1.Pod, events []*coreV1.Event, swap *model.NodeSwapModel) string {
+	d := new(describer)
+	d.field(0, "Name", "%s", node.Name)
+	d.listField(0, "Roles", model.GetNodeControlRoles(node))
+	d.mapField(0, "Labels", node.Labels)
+	d.field(0, "Annotations", "%d", len(node.Annotations))
+	d.field(0, "CreationTimestamp", "%s", formatTime(node.CreationTimestamp))
+	var taints []string
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, taint.ToString())
+	}
+	d.listField(0, "Taints", taints)
+	d.field(0, "Unschedulable", "%t", node.Spec.Unschedulable)
+
+	d.section("Conditions")
+	d.line(1, "%-20s %-8s %-20s %s", "Type", "Status", "Reason", "LastTransition")
+	for _, cond := range node.Status.Conditions {
+		d.line(1, "%-20s %-8s %-20s %s", cond.Type, cond.Status, cond.Reason, formatTime(cond.LastTransitionTime))
+	}
+
+	d.section("Addresses")
+	for _, addr := range node.Status.Addresses {
+		d.field(1, string(addr.Type), "%s", addr.Address)
+	}
+	d.section("Capacity")
+	d.line(1, "%s", formatResources(node.Status.Capacity))
+	d.section("Allocatable")
+	d.line(1, "%s", formatResources(node.Status.Allocatable))
+	if swap != nil {
+		describeSwap(d, swap)
+	}
+
+	info := node.Status.NodeInfo
+	d.section("System Info")
+	d.field(1, "OS Image", "%s", info.OSImage)
+	d.field(1, "Operating System", "%s", info.OperatingSystem)
+	d.field(1, "Architecture", "%s", info.Architecture)
+	d.field(1, "Kernel Version", "%s", info.KernelVersion)
+	d.field(1, "Container Runtime", "%s", info.ContainerRuntimeVersion)
+	d.field(1, "Kubelet Version", "%s", info.KubeletVersion)
+	d.field(0, "PodCIDR", "%s", node.Spec.PodCIDR)
+	d.field(0, "ProviderID", "%s", node.Spec.ProviderID)
+
+	d.section(fmt.Sprintf("Pods (%d)", len(pods)))
+	d.line(1, "%-24s %-48s %s", "Namespace", "Name", "Status")
+	for _, pod := range pods {
+		d.line(1, "%-24s %-48s %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+
+	d.events(events)
+	return d.String()
+}
+
+// describeSwap describes the kubelet swap settings, and the swap usage of the node and its pods
+func describeSwap(d *describer, swap *model.NodeSwapModel) {
+	d.section("Swap")
+	switch {
+	case !swap.ConfigKnown:
+		d.field(1, "Behavior", "<unknown>")
+	case swap.Behavior == "":
+		d.field(1, "Behavior", "<default>")
+		d.field(1, "FailSwapOn", "%t", swap.FailSwapOn)
+	default:
+		d.field(1, "Behavior", "%s", swap.Behavior)
+		d.field(1, "FailSwapOn", "%t", swap.FailSwapOn)
+	}
+	if swap.UsageQty == nil {
+		d.field(1, "Usage", "<not reported>")
+		return
+	}
+	d.field(1, "Usage", "%dMi/%dMi", swap.UsageQty.Value()>>20, swap.CapacityQty.Value()>>20)
+	if len(swap.Pods) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(swap.Pods))
+	for key := range swap.Pods {
+		keys = append(keys, key)
+	}
+	// pods using the most swap first
+	sort.Slice(keys, func(i, j int) bool {
+		if cmp := swap.Pods[keys[i]].Cmp(*swap.Pods[keys[j]]); cmp != 0 {
+			return cmp > 0
+		}
+		return keys[i] < keys[j]
+	})
+	d.subsection(1, "Pods using swap")
+	for _, key := range keys {
+		d.line(2, "%-72s %dMi", key, swap.Pods[key].Value()>>20)
+	}
+}
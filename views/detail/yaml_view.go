@@ -0,0 +1,138 @@
+package detail
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/ui"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// ShowYAML displays obj as YAML in a scrollable modal view.
+// From the view, key 'o' opens the manifest (read-only) in $EDITOR.
+func ShowYAML(app *application.Application, name string, obj runtime.Object) {
+	data, err := ToYAML(obj)
+	if err != nil {
+		app.ShowMessage(fmt.Sprintf("Failed to render %s: %s", name, err))
+		return
+	}
+
+	view := newTextView(fmt.Sprintf("%s (o: open in editor, Esc: close)", name), ui.HighlightYAML(string(data)))
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'o' {
+			if err := openInEditor(app, name, data); err != nil {
+				app.ShowMessage(fmt.Sprintf("Failed to open editor: %s", err))
+			}
+			return nil
+		}
+		return event
+	})
+
+	app.ShowNamedModal(name+" (yaml)", view, ui.KeyHint{Key: "o", Name: "open in editor"})
+}
+
+// Action is a key binding of a detail view; Do is called when Key is pressed
+type Action struct {
+	Key  rune
+	Name string
+	Do   func()
+}
+
+// ShowText displays text, which may contain tview color tags, in a scrollable modal view
+// with optional actions, listed in the view title, bound to keys.
+func ShowText(app *application.Application, title, text string, actions ...Action) {
+	var hints []string
+	var keyHints []ui.KeyHint
+	for _, action := range actions {
+		hints = append(hints, fmt.Sprintf("%c: %s", action.Key, action.Name))
+		keyHints = append(keyHints, ui.KeyHint{Key: string(action.Key), Name: action.Name})
+	}
+	hints = append(hints, "Esc: close")
+
+	view := newTextView(fmt.Sprintf("%s (%s)", title, strings.Join(hints, ", ")), text)
+	if len(actions) > 0 {
+		view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			for _, action := range actions {
+				if event.Key() == tcell.KeyRune && event.Rune() == action.Key {
+					action.Do()
+					return nil
+				}
+			}
+			return event
+		})
+	}
+	app.ShowNamedModal(title, view, keyHints...)
+}
+
+func newTextView(title, text string) *tview.TextView {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(false).
+		SetText(text)
+	view.SetBorder(true)
+	view.SetTitle(" " + title + " ")
+	view.SetTitleAlign(tview.AlignLeft)
+	return view
+}
+
+// ToYAML returns the YAML manifest of obj including its apiVersion and kind,
+// but without the managedFields metadata.
+func ToYAML(obj runtime.Object) ([]byte, error) {
+	obj = obj.DeepCopyObject()
+	if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return yaml.Marshal(obj)
+}
+
+// openInEditor writes data to a read-only temporary file and opens it with
+// $KUBE_EDITOR or $EDITOR (default vi) while the terminal UI is suspended.
+func openInEditor(app *application.Application, name string, data []byte) error {
+	file, err := os.CreateTemp("", "ktop-"+strings.ReplaceAll(name, "/", "_")+"-*.yaml")
+	if err != nil {
+		return err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0400); err != nil {
+		return err
+	}
+
+	editor := os.Getenv("KUBE_EDITOR")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), path)
+
+	var runErr error
+	app.Suspend(func() {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		runErr = cmd.Run()
+	})
+	return runErr
+}
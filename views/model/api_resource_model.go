@@ -0,0 +1,40 @@
+package model
+
+import (
+	"strings"
+)
+
+// APIResourceModel represents a resource served by the API server, as reported by discovery
+type APIResourceModel struct {
+	Group      string
+	Version    string
+	Name       string
+	Kind       string
+	Namespaced bool
+	Verbs      []string
+	ShortNames []string
+}
+
+// GroupVersion returns the group/version of the resource, only the version for the core group
+func (m APIResourceModel) GroupVersion() string {
+	if m.Group == "" {
+		return m.Version
+	}
+	return m.Group + "/" + m.Version
+}
+
+// Matches returns true if the group, version, name, kind, or a short name of the
+// resource contains filter (case insensitive). An empty filter matches all resources.
+func (m APIResourceModel) Matches(filter string) bool {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return true
+	}
+	fields := append([]string{m.GroupVersion(), m.Name, m.Kind}, m.ShortNames...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AutoscalerClusterAutoscaler designates the Kubernetes Cluster Autoscaler
+	AutoscalerClusterAutoscaler = "cluster-autoscaler"
+	// AutoscalerKarpenter designates Karpenter
+	AutoscalerKarpenter = "karpenter"
+
+	// ClusterAutoscalerUnneededTaint is the taint set by the Cluster Autoscaler on the nodes it considers unneeded
+	ClusterAutoscalerUnneededTaint = "DeletionCandidateOfClusterAutoscaler"
+	// KarpenterDisruptedTaint is the taint set by Karpenter on the nodes it disrupts
+	KarpenterDisruptedTaint = "karpenter.sh/disrupted"
+	// KarpenterNodePoolLabel is the label of the Karpenter node pool of a NodeClaim
+	KarpenterNodePoolLabel = "karpenter.sh/nodepool"
+)
+
+// Autoscaling activity kinds
+const (
+	ActivityScaleUp   = "scale-up"
+	ActivityScaleDown = "scale-down"
+	ActivityFailure   = "failure"
+)
+
+// activityReasons maps the reasons of the Cluster Autoscaler, and Karpenter, events to an activity kind
+var activityReasons = map[string]string{
+	"TriggeredScaleUp":          ActivityScaleUp,
+	"ScaledUpGroup":             ActivityScaleUp,
+	"Nominated":                 ActivityScaleUp,
+	"Launched":                  ActivityScaleUp,
+	"ScaleDown":                 ActivityScaleDown,
+	"ScaleDownEmpty":            ActivityScaleDown,
+	"DisruptionTerminating":     ActivityScaleDown,
+	"NotTriggerScaleUp":         ActivityFailure,
+	"FailedToScaleUpGroup":      ActivityFailure,
+	"ScaleUpTimedOut":           ActivityFailure,
+	"ScaleDownFailed":           ActivityFailure,
+	"InsufficientCapacityError": ActivityFailure,
+	"FailedLaunch":              ActivityFailure,
+	"DisruptionBlocked":         ActivityFailure,
+}
+
+// AutoscalingModel represents the node autoscaling activity of the cluster: the
+// autoscaler status, Karpenter NodeClaims, unneeded nodes, recent scale-ups and
+// failures, and the pending pods along with the autoscaler decision about them.
+type AutoscalingModel struct {
+	Autoscalers   []string
+	Status        []AutoscalerStatus
+	NodeClaims    []NodeClaimModel
+	UnneededNodes []string
+	Activities    []AutoscalingActivity // newest first
+	PendingPods   []PendingPodModel
+}
+
+// AutoscalerStatus is a cluster-wide status of the Cluster Autoscaler, i.e. ScaleUp: InProgress
+type AutoscalerStatus struct {
+	Name   string
+	Status string
+}
+
+// NodeClaimModel represents a Karpenter NodeClaim
+type NodeClaimModel struct {
+	Name         string
+	NodePool     string
+	InstanceType string
+	Node         string
+	Status       string // first condition not true (i.e. Launched), or Ready
+	Failed       bool   // a condition is false
+	Message      string
+	CreationTime time.Time
+}
+
+// AutoscalingActivity is an autoscaling event: a scale-up, scale-down, or failure
+type AutoscalingActivity struct {
+	Kind    string
+	Object  string // kind/name of the object of the event
+	Reason  string
+	Message string
+	Time    time.Time
+}
+
+// PendingPodModel is an unscheduled pod along with the last autoscaling activity about it
+type PendingPodModel struct {
+	Namespace    string
+	Name         string
+	CreationTime time.Time
+	Activity     *AutoscalingActivity
+}
+
+// NewAutoscalingActivity returns the activity of an autoscaling event that occurred at time;
+// it returns false for events not related to autoscaling.
+func NewAutoscalingActivity(event *coreV1.Event, at time.Time) (AutoscalingActivity, bool) {
+	kind, ok := activityReasons[event.Reason]
+	if !ok {
+		return AutoscalingActivity{}, false
+	}
+	return AutoscalingActivity{
+		Kind:    kind,
+		Object:  strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name,
+		Reason:  event.Reason,
+		Message: event.Message,
+		Time:    at,
+	}, true
+}
+
+// NewAutoscalingModel returns the autoscaling model of the nodes, pods, and autoscaling activities.
+// The activities about a pending pod are keyed by namespace/name in podActivities.
+func NewAutoscalingModel(autoscalers []string, status []AutoscalerStatus, nodeClaims []NodeClaimModel, nodes []*coreV1.Node, pods []*coreV1.Pod, activities []AutoscalingActivity, podActivities map[string]AutoscalingActivity) AutoscalingModel {
+	m := AutoscalingModel{Autoscalers: autoscalers, Status: status, NodeClaims: nodeClaims, Activities: activities}
+
+	for _, node := range nodes {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == ClusterAutoscalerUnneededTaint || taint.Key == KarpenterDisruptedTaint {
+				m.UnneededNodes = append(m.UnneededNodes, node.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(m.UnneededNodes)
+
+	for _, pod := range pods {
+		if pod.Status.Phase != coreV1.PodPending || pod.Spec.NodeName != "" {
+			continue
+		}
+		pending := PendingPodModel{Namespace: pod.Namespace, Name: pod.Name, CreationTime: pod.CreationTimestamp.Time}
+		if activity, ok := podActivities[pod.Namespace+"/"+pod.Name]; ok {
+			pending.Activity = &activity
+		}
+		m.PendingPods = append(m.PendingPods, pending)
+	}
+	sort.Slice(m.PendingPods, func(i, j int) bool {
+		return m.PendingPods[i].CreationTime.Before(m.PendingPods[j].CreationTime)
+	})
+
+	sort.Slice(m.Activities, func(i, j int) bool {
+		return m.Activities[i].Time.After(m.Activities[j].Time)
+	})
+	sort.Slice(m.NodeClaims, func(i, j int) bool {
+		return m.NodeClaims[i].Name < m.NodeClaims[j].Name
+	})
+	return m
+}
+
+// ParseClusterAutoscalerStatus returns the cluster-wide Health, ScaleUp, and ScaleDown status
+// of the Cluster Autoscaler status ConfigMap, written as text or, from 1.30, as YAML.
+func ParseClusterAutoscalerStatus(status string) []AutoscalerStatus {
+	var result []AutoscalerStatus
+	clusterWide := false
+	for _, line := range strings.Split(status, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "Cluster-wide:":
+			clusterWide = true
+			continue
+		case strings.HasSuffix(trimmed, ":") && !strings.HasPrefix(line, " "):
+			clusterWide = false
+		}
+		if !clusterWide {
+			continue
+		}
+		for _, name := range []string{"Health", "ScaleUp", "ScaleDown"} {
+			if strings.HasPrefix(trimmed, name+":") {
+				result = append(result, AutoscalerStatus{Name: name, Status: strings.TrimSpace(strings.TrimPrefix(trimmed, name+":"))})
+			}
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+
+	var yamlStatus struct {
+		ClusterWide struct {
+			Health struct {
+				Status string `json:"status"`
+			} `json:"health"`
+			ScaleUp struct {
+				Status string `json:"status"`
+			} `json:"scaleUp"`
+			ScaleDown struct {
+				Status     string `json:"status"`
+				Candidates int    `json:"candidates"`
+			} `json:"scaleDown"`
+		} `json:"clusterWide"`
+	}
+	if err := yaml.Unmarshal([]byte(status), &yamlStatus); err != nil || yamlStatus.ClusterWide.Health.Status == "" {
+		return nil
+	}
+	wide := yamlStatus.ClusterWide
+	scaleDown := wide.ScaleDown.Status
+	if wide.ScaleDown.Candidates > 0 {
+		scaleDown = fmt.Sprintf("%s (candidates=%d)", scaleDown, wide.ScaleDown.Candidates)
+	}
+	return []AutoscalerStatus{
+		{Name: "Health", Status: wide.Health.Status},
+		{Name: "ScaleUp", Status: wide.ScaleUp.Status},
+		{Name: "ScaleDown", Status: scaleDown},
+	}
+}
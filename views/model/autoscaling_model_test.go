@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+// clusterAutoscalerTextStatus is the status of the cluster-autoscaler-status ConfigMap
+// written by the Cluster Autoscaler before 1.30
+const clusterAutoscalerTextStatus = `Cluster-autoscaler status at 2024-01-02 15:00:00.123456789 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=3 unready=0 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=3 longUnregistered=0)
+               LastProbeTime:      2024-01-02 14:59:59.987654321 +0000 UTC
+               LastTransitionTime: 2024-01-01 10:00:00.000000000 +0000 UTC
+  ScaleUp:     NoActivity (ready=3 registered=3)
+               LastProbeTime:      2024-01-02 14:59:59.987654321 +0000 UTC
+               LastTransitionTime: 2024-01-02 12:00:00.000000000 +0000 UTC
+  ScaleDown:   CandidatesPresent (candidates=1)
+               LastProbeTime:      2024-01-02 14:59:59.987654321 +0000 UTC
+               LastTransitionTime: 2024-01-02 14:30:00.000000000 +0000 UTC
+
+NodeGroups:
+  Name:        eks-workers-2024010112000000000000001
+  Health:      Healthy (ready=3 unready=0 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=3 longUnregistered=0 cloudProviderTarget=3 (minSize=1, maxSize=10))
+  ScaleUp:     Backoff (ready=3 cloudProviderTarget=3)
+  ScaleDown:   NoCandidates (candidates=0)
+`
+
+// clusterAutoscalerYAMLStatus is the status of the cluster-autoscaler-status ConfigMap
+// written by the Cluster Autoscaler from 1.30
+const clusterAutoscalerYAMLStatus = `time: 2024-01-02 15:00:00.123456789 +0000 UTC
+autoscalerStatus: Running
+clusterWide:
+  health:
+    status: Unhealthy
+    nodeCounts:
+      registered:
+        total: 3
+        ready: 1
+        notStarted: 0
+      longUnregistered: 0
+      unregistered: 0
+    lastProbeTime: "2024-01-02T14:59:59Z"
+    lastTransitionTime: "2024-01-02T14:00:00Z"
+  scaleUp:
+    status: InProgress
+    lastProbeTime: "2024-01-02T14:59:59Z"
+    lastTransitionTime: "2024-01-02T14:50:00Z"
+  scaleDown:
+    status: CandidatesPresent
+    candidates: 2
+    lastProbeTime: "2024-01-02T14:59:59Z"
+    lastTransitionTime: "2024-01-02T14:30:00Z"
+nodeGroups:
+- name: eks-workers
+  health:
+    status: Healthy
+  scaleUp:
+    status: Backoff
+  scaleDown:
+    status: NoCandidates
+`
+
+func TestParseClusterAutoscalerStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		expected []AutoscalerStatus
+	}{
+		{
+			name:   "text",
+			status: clusterAutoscalerTextStatus,
+			expected: []AutoscalerStatus{
+				{Name: "Health", Status: "Healthy (ready=3 unready=0 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=3 longUnregistered=0)"},
+				{Name: "ScaleUp", Status: "NoActivity (ready=3 registered=3)"},
+				{Name: "ScaleDown", Status: "CandidatesPresent (candidates=1)"},
+			},
+		},
+		{
+			name:   "yaml",
+			status: clusterAutoscalerYAMLStatus,
+			expected: []AutoscalerStatus{
+				{Name: "Health", Status: "Unhealthy"},
+				{Name: "ScaleUp", Status: "InProgress"},
+				{Name: "ScaleDown", Status: "CandidatesPresent (candidates=2)"},
+			},
+		},
+		{name: "empty", status: ""},
+		{name: "unknown format", status: "autoscalerStatus: Initializing\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			result := ParseClusterAutoscalerStatus(tc.status)
+			if fmt.Sprint(result) != fmt.Sprint(tc.expected) {
+				t.Errorf("expecting status %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
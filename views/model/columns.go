@@ -0,0 +1,65 @@
+package model
+
+import "fmt"
+
+// PodColumnText returns the text of the pod column, as shown by the pods panel and the text
+// snapshots, or false for the columns without plain text value (i.e. CPU or MEMORY)
+func PodColumnText(column string, pod PodModel) (string, bool) {
+	switch column {
+	case "NAMESPACE":
+		return pod.Namespace, true
+	case "POD":
+		return pod.Name, true
+	case "READY":
+		return fmt.Sprintf("%d/%d", pod.ReadyContainers, pod.TotalContainers), true
+	case "STATUS":
+		return pod.Status, true
+	case "RESTARTS":
+		return fmt.Sprintf("%d", pod.Restarts), true
+	case "SCHEDULER":
+		return pod.Scheduler, true
+	case "AGE":
+		return pod.TimeSince, true
+	case "VOLS":
+		return fmt.Sprintf("%d", pod.Volumes), true
+	case "NODE":
+		return pod.Node, true
+	}
+	return "", false
+}
+
+// NodeColumnText returns the text of the node column, as shown by the nodes panel and the text
+// snapshots, or false for the columns without plain text value (i.e. CPU or MEM)
+func NodeColumnText(column string, node NodeModel) (string, bool) {
+	switch column {
+	case "NAME":
+		return node.Name, true
+	case "STATUS":
+		return node.Status, true
+	case "AGE":
+		return node.TimeSinceStart, true
+	case "VERSION":
+		return node.KubeletVersion, true
+	case "INT/EXT IPs":
+		return fmt.Sprintf("%s/%s", node.InternalIP, node.ExternalIP), true
+	case "INTERNAL-IP":
+		return valueOrNone(node.InternalIP), true
+	case "EXTERNAL-IP":
+		return valueOrNone(node.ExternalIP), true
+	case "OS/ARC":
+		return fmt.Sprintf("%s/%s", node.OSImage, node.Architecture), true
+	case "PODS/IMGs":
+		return fmt.Sprintf("%d/%d", node.PodsCount, node.ContainerImagesCount), true
+	case "OS":
+		return node.OS, true
+	}
+	return "", false
+}
+
+// valueOrNone returns value, or <none> when empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
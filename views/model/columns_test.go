@@ -0,0 +1,70 @@
+package model
+
+import "testing"
+
+func TestPodColumnText(t *testing.T) {
+	pod := PodModel{
+		Namespace: "default", Name: "web-0", Status: "Running", Node: "node-a", Scheduler: "default-scheduler",
+		TimeSince: "5m", ReadyContainers: 1, TotalContainers: 2, Restarts: 3, Volumes: 4,
+	}
+	tests := []struct {
+		column   string
+		expected string
+		ok       bool
+	}{
+		{column: "NAMESPACE", expected: "default", ok: true},
+		{column: "POD", expected: "web-0", ok: true},
+		{column: "READY", expected: "1/2", ok: true},
+		{column: "STATUS", expected: "Running", ok: true},
+		{column: "RESTARTS", expected: "3", ok: true},
+		{column: "SCHEDULER", expected: "default-scheduler", ok: true},
+		{column: "AGE", expected: "5m", ok: true},
+		{column: "VOLS", expected: "4", ok: true},
+		{column: "NODE", expected: "node-a", ok: true},
+		{column: "CPU"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.column, func(t *testing.T) {
+			t.Logf("running test %s", tc.column)
+			text, ok := PodColumnText(tc.column, pod)
+			if text != tc.expected || ok != tc.ok {
+				t.Errorf("expecting %q %t, got %q %t", tc.expected, tc.ok, text, ok)
+			}
+		})
+	}
+}
+
+func TestNodeColumnText(t *testing.T) {
+	node := NodeModel{
+		Name: "node-a", Status: "Ready", TimeSinceStart: "2d", KubeletVersion: "v1.24.1", InternalIP: "10.0.0.1",
+		OSImage: "Ubuntu 22.04", Architecture: "amd64", OS: "linux", PodsCount: 12, ContainerImagesCount: 30,
+	}
+	tests := []struct {
+		column   string
+		expected string
+		ok       bool
+	}{
+		{column: "NAME", expected: "node-a", ok: true},
+		{column: "STATUS", expected: "Ready", ok: true},
+		{column: "AGE", expected: "2d", ok: true},
+		{column: "VERSION", expected: "v1.24.1", ok: true},
+		{column: "INT/EXT IPs", expected: "10.0.0.1/", ok: true},
+		{column: "INTERNAL-IP", expected: "10.0.0.1", ok: true},
+		{column: "EXTERNAL-IP", expected: "<none>", ok: true},
+		{column: "OS/ARC", expected: "Ubuntu 22.04/amd64", ok: true},
+		{column: "PODS/IMGs", expected: "12/30", ok: true},
+		{column: "OS", expected: "linux", ok: true},
+		{column: "MEM"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.column, func(t *testing.T) {
+			t.Logf("running test %s", tc.column)
+			text, ok := NodeColumnText(tc.column, node)
+			if text != tc.expected || ok != tc.ok {
+				t.Errorf("expecting %q %t, got %q %t", tc.expected, tc.ok, text, ok)
+			}
+		})
+	}
+}
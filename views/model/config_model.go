@@ -0,0 +1,110 @@
+package model
+
+import (
+	"sort"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LargeConfigObjectSize is the data size above which a ConfigMap or Secret is flagged as large:
+// large objects bloat etcd, which rejects objects above 1.5MiB.
+const LargeConfigObjectSize = 512 * 1024
+
+// ConfigInventoryModel represents the ConfigMaps and Secrets of a namespace
+type ConfigInventoryModel struct {
+	Namespace  string
+	ConfigMaps int
+	Secrets    int
+	Size       int64 // total data size, in bytes
+	Oldest     metav1.Time
+	Newest     metav1.Time
+	Large      []string // kind/name of the objects larger than LargeConfigObjectSize
+}
+
+// TimeSinceOldest returns the age of the oldest object of the namespace
+func (m ConfigInventoryModel) TimeSinceOldest() string {
+	return timeSince(m.Oldest)
+}
+
+// TimeSinceNewest returns the age of the newest object of the namespace
+func (m ConfigInventoryModel) TimeSinceNewest() string {
+	return timeSince(m.Newest)
+}
+
+// ConfigObject is a ConfigMap or Secret without its data, only its data size
+type ConfigObject struct {
+	Kind         string // configmap or secret
+	Namespace    string
+	Name         string
+	CreationTime metav1.Time
+	Size         int64 // data size, in bytes
+}
+
+// NewConfigMapObject returns the ConfigMap without its data
+func NewConfigMapObject(cm coreV1.ConfigMap) ConfigObject {
+	return ConfigObject{Kind: "configmap", Namespace: cm.Namespace, Name: cm.Name, CreationTime: cm.CreationTimestamp, Size: ConfigMapSize(cm)}
+}
+
+// NewSecretObject returns the Secret without its data
+func NewSecretObject(secret coreV1.Secret) ConfigObject {
+	return ConfigObject{Kind: "secret", Namespace: secret.Namespace, Name: secret.Name, CreationTime: secret.CreationTimestamp, Size: SecretSize(secret)}
+}
+
+// NewConfigInventoryModels returns the inventory of the ConfigMaps and Secrets of each namespace, sorted by namespace
+func NewConfigInventoryModels(objects []ConfigObject) []ConfigInventoryModel {
+	byNamespace := make(map[string]*ConfigInventoryModel)
+	for _, obj := range objects {
+		m, ok := byNamespace[obj.Namespace]
+		if !ok {
+			m = &ConfigInventoryModel{Namespace: obj.Namespace, Oldest: obj.CreationTime, Newest: obj.CreationTime}
+			byNamespace[obj.Namespace] = m
+		}
+		m.Size += obj.Size
+		if obj.CreationTime.Before(&m.Oldest) {
+			m.Oldest = obj.CreationTime
+		}
+		if m.Newest.Before(&obj.CreationTime) {
+			m.Newest = obj.CreationTime
+		}
+		if obj.Size > LargeConfigObjectSize {
+			m.Large = append(m.Large, obj.Kind+"/"+obj.Name)
+		}
+		if obj.Kind == "secret" {
+			m.Secrets++
+		} else {
+			m.ConfigMaps++
+		}
+	}
+
+	models := make([]ConfigInventoryModel, 0, len(byNamespace))
+	for _, m := range byNamespace {
+		sort.Strings(m.Large)
+		models = append(models, *m)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Namespace < models[j].Namespace
+	})
+	return models
+}
+
+// ConfigMapSize returns the size, in bytes, of the ConfigMap data
+func ConfigMapSize(cm coreV1.ConfigMap) int64 {
+	var size int64
+	for key, val := range cm.Data {
+		size += int64(len(key) + len(val))
+	}
+	for key, val := range cm.BinaryData {
+		size += int64(len(key) + len(val))
+	}
+	return size
+}
+
+// SecretSize returns the size, in bytes, of the Secret data
+func SecretSize(secret coreV1.Secret) int64 {
+	var size int64
+	for key, val := range secret.Data {
+		size += int64(len(key) + len(val))
+	}
+	return size
+}
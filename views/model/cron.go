@@ -0,0 +1,239 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard (5 fields) cron expression, as accepted by CronJobs
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // bit sets of the matching values
+	domStar, dowStar              bool   // the day of month, or week, field is "*"
+	loc                           *time.Location
+}
+
+// cronMacros are the predefined schedules, replaced by their expression
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField is the range, and value names, of a cron expression field
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names of the values, from min
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// ParseCron parses the standard cron expression spec (minute, hour, day of month, month, and
+// day of week, or a predefined schedule like @daily) with an optional CRON_TZ= or TZ= prefix.
+// Schedules are in UTC unless a time zone is set.
+func ParseCron(spec string) (*CronSchedule, error) {
+	sched := &CronSchedule{loc: time.UTC}
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.Index(spec, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("cron: missing schedule after time zone")
+		}
+		loc, err := time.LoadLocation(spec[strings.Index(spec, "=")+1 : i])
+		if err != nil {
+			return nil, fmt.Errorf("cron: %w", err)
+		}
+		sched.loc, spec = loc, strings.TrimSpace(spec[i:])
+	}
+	if expr, ok := cronMacros[spec]; ok {
+		spec = expr
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron: expected %d fields, got %d in %q", len(cronFields), len(fields), spec)
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, err
+		}
+	}
+	sched.minute, sched.hour, sched.dom, sched.month, sched.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+	// 7 is also Sunday
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domStar, sched.dowStar = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+	return sched, nil
+}
+
+// parseCronField returns the bit set of the values matched by the comma-separated
+// ranges (i.e. "*", "1-5", "*/15", "mon-fri", "0,30") of expr
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("cron: invalid %s step in %q", field.name, part)
+			}
+			rng = part[:i]
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = cronValue(rng, field); err != nil {
+				return 0, err
+			}
+			// a single value with a step runs from the value to the field maximum
+			if step == 1 {
+				high = low
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("cron: invalid %s range %q", field.name, part)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// cronValue returns the value, a number or a name, of a field
+func cronValue(val string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(val, name) {
+			return field.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(val)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("cron: invalid %s %q", field.name, val)
+	}
+	return v, nil
+}
+
+// Next returns the first time matching the schedule after t, zero when none in the next five years
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches returns true when the day of t matches the day of month and day of week fields:
+// both when one of them is "*", either otherwise (like cron)
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// DescribeCron returns a human-readable description of the common cron expressions
+// (i.e. "every 15 minutes", "daily at 02:30"), or spec itself
+func DescribeCron(spec string) string {
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		return spec
+	}
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return spec
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+	m, minuteErr := strconv.Atoi(minute)
+	h, hourErr := strconv.Atoi(hour)
+	at := fmt.Sprintf("%02d:%02d", h, m)
+
+	switch {
+	case expr == "* * * * *":
+		return "every minute"
+	case strings.HasPrefix(minute, "*/") && hour == "*" && dom == "*" && month == "*" && dow == "*":
+		return fmt.Sprintf("every %s minutes", minute[2:])
+	case minuteErr == nil && hour == "*" && dom == "*" && month == "*" && dow == "*":
+		return fmt.Sprintf("hourly at :%02d", m)
+	case minuteErr == nil && strings.HasPrefix(hour, "*/") && dom == "*" && month == "*" && dow == "*":
+		return fmt.Sprintf("every %s hours at :%02d", hour[2:], m)
+	case minuteErr != nil || hourErr != nil:
+		return spec
+	case dom == "*" && month == "*" && dow == "*":
+		return "daily at " + at
+	case dom == "*" && month == "*":
+		return fmt.Sprintf("on %s at %s", describeCronDays(dow), at)
+	case month == "*" && dow == "*":
+		return fmt.Sprintf("monthly on day %s at %s", dom, at)
+	}
+	return spec
+}
+
+// describeCronDays returns the days of week of expr with their names, i.e. "Mon-Fri"
+func describeCronDays(expr string) string {
+	names := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	var b strings.Builder
+	start := 0
+	for i, r := range expr {
+		if r != ',' && r != '-' {
+			continue
+		}
+		b.WriteString(cronDayName(expr[start:i], names))
+		b.WriteRune(r)
+		start = i + 1
+	}
+	b.WriteString(cronDayName(expr[start:], names))
+	return b.String()
+}
+
+// cronDayName returns the name of the day of week val, or val when not a number
+func cronDayName(val string, names []string) string {
+	if v, err := strconv.Atoi(val); err == nil && v >= 0 && v < len(names) {
+		return names[v]
+	}
+	return val
+}
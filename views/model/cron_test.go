@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2026, 10, 16, 10, 7, 30, 0, time.UTC) // a Friday
+	tests := []struct {
+		name     string
+		spec     string
+		expected time.Time
+	}{
+		{name: "every 15 minutes", spec: "*/15 * * * *", expected: time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{name: "daily", spec: "30 2 * * *", expected: time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC)},
+		{name: "week days", spec: "0 9 * * mon-fri", expected: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{name: "macro", spec: "@monthly", expected: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or week", spec: "0 0 13 * 5", expected: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{name: "never", spec: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			sched, err := ParseCron(tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next := sched.Next(from); !next.Equal(tc.expected) {
+				t.Fatalf("expecting %s, got %s", tc.expected, next)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "61 * * * *", "*/0 * * * *", "5-1 * * * *", "0 0 * * funday"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("expecting an error parsing %q", spec)
+		}
+	}
+}
+
+func TestDescribeCron(t *testing.T) {
+	tests := map[string]string{
+		"* * * * *":    "every minute",
+		"*/10 * * * *": "every 10 minutes",
+		"15 * * * *":   "hourly at :15",
+		"5 */6 * * *":  "every 6 hours at :05",
+		"30 2 * * *":   "daily at 02:30",
+		"0 9 * * 1-5":  "on Mon-Fri at 09:00",
+		"0 0 1 * *":    "monthly on day 1 at 00:00",
+		"@daily":       "daily at 00:00",
+		"0 0 1 1,7 *":  "0 0 1 1,7 *",
+		"0 0 13 * 5":   "0 0 13 * 5",
+	}
+	for spec, expected := range tests {
+		if text := DescribeCron(spec); text != expected {
+			t.Errorf("%q: expecting %q, got %q", spec, expected, text)
+		}
+	}
+}
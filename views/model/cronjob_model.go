@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// cronJobMissedRunDelay is the delay after which a scheduled run not started by the
+// cronjob controller is considered missed
+const cronJobMissedRunDelay = time.Minute
+
+// CronJobModel represents a cronjob along with its next scheduled run and the state of its jobs
+type CronJobModel struct {
+	Namespace string
+	Name      string
+	Schedule  string
+	// Description is the human-readable schedule, i.e. "daily at 02:30"
+	Description string
+	Suspended   bool
+	// ScheduleError is the error parsing the schedule, empty when valid
+	ScheduleError string
+
+	// LastSchedule and LastSuccess are the times of the last scheduled, and last successful,
+	// runs; NextRun is the time of the next scheduled run. Times are zero when unknown.
+	LastSchedule time.Time
+	LastSuccess  time.Time
+	NextRun      time.Time
+
+	ActiveJobs int
+	FailedJobs int
+	// MissedRun is set when a scheduled run was not started, i.e. the controller was down,
+	// the starting deadline passed, or the concurrency policy forbade it
+	MissedRun bool
+}
+
+// NewCronJobModel returns a model of the cronjob at now; jobs are matched to the cronjob
+// by owner to count its failed jobs. The schedule is evaluated in the cronjob time zone.
+func NewCronJobModel(cronJob *batchV1.CronJob, jobs []*batchV1.Job, now time.Time) CronJobModel {
+	m := CronJobModel{
+		Namespace:   cronJob.Namespace,
+		Name:        cronJob.Name,
+		Schedule:    cronJob.Spec.Schedule,
+		Description: DescribeCron(cronJob.Spec.Schedule),
+		Suspended:   cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		ActiveJobs:  len(cronJob.Status.Active),
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		m.LastSchedule = cronJob.Status.LastScheduleTime.Time
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		m.LastSuccess = cronJob.Status.LastSuccessfulTime.Time
+	}
+	for _, job := range jobs {
+		if job.Namespace != cronJob.Namespace || !ownedBy(job, cronJob.UID) {
+			continue
+		}
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchV1.JobFailed && cond.Status == coreV1.ConditionTrue {
+				m.FailedJobs++
+				break
+			}
+		}
+	}
+
+	// the schedule is in the time zone of the cronjob, if set, rather than in UTC
+	spec := cronJob.Spec.Schedule
+	if tz := cronJob.Spec.TimeZone; tz != nil && *tz != "" {
+		spec = "CRON_TZ=" + *tz + " " + spec
+	}
+	sched, err := ParseCron(spec)
+	if err != nil {
+		m.ScheduleError = err.Error()
+		return m
+	}
+	if !m.Suspended {
+		m.NextRun = sched.Next(now)
+	}
+
+	// the first run expected after the last scheduled one, or after the cronjob creation
+	last := m.LastSchedule
+	if last.IsZero() {
+		last = cronJob.CreationTimestamp.Time
+	}
+	if expected := sched.Next(last); !m.Suspended && !expected.IsZero() && expected.Add(cronJobMissedRunDelay).Before(now) {
+		m.MissedRun = true
+	}
+	return m
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	batchV1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCronJobModel(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 7, 0, 0, time.UTC) // 19:07 in Tokyo
+	lastSchedule := metav1.NewTime(time.Date(2026, 10, 15, 17, 30, 0, 0, time.UTC))
+	tokyo := "Asia/Tokyo"
+	unknown := "Mars/Olympus_Mons"
+
+	tests := []struct {
+		name      string
+		timeZone  *string
+		nextRun   time.Time
+		missedRun bool
+		err       bool
+	}{
+		{
+			name:    "utc",
+			nextRun: time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC),
+			// the last run, at 17:30 UTC, was followed by no run at 02:30 UTC
+			missedRun: true,
+		},
+		{
+			name:     "time zone",
+			timeZone: &tokyo,
+			// 02:30 in Tokyo, the last run being at 02:30 in Tokyo today
+			nextRun: time.Date(2026, 10, 16, 17, 30, 0, 0, time.UTC),
+		},
+		{name: "unknown time zone", timeZone: &unknown, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			cronJob := &batchV1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "report"},
+				Spec:       batchV1.CronJobSpec{Schedule: "30 2 * * *", TimeZone: tc.timeZone},
+				Status:     batchV1.CronJobStatus{LastScheduleTime: &lastSchedule},
+			}
+			m := NewCronJobModel(cronJob, nil, now)
+			if tc.err {
+				if m.ScheduleError == "" {
+					t.Fatal("expecting a schedule error")
+				}
+				return
+			}
+			if m.ScheduleError != "" {
+				t.Fatal(m.ScheduleError)
+			}
+			if !m.NextRun.Equal(tc.nextRun) {
+				t.Errorf("expecting next run %s, got %s", tc.nextRun, m.NextRun.UTC())
+			}
+			if m.MissedRun != tc.missedRun {
+				t.Errorf("expecting missed run %t, got %t", tc.missedRun, m.MissedRun)
+			}
+		})
+	}
+}
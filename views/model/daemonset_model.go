@@ -0,0 +1,197 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DaemonSetModel represents a daemon set along with the nodes its pod is missing,
+// or not ready, on
+type DaemonSetModel struct {
+	Namespace string
+	Name      string
+	Desired   int
+	Current   int
+	Ready     int
+
+	// MissingNodes are the nodes the daemon set pod should run on but does not,
+	// NotReadyNodes the nodes running a daemon set pod that is not ready
+	MissingNodes  []string
+	NotReadyNodes []string
+}
+
+// Covered returns true when the daemon set pod runs, and is ready, on every node it should run on
+func (m DaemonSetModel) Covered() bool {
+	return m.Ready >= m.Desired && len(m.MissingNodes) == 0 && len(m.NotReadyNodes) == 0
+}
+
+// daemonSetTolerations are the tolerations added by the daemon set controller to the daemon set pods
+var daemonSetTolerations = []coreV1.Toleration{
+	{Key: coreV1.TaintNodeNotReady, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoExecute},
+	{Key: coreV1.TaintNodeUnreachable, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoExecute},
+	{Key: coreV1.TaintNodeDiskPressure, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule},
+	{Key: coreV1.TaintNodeMemoryPressure, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule},
+	{Key: coreV1.TaintNodePIDPressure, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule},
+	{Key: coreV1.TaintNodeUnschedulable, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule},
+}
+
+// NewDaemonSetModel returns a model of the daemon set; the nodes the daemon set pod should run on
+// (node selector, required node affinity, and taints) are matched against the pods it owns.
+func NewDaemonSetModel(ds *appsV1.DaemonSet, pods []*coreV1.Pod, nodes []*coreV1.Node) DaemonSetModel {
+	m := DaemonSetModel{
+		Namespace: ds.Namespace,
+		Name:      ds.Name,
+		Desired:   int(ds.Status.DesiredNumberScheduled),
+		Current:   int(ds.Status.CurrentNumberScheduled),
+		Ready:     int(ds.Status.NumberReady),
+	}
+
+	// readiness of the daemon set pods, by node
+	ready := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Namespace != ds.Namespace || pod.Spec.NodeName == "" || !ownedBy(pod, ds.UID) {
+			continue
+		}
+		if pod.Status.Phase == coreV1.PodSucceeded || pod.Status.Phase == coreV1.PodFailed {
+			continue
+		}
+		ready[pod.Spec.NodeName] = ready[pod.Spec.NodeName] || podIsReady(pod.Status.Conditions)
+	}
+
+	spec := ds.Spec.Template.Spec
+	for _, node := range nodes {
+		podReady, running := ready[node.Name]
+		switch {
+		case running && !podReady:
+			m.NotReadyNodes = append(m.NotReadyNodes, node.Name)
+		case !running && daemonSetSchedulable(spec, node):
+			m.MissingNodes = append(m.MissingNodes, node.Name)
+		}
+	}
+	sort.Strings(m.MissingNodes)
+	sort.Strings(m.NotReadyNodes)
+	return m
+}
+
+// ownedBy returns true when obj is controlled by the object with uid
+func ownedBy(obj metav1.Object, uid types.UID) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller && ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// daemonSetSchedulable returns true when a daemon set pod with spec should run on node: the node
+// matches the node selector and required node affinity, and the pod tolerates the node taints
+func daemonSetSchedulable(spec coreV1.PodSpec, node *coreV1.Node) bool {
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if affinity := spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if !matchNodeSelectorTerms(required.NodeSelectorTerms, node) {
+				return false
+			}
+		}
+	}
+
+	tolerations := append(append([]coreV1.Toleration{}, spec.Tolerations...), daemonSetTolerations...)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, coreV1.Toleration{
+			Key: coreV1.TaintNodeNetworkUnavailable, Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule,
+		})
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == coreV1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNodeSelectorTerms returns true when node matches one of terms (terms are ORed,
+// the requirements of a term ANDed)
+func matchNodeSelectorTerms(terms []coreV1.NodeSelectorTerm, node *coreV1.Node) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		matched := true
+		for _, req := range term.MatchExpressions {
+			if !matchNodeSelectorRequirement(req, node.Labels) {
+				matched = false
+				break
+			}
+		}
+		// metadata.name is the only node field supported by the scheduler, the terms
+		// with other fields match no node
+		for _, req := range term.MatchFields {
+			if !matched {
+				break
+			}
+			if req.Key != "metadata.name" || !matchNodeSelectorRequirement(req, map[string]string{req.Key: node.Name}) {
+				matched = false
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNodeSelectorRequirement returns true when values, node labels or fields, match req
+func matchNodeSelectorRequirement(req coreV1.NodeSelectorRequirement, values map[string]string) bool {
+	value, ok := values[req.Key]
+	switch req.Operator {
+	case coreV1.NodeSelectorOpExists:
+		return ok
+	case coreV1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case coreV1.NodeSelectorOpIn, coreV1.NodeSelectorOpNotIn:
+		in := false
+		for _, val := range req.Values {
+			if ok && val == value {
+				in = true
+				break
+			}
+		}
+		return in == (req.Operator == coreV1.NodeSelectorOpIn)
+	case coreV1.NodeSelectorOpGt, coreV1.NodeSelectorOpLt:
+		if !ok || len(req.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		bound, err := strconv.ParseInt(req.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if req.Operator == coreV1.NodeSelectorOpGt {
+			return actual > bound
+		}
+		return actual < bound
+	}
+	return false
+}
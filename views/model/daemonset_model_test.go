@@ -0,0 +1,153 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetSchedulable(t *testing.T) {
+	node := &coreV1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-a",
+		Labels: map[string]string{"kubernetes.io/os": "linux", "gpu-count": "4"},
+	}}
+	required := func(terms ...coreV1.NodeSelectorTerm) coreV1.PodSpec {
+		return coreV1.PodSpec{Affinity: &coreV1.Affinity{NodeAffinity: &coreV1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &coreV1.NodeSelector{NodeSelectorTerms: terms},
+		}}}
+	}
+	expression := func(key string, op coreV1.NodeSelectorOperator, values ...string) coreV1.NodeSelectorTerm {
+		return coreV1.NodeSelectorTerm{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: key, Operator: op, Values: values}}}
+	}
+	field := func(key string, op coreV1.NodeSelectorOperator, values ...string) coreV1.NodeSelectorTerm {
+		return coreV1.NodeSelectorTerm{MatchFields: []coreV1.NodeSelectorRequirement{{Key: key, Operator: op, Values: values}}}
+	}
+	tainted := func(taints ...coreV1.Taint) *coreV1.Node {
+		tainted := node.DeepCopy()
+		tainted.Spec.Taints = taints
+		return tainted
+	}
+
+	tests := []struct {
+		name     string
+		spec     coreV1.PodSpec
+		node     *coreV1.Node
+		expected bool
+	}{
+		{name: "no constraints", expected: true},
+		{name: "node selector", spec: coreV1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "linux"}}, expected: true},
+		{name: "node selector mismatch", spec: coreV1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/os": "windows"}}},
+		{name: "in", spec: required(expression("kubernetes.io/os", coreV1.NodeSelectorOpIn, "linux", "windows")), expected: true},
+		{name: "not in", spec: required(expression("kubernetes.io/os", coreV1.NodeSelectorOpNotIn, "linux"))},
+		{name: "exists", spec: required(expression("gpu-count", coreV1.NodeSelectorOpExists)), expected: true},
+		{name: "does not exist", spec: required(expression("gpu-count", coreV1.NodeSelectorOpDoesNotExist))},
+		{name: "gt", spec: required(expression("gpu-count", coreV1.NodeSelectorOpGt, "2")), expected: true},
+		{name: "gt equal", spec: required(expression("gpu-count", coreV1.NodeSelectorOpGt, "4"))},
+		{name: "lt", spec: required(expression("gpu-count", coreV1.NodeSelectorOpLt, "8")), expected: true},
+		{name: "lt not a number", spec: required(expression("kubernetes.io/os", coreV1.NodeSelectorOpLt, "8"))},
+		{name: "lt missing label", spec: required(expression("cpu-count", coreV1.NodeSelectorOpLt, "8"))},
+		{
+			name:     "terms ORed",
+			spec:     required(expression("kubernetes.io/os", coreV1.NodeSelectorOpIn, "windows"), expression("gpu-count", coreV1.NodeSelectorOpExists)),
+			expected: true,
+		},
+		{name: "empty term", spec: required(coreV1.NodeSelectorTerm{})},
+		{name: "field name", spec: required(field("metadata.name", coreV1.NodeSelectorOpIn, "node-a")), expected: true},
+		{name: "field name mismatch", spec: required(field("metadata.name", coreV1.NodeSelectorOpIn, "node-b"))},
+		{name: "field name not in", spec: required(field("metadata.name", coreV1.NodeSelectorOpNotIn, "node-b")), expected: true},
+		{name: "unsupported field", spec: required(field("metadata.uid", coreV1.NodeSelectorOpNotIn, "1234"))},
+		{
+			name: "taint not tolerated",
+			node: tainted(coreV1.Taint{Key: "dedicated", Value: "gpu", Effect: coreV1.TaintEffectNoSchedule}),
+		},
+		{
+			name: "taint tolerated",
+			spec: coreV1.PodSpec{Tolerations: []coreV1.Toleration{
+				{Key: "dedicated", Operator: coreV1.TolerationOpEqual, Value: "gpu", Effect: coreV1.TaintEffectNoSchedule},
+			}},
+			node:     tainted(coreV1.Taint{Key: "dedicated", Value: "gpu", Effect: coreV1.TaintEffectNoSchedule}),
+			expected: true,
+		},
+		{
+			name:     "prefer no schedule",
+			node:     tainted(coreV1.Taint{Key: "dedicated", Value: "gpu", Effect: coreV1.TaintEffectPreferNoSchedule}),
+			expected: true,
+		},
+		{
+			name:     "daemon set tolerations",
+			node:     tainted(coreV1.Taint{Key: coreV1.TaintNodeUnschedulable, Effect: coreV1.TaintEffectNoSchedule}),
+			expected: true,
+		},
+		{
+			name: "network unavailable",
+			node: tainted(coreV1.Taint{Key: coreV1.TaintNodeNetworkUnavailable, Effect: coreV1.TaintEffectNoSchedule}),
+		},
+		{
+			name:     "network unavailable host network",
+			spec:     coreV1.PodSpec{HostNetwork: true},
+			node:     tainted(coreV1.Taint{Key: coreV1.TaintNodeNetworkUnavailable, Effect: coreV1.TaintEffectNoSchedule}),
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			n := tc.node
+			if n == nil {
+				n = node
+			}
+			if schedulable := daemonSetSchedulable(tc.spec, n); schedulable != tc.expected {
+				t.Errorf("expecting schedulable %t, got %t", tc.expected, schedulable)
+			}
+		})
+	}
+}
+
+func TestNewDaemonSetModel(t *testing.T) {
+	controller := true
+	ds := &appsV1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "agent", UID: "ds-uid"}}
+	ds.Spec.Template.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+	ds.Status = appsV1.DaemonSetStatus{DesiredNumberScheduled: 3, CurrentNumberScheduled: 2, NumberReady: 1}
+
+	node := func(name, os string) *coreV1.Node {
+		return &coreV1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/os": os}}}
+	}
+	pod := func(name, node string, ready bool) *coreV1.Pod {
+		status := coreV1.ConditionFalse
+		if ready {
+			status = coreV1.ConditionTrue
+		}
+		return &coreV1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "kube-system",
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{{UID: "ds-uid", Controller: &controller}},
+			},
+			Spec: coreV1.PodSpec{NodeName: node},
+			Status: coreV1.PodStatus{
+				Phase:      coreV1.PodRunning,
+				Conditions: []coreV1.PodCondition{{Type: coreV1.PodReady, Status: status}},
+			},
+		}
+	}
+	other := pod("other", "node-c", true)
+	other.OwnerReferences = nil
+
+	nodes := []*coreV1.Node{node("node-a", "linux"), node("node-b", "linux"), node("node-c", "linux"), node("node-w", "windows")}
+	pods := []*coreV1.Pod{pod("agent-a", "node-a", true), pod("agent-b", "node-b", false), other}
+
+	m := NewDaemonSetModel(ds, pods, nodes)
+	if missing := strings.Join(m.MissingNodes, ","); missing != "node-c" {
+		t.Errorf("expecting missing nodes node-c, got %q", missing)
+	}
+	if notReady := strings.Join(m.NotReadyNodes, ","); notReady != "node-b" {
+		t.Errorf("expecting not ready nodes node-b, got %q", notReady)
+	}
+	if m.Covered() {
+		t.Error("expecting the daemon set not covered")
+	}
+}
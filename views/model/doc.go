@@ -0,0 +1,5 @@
+// Package model defines the view models (pods, nodes, cluster summary)
+// produced by the k8s.Controller. Models are plain values built from API
+// objects and metrics; the package keeps no state so its functions can
+// safely be used concurrently by other tools.
+package model
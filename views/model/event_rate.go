@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// eventRateWarmup is the number of samples observed before spikes are reported
+	eventRateWarmup = 12
+	// eventRateSmoothing is the weight of a new sample in the baseline moving average
+	eventRateSmoothing = 0.1
+	// eventRateSpikeFactor and eventRateSpikeMin are the ratio to the baseline, and the minimum
+	// count, of Warning events reported as a spike
+	eventRateSpikeFactor = 3
+	eventRateSpikeMin    = 10
+	// eventRateTopReasons is the number of reasons reported with a spike
+	eventRateTopReasons = 3
+)
+
+// EventRate is a sample of the Warning events rate compared to its baseline
+type EventRate struct {
+	// Warnings is the count of Warning events in the sample window
+	Warnings int
+	// Baseline is the moving average of the previous samples
+	Baseline float64
+	// Spike is set when Warnings is well above the baseline
+	Spike bool
+	// TopReasons are the most frequent reasons of the sample with their count, i.e. "BackOff (12)"
+	TopReasons []string
+}
+
+// EventRateTracker tracks the Warning events rate, sampled periodically over a sliding window,
+// against its baseline: the exponential moving average of the previous samples
+type EventRateTracker struct {
+	baseline float64
+	samples  int
+}
+
+// Observe adds the sample of Warning event counts, by reason, returning the sample rate
+// compared to the baseline before the sample
+func (t *EventRateTracker) Observe(reasons map[string]int) EventRate {
+	rate := EventRate{Baseline: t.baseline}
+	for _, count := range reasons {
+		rate.Warnings += count
+	}
+	rate.Spike = t.samples >= eventRateWarmup && rate.Warnings >= eventRateSpikeMin &&
+		float64(rate.Warnings) >= eventRateSpikeFactor*t.baseline
+
+	if t.samples == 0 {
+		t.baseline = float64(rate.Warnings)
+	} else {
+		t.baseline += eventRateSmoothing * (float64(rate.Warnings) - t.baseline)
+	}
+	t.samples++
+
+	names := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		names = append(names, reason)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if reasons[names[i]] != reasons[names[j]] {
+			return reasons[names[i]] > reasons[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > eventRateTopReasons {
+		names = names[:eventRateTopReasons]
+	}
+	for _, reason := range names {
+		rate.TopReasons = append(rate.TopReasons, fmt.Sprintf("%s (%d)", reason, reasons[reason]))
+	}
+	return rate
+}
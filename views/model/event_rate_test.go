@@ -0,0 +1,30 @@
+package model
+
+import "testing"
+
+func TestEventRateTracker(t *testing.T) {
+	var tracker EventRateTracker
+	for i := 0; i < eventRateWarmup; i++ {
+		if rate := tracker.Observe(map[string]int{"BackOff": 2}); rate.Spike {
+			t.Fatalf("unexpected spike during warmup at sample %d", i)
+		}
+	}
+
+	rate := tracker.Observe(map[string]int{"BackOff": 3, "FailedScheduling": 20, "Unhealthy": 5, "FailedMount": 1})
+	if !rate.Spike {
+		t.Fatalf("expecting a spike with %d warnings over a baseline of %.1f", rate.Warnings, rate.Baseline)
+	}
+	expected := []string{"FailedScheduling (20)", "Unhealthy (5)", "BackOff (3)"}
+	if len(rate.TopReasons) != len(expected) {
+		t.Fatalf("expecting reasons %v, got %v", expected, rate.TopReasons)
+	}
+	for i := range expected {
+		if rate.TopReasons[i] != expected[i] {
+			t.Fatalf("expecting reasons %v, got %v", expected, rate.TopReasons)
+		}
+	}
+
+	if rate := tracker.Observe(map[string]int{"BackOff": 4}); rate.Spike {
+		t.Fatalf("unexpected spike with %d warnings", rate.Warnings)
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// HugePagesResources are the hugepages resources displayed by ktop, by page size
+var HugePagesResources = []v1.ResourceName{"hugepages-2Mi", "hugepages-1Gi"}
+
+// hugePages returns the hugepages resources of list, nil without hugepages
+func hugePages(list v1.ResourceList) v1.ResourceList {
+	var result v1.ResourceList
+	for name, qty := range list {
+		if !strings.HasPrefix(string(name), v1.ResourceHugePagesPrefix) || qty.IsZero() {
+			continue
+		}
+		if result == nil {
+			result = make(v1.ResourceList)
+		}
+		result[name] = qty.DeepCopy()
+	}
+	return result
+}
+
+// AddResources adds the quantities of list to total, allocating total when nil
+func AddResources(total, list v1.ResourceList) v1.ResourceList {
+	for name, qty := range list {
+		if total == nil {
+			total = make(v1.ResourceList)
+		}
+		sum := total[name]
+		sum.Add(qty)
+		total[name] = sum
+	}
+	return total
+}
+
+// PodHugePages returns the hugepages requests and limits of the pod containers
+func PodHugePages(pod *v1.Pod) (requests, limits v1.ResourceList) {
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		requests = AddResources(requests, hugePages(container.Resources.Requests))
+		limits = AddResources(limits, hugePages(container.Resources.Limits))
+	}
+	return requests, limits
+}
+
+// HugePagesQty returns the quantity of the hugepages resource name in list, nil when not set
+func HugePagesQty(list v1.ResourceList, name v1.ResourceName) *resource.Quantity {
+	qty, ok := list[name]
+	if !ok {
+		return nil
+	}
+	return &qty
+}
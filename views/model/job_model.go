@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Job statuses
+const (
+	JobRunning   = "Running"
+	JobComplete  = "Complete"
+	JobFailed    = "Failed"
+	JobSuspended = "Suspended"
+)
+
+// JobModel represents a job along with its run duration and the reasons its pods failed
+type JobModel struct {
+	Namespace   string
+	Name        string
+	Status      string
+	Succeeded   int
+	Completions int
+	Active      int
+	// Failed is the count of failed pods, the backoff count compared to BackoffLimit
+	Failed       int
+	BackoffLimit int
+	// Duration is the run time of the job, from its start to its completion, or to now while running
+	Duration time.Duration
+	// ActiveDeadline is the activeDeadlineSeconds of the job, 0 when not set
+	ActiveDeadline time.Duration
+	// DeadlineExceeded is set when the job ran longer than its active deadline
+	DeadlineExceeded bool
+	// FailedReasons are the reasons of the failed job pods with their count, i.e. "OOMKilled (exit 137) x2"
+	FailedReasons []string
+}
+
+// NewJobModel returns a model of the job at now; pods are matched to the job by owner
+// to collect the reasons of its failed pods.
+func NewJobModel(job *batchV1.Job, pods []*coreV1.Pod, now time.Time) JobModel {
+	m := JobModel{
+		Namespace:   job.Namespace,
+		Name:        job.Name,
+		Status:      JobRunning,
+		Succeeded:   int(job.Status.Succeeded),
+		Completions: 1,
+		Active:      int(job.Status.Active),
+		Failed:      int(job.Status.Failed),
+		// the API server defaults the backoff limit to 6
+		BackoffLimit: 6,
+	}
+	if job.Spec.Completions != nil {
+		m.Completions = int(*job.Spec.Completions)
+	}
+	if job.Spec.BackoffLimit != nil {
+		m.BackoffLimit = int(*job.Spec.BackoffLimit)
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		m.ActiveDeadline = time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+	}
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		m.Status = JobSuspended
+	}
+
+	end := now
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != coreV1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchV1.JobComplete:
+			m.Status = JobComplete
+			end = cond.LastTransitionTime.Time
+		case batchV1.JobFailed:
+			m.Status = JobFailed
+			end = cond.LastTransitionTime.Time
+			// the job controller stops the job, with this reason, at its deadline
+			m.DeadlineExceeded = cond.Reason == "DeadlineExceeded"
+		}
+	}
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	if job.Status.StartTime != nil && end.After(job.Status.StartTime.Time) {
+		m.Duration = end.Sub(job.Status.StartTime.Time)
+	}
+	if m.ActiveDeadline > 0 && m.Duration > m.ActiveDeadline {
+		m.DeadlineExceeded = true
+	}
+
+	reasons := make(map[string]int)
+	for _, pod := range pods {
+		if pod.Namespace != job.Namespace || pod.Status.Phase != coreV1.PodFailed || !ownedBy(pod, job.UID) {
+			continue
+		}
+		reasons[podFailureReason(pod)]++
+	}
+	for reason, count := range reasons {
+		m.FailedReasons = append(m.FailedReasons, fmt.Sprintf("%s x%d", reason, count))
+	}
+	sort.Strings(m.FailedReasons)
+	return m
+}
+
+// podFailureReason returns the reason pod failed: the pod reason (i.e. Evicted,
+// DeadlineExceeded), else the reason and exit code of its first failed container
+func podFailureReason(pod *coreV1.Pod) string {
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+		reason := terminated.Reason
+		if reason == "" {
+			reason = "Error"
+		}
+		return fmt.Sprintf("%s (exit %d)", reason, terminated.ExitCode)
+	}
+	return "Unknown"
+}
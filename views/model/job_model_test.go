@@ -0,0 +1,159 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchV1 "k8s.io/api/batch/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// terminatedPod returns a failed pod with a container terminated with reason and exit code
+func terminatedPod(reason string, exitCode int32) *coreV1.Pod {
+	return &coreV1.Pod{Status: coreV1.PodStatus{
+		Phase: coreV1.PodFailed,
+		ContainerStatuses: []coreV1.ContainerStatus{
+			{Name: "app", State: coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode}}},
+		},
+	}}
+}
+
+func TestPodFailureReason(t *testing.T) {
+	evicted := terminatedPod("OOMKilled", 137)
+	evicted.Status.Reason = "Evicted"
+	initFailed := terminatedPod("Completed", 0)
+	initFailed.Status.InitContainerStatuses = []coreV1.ContainerStatus{
+		{Name: "init", State: coreV1.ContainerState{Terminated: &coreV1.ContainerStateTerminated{ExitCode: 2}}},
+	}
+
+	tests := []struct {
+		name     string
+		pod      *coreV1.Pod
+		expected string
+	}{
+		{name: "pod reason", pod: evicted, expected: "Evicted"},
+		{name: "container reason", pod: terminatedPod("OOMKilled", 137), expected: "OOMKilled (exit 137)"},
+		{name: "init container without reason", pod: initFailed, expected: "Error (exit 2)"},
+		{name: "no failed container", pod: terminatedPod("Completed", 0), expected: "Unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if reason := podFailureReason(tc.pod); reason != tc.expected {
+				t.Errorf("expecting reason %q, got %q", tc.expected, reason)
+			}
+		})
+	}
+}
+
+func TestNewJobModel(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	int32Ptr := func(i int32) *int32 { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+	job := func(mutate func(job *batchV1.Job)) *batchV1.Job {
+		start := metav1.NewTime(now.Add(-10 * time.Minute))
+		job := &batchV1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "report", UID: "job-uid"},
+			Status:     batchV1.JobStatus{StartTime: &start},
+		}
+		if mutate != nil {
+			mutate(job)
+		}
+		return job
+	}
+	owned := func(pod *coreV1.Pod) *coreV1.Pod {
+		pod.Namespace = "default"
+		pod.OwnerReferences = []metav1.OwnerReference{{UID: "job-uid", Controller: boolPtr(true)}}
+		return pod
+	}
+	pods := []*coreV1.Pod{
+		owned(terminatedPod("OOMKilled", 137)),
+		owned(terminatedPod("OOMKilled", 137)),
+		owned(terminatedPod("Error", 1)),
+		terminatedPod("Error", 2), // not owned by the job
+	}
+
+	tests := []struct {
+		name     string
+		job      *batchV1.Job
+		pods     []*coreV1.Pod
+		expected JobModel
+	}{
+		{
+			name: "running with defaults",
+			job:  job(nil),
+			expected: JobModel{
+				Status: JobRunning, Completions: 1, BackoffLimit: 6, Duration: 10 * time.Minute,
+			},
+		},
+		{
+			name: "complete",
+			job: job(func(job *batchV1.Job) {
+				job.Spec.Completions = int32Ptr(3)
+				job.Status.Succeeded = 3
+				completion := metav1.NewTime(now.Add(-4 * time.Minute))
+				job.Status.CompletionTime = &completion
+				job.Status.Conditions = []batchV1.JobCondition{{Type: batchV1.JobComplete, Status: coreV1.ConditionTrue, LastTransitionTime: completion}}
+			}),
+			expected: JobModel{
+				Status: JobComplete, Succeeded: 3, Completions: 3, BackoffLimit: 6, Duration: 6 * time.Minute,
+			},
+		},
+		{
+			name: "failed pods",
+			job: job(func(job *batchV1.Job) {
+				job.Spec.BackoffLimit = int32Ptr(2)
+				job.Status.Failed = 3
+				job.Status.Conditions = []batchV1.JobCondition{
+					{Type: batchV1.JobFailed, Status: coreV1.ConditionTrue, Reason: "BackoffLimitExceeded", LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+				}
+			}),
+			pods: pods,
+			expected: JobModel{
+				Status: JobFailed, Completions: 1, Failed: 3, BackoffLimit: 2, Duration: 9 * time.Minute,
+				FailedReasons: []string{"Error (exit 1) x1", "OOMKilled (exit 137) x2"},
+			},
+		},
+		{
+			name: "deadline exceeded",
+			job: job(func(job *batchV1.Job) {
+				job.Spec.ActiveDeadlineSeconds = int64Ptr(300)
+				job.Status.Active = 1
+			}),
+			expected: JobModel{
+				Status: JobRunning, Completions: 1, Active: 1, BackoffLimit: 6, Duration: 10 * time.Minute,
+				ActiveDeadline: 5 * time.Minute, DeadlineExceeded: true,
+			},
+		},
+		{
+			name: "suspended",
+			job:  job(func(job *batchV1.Job) { job.Spec.Suspend = boolPtr(true) }),
+			expected: JobModel{
+				Status: JobSuspended, Completions: 1, BackoffLimit: 6, Duration: 10 * time.Minute,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			m := NewJobModel(tc.job, tc.pods, now)
+			expected := tc.expected
+			if m.Status != expected.Status || m.Succeeded != expected.Succeeded || m.Completions != expected.Completions ||
+				m.Active != expected.Active || m.Failed != expected.Failed || m.BackoffLimit != expected.BackoffLimit {
+				t.Errorf("expecting %+v, got %+v", expected, m)
+			}
+			if m.Duration != expected.Duration || m.ActiveDeadline != expected.ActiveDeadline || m.DeadlineExceeded != expected.DeadlineExceeded {
+				t.Errorf("expecting duration %s, deadline %s (exceeded %t), got %s, %s (%t)",
+					expected.Duration, expected.ActiveDeadline, expected.DeadlineExceeded, m.Duration, m.ActiveDeadline, m.DeadlineExceeded)
+			}
+			if strings.Join(m.FailedReasons, ",") != strings.Join(expected.FailedReasons, ",") {
+				t.Errorf("expecting failed reasons %q, got %q", expected.FailedReasons, m.FailedReasons)
+			}
+		})
+	}
+}
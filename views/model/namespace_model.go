@@ -0,0 +1,96 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	// NamespaceRecentAge is the age below which a namespace is recently created
+	NamespaceRecentAge = time.Hour
+	// NamespaceStuckAge is the time after which a namespace still Terminating is stuck
+	NamespaceStuckAge = 5 * time.Minute
+)
+
+// NamespaceModel represents a namespace along with its lifecycle: recently created, or
+// Terminating with the finalizers blocking its deletion
+type NamespaceModel struct {
+	Name         string
+	Phase        string
+	Age          string
+	CreationTime time.Time
+	// Recent is set for namespaces created within NamespaceRecentAge
+	Recent bool
+
+	// DeletionTime is the time the namespace deletion was requested, zero when not Terminating
+	DeletionTime time.Time
+	// Stuck is set for namespaces Terminating for longer than NamespaceStuckAge
+	Stuck bool
+	// Finalizers are the namespace finalizers (spec and metadata) still pending
+	Finalizers []string
+	// Blockers are the messages of the namespace deletion conditions, i.e. the finalizers
+	// of the remaining namespace content, or the API groups that could not be discovered
+	Blockers []string
+}
+
+// Terminating returns true when the namespace deletion was requested
+func (m NamespaceModel) Terminating() bool {
+	return !m.DeletionTime.IsZero()
+}
+
+// NewNamespaceModel returns a model of the namespace at now
+func NewNamespaceModel(ns *coreV1.Namespace, now time.Time) NamespaceModel {
+	m := NamespaceModel{
+		Name:         ns.Name,
+		Phase:        string(ns.Status.Phase),
+		Age:          timeSince(ns.CreationTimestamp),
+		CreationTime: ns.CreationTimestamp.Time,
+		Recent:       now.Sub(ns.CreationTimestamp.Time) < NamespaceRecentAge,
+	}
+	if ns.DeletionTimestamp == nil {
+		return m
+	}
+	m.DeletionTime = ns.DeletionTimestamp.Time
+	m.Stuck = now.Sub(m.DeletionTime) > NamespaceStuckAge
+	for _, finalizer := range ns.Spec.Finalizers {
+		m.Finalizers = append(m.Finalizers, string(finalizer))
+	}
+	m.Finalizers = append(m.Finalizers, ns.Finalizers...)
+	for _, cond := range ns.Status.Conditions {
+		if cond.Status == coreV1.ConditionTrue && cond.Message != "" {
+			m.Blockers = append(m.Blockers, strings.TrimSpace(cond.Message))
+		}
+	}
+	return m
+}
+
+// NamespaceLifecycle returns the models of the Terminating namespaces, the longest terminating
+// first, followed by the recently created namespaces, the newest first
+func NamespaceLifecycle(namespaces []*coreV1.Namespace, now time.Time) []NamespaceModel {
+	var terminating, recent []NamespaceModel
+	for _, ns := range namespaces {
+		m := NewNamespaceModel(ns, now)
+		switch {
+		case m.Terminating():
+			terminating = append(terminating, m)
+		case m.Recent:
+			recent = append(recent, m)
+		}
+	}
+	sort.Slice(terminating, func(i, j int) bool {
+		if !terminating[i].DeletionTime.Equal(terminating[j].DeletionTime) {
+			return terminating[i].DeletionTime.Before(terminating[j].DeletionTime)
+		}
+		return terminating[i].Name < terminating[j].Name
+	})
+	sort.Slice(recent, func(i, j int) bool {
+		if !recent[i].CreationTime.Equal(recent[j].CreationTime) {
+			return recent[i].CreationTime.After(recent[j].CreationTime)
+		}
+		return recent[i].Name < recent[j].Name
+	})
+	return append(terminating, recent...)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceLifecycle(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	namespace := func(name string, created time.Duration, deleted time.Duration) *coreV1.Namespace {
+		ns := &coreV1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-created)),
+		}}
+		if deleted > 0 {
+			deletion := metav1.NewTime(now.Add(-deleted))
+			ns.DeletionTimestamp = &deletion
+			ns.Status.Phase = coreV1.NamespaceTerminating
+		}
+		return ns
+	}
+
+	stuck := namespace("stuck", 48*time.Hour, time.Hour)
+	stuck.Spec.Finalizers = []coreV1.FinalizerName{coreV1.FinalizerKubernetes}
+	stuck.Status.Conditions = []coreV1.NamespaceCondition{
+		{Type: coreV1.NamespaceFinalizersRemaining, Status: coreV1.ConditionTrue, Message: "Some content in the namespace has finalizers remaining: kubernetes.io/pvc-protection in 1 resource instances"},
+		{Type: coreV1.NamespaceDeletionDiscoveryFailure, Status: coreV1.ConditionFalse, Message: "All resources successfully discovered"},
+	}
+	namespaces := []*coreV1.Namespace{
+		namespace("default", 48*time.Hour, 0),
+		namespace("newest", time.Minute, 0),
+		namespace("deleting", 48*time.Hour, time.Minute),
+		namespace("new", 30*time.Minute, 0),
+		stuck,
+	}
+
+	models := NamespaceLifecycle(namespaces, now)
+	expected := []string{"stuck", "deleting", "newest", "new"}
+	if len(models) != len(expected) {
+		t.Fatalf("expecting namespaces %v, got %d models", expected, len(models))
+	}
+	for i, name := range expected {
+		if models[i].Name != name {
+			t.Fatalf("expecting namespace %s at %d, got %s", name, i, models[i].Name)
+		}
+	}
+
+	if !models[0].Stuck || models[1].Stuck {
+		t.Errorf("expecting only namespace stuck to be stuck")
+	}
+	if len(models[0].Finalizers) != 1 || models[0].Finalizers[0] != "kubernetes" {
+		t.Errorf("unexpected finalizers %v", models[0].Finalizers)
+	}
+	if len(models[0].Blockers) != 1 {
+		t.Errorf("expecting the remaining finalizers condition as blocker, got %v", models[0].Blockers)
+	}
+	if !models[2].Recent || models[2].Terminating() {
+		t.Errorf("expecting namespace newest to be recent, not terminating")
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"sort"
+	"strings"
 
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -12,8 +13,13 @@ import (
 const (
 	ControlPlaneLabel = "node-role.kubernetes.io/control-plane"
 	MasterNodeLabel   = "node-role.kubernetes.io/master"
+	// NodeRoleLabelPrefix is the prefix of the node role labels, i.e. node-role.kubernetes.io/worker
+	NodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// NodeRoleLabel is the legacy node role label, its value is the role
+	NodeRoleLabel = "kubernetes.io/role"
 )
 
+// NodeModel represents a node along with its allocatable resources and usage
 type NodeModel struct {
 	Name                 string
 	Roles                []string
@@ -31,6 +37,14 @@ type NodeModel struct {
 	VolumesInUse         int
 	VolumesAttached      int
 
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// Unreachable is set when the node controller lost contact with the node kubelet
+	Unreachable bool
+	// ReadyTransition is the time of the last transition of the node Ready condition, zero when unknown
+	ReadyTransition metav1.Time
+
 	KubeletVersion          string
 	OS                      string
 	OSImage                 string
@@ -40,15 +54,43 @@ type NodeModel struct {
 
 	RequestedPodCpuQty *resource.Quantity
 	RequestedPodMemQty *resource.Quantity
+	// LimitPodCpuQty and LimitPodMemQty are the limits of the app containers of the node pods,
+	// nil when a node pod is not limited (see GetPodContainerSummary)
+	LimitPodCpuQty *resource.Quantity
+	LimitPodMemQty *resource.Quantity
+	// RequestedPodHugePages and AllocatableHugePages are the hugepages resources requested by the
+	// node pods, and allocatable, by resource name (i.e. hugepages-2Mi), nil without hugepages
+	RequestedPodHugePages coreV1.ResourceList
+	AllocatableHugePages  coreV1.ResourceList
 
 	AllocatableCpuQty     *resource.Quantity
 	AllocatableMemQty     *resource.Quantity
 	AllocatableStorageQty *resource.Quantity
 
+	CapacityCpuQty *resource.Quantity
+	CapacityMemQty *resource.Quantity
+
 	UsageCpuQty *resource.Quantity
 	UsageMemQty *resource.Quantity
 }
 
+// NodeSwapModel is the swap configuration and usage of a node, read from its kubelet
+type NodeSwapModel struct {
+	// Behavior is the kubelet memorySwap.swapBehavior (NoSwap or LimitedSwap), empty
+	// for the kubelet default; FailSwapOn is the kubelet failSwapOn setting
+	Behavior   string
+	FailSwapOn bool
+	// ConfigKnown is false when the kubelet configuration could not be read
+	ConfigKnown bool
+
+	// CapacityQty and UsageQty are nil when the kubelet does not report swap
+	CapacityQty *resource.Quantity
+	UsageQty    *resource.Quantity
+	// Pods are the swap usages of the pods of the node using swap, keyed by namespace/name
+	Pods map[string]*resource.Quantity
+}
+
+// NewNodeModel builds a node model from a node and its metrics (metrics may be empty, but not nil)
 func NewNodeModel(node *coreV1.Node, metrics *v1beta1.NodeMetrics) *NodeModel {
 	roles := GetNodeControlRoles(node)
 	return &NodeModel{
@@ -62,6 +104,11 @@ func NewNodeModel(node *coreV1.Node, metrics *v1beta1.NodeMetrics) *NodeModel {
 		CreationTime:   node.CreationTimestamp,
 		InternalIP:     GetNodeIp(node, coreV1.NodeInternalIP),
 		ExternalIP:     GetNodeIp(node, coreV1.NodeExternalIP),
+		Labels:         node.Labels,
+		Annotations:    node.Annotations,
+
+		Unreachable:     IsNodeUnreachable(node),
+		ReadyTransition: GetNodeReadyTransition(node),
 
 		ContainerImagesCount: len(node.Status.Images),
 		VolumesAttached:      len(node.Status.VolumesAttached),
@@ -77,12 +124,31 @@ func NewNodeModel(node *coreV1.Node, metrics *v1beta1.NodeMetrics) *NodeModel {
 		AllocatableCpuQty:     node.Status.Allocatable.Cpu(),
 		AllocatableMemQty:     node.Status.Allocatable.Memory(),
 		AllocatableStorageQty: node.Status.Allocatable.StorageEphemeral(),
+		CapacityCpuQty:        node.Status.Capacity.Cpu(),
+		CapacityMemQty:        node.Status.Capacity.Memory(),
+		AllocatableHugePages:  hugePages(node.Status.Allocatable),
 
 		UsageCpuQty: metrics.Usage.Cpu(),
 		UsageMemQty: metrics.Usage.Memory(),
 	}
 }
 
+// IsWindows returns true if the node runs Windows
+func (m NodeModel) IsWindows() bool {
+	return strings.EqualFold(m.OS, "windows")
+}
+
+// MemoryBaseQty returns the memory the node memory usage is compared to: the allocatable
+// memory or, on Windows nodes, the memory capacity. The memory usage of Windows nodes is
+// the memory used by the whole host, not only by pods, so it can exceed the allocatable
+// memory with no pod pressure.
+func (m NodeModel) MemoryBaseQty() *resource.Quantity {
+	if m.IsWindows() && m.CapacityMemQty != nil && !m.CapacityMemQty.IsZero() {
+		return m.CapacityMemQty
+	}
+	return m.AllocatableMemQty
+}
+
 func GetNodeControlRoles(node *coreV1.Node) []string {
 	roles := []string{}
 	for key, _ := range node.Labels {
@@ -96,6 +162,21 @@ func GetNodeControlRoles(node *coreV1.Node) []string {
 	return roles
 }
 
+// GetNodeRoles returns the sorted roles of node, from its role labels, like kubectl get nodes
+func GetNodeRoles(node *coreV1.Node) []string {
+	var roles []string
+	for key, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(key, NodeRoleLabelPrefix) && len(key) > len(NodeRoleLabelPrefix):
+			roles = append(roles, strings.TrimPrefix(key, NodeRoleLabelPrefix))
+		case key == NodeRoleLabel && value != "":
+			roles = append(roles, value)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
 func IsNodeController(roles []string) bool {
 	for _, role := range roles {
 		if role == "control-plane" || role == "master" {
@@ -123,6 +204,27 @@ func GetNodeReadyStatus(node *coreV1.Node) string {
 	return "NotReady"
 }
 
+// IsNodeUnreachable returns true when the node is tainted unreachable, i.e. its kubelet
+// stopped posting status
+func IsNodeUnreachable(node *coreV1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == coreV1.TaintNodeUnreachable {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodeReadyTransition returns the time of the last transition of the node Ready condition
+func GetNodeReadyTransition(node *coreV1.Node) metav1.Time {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == coreV1.NodeReady {
+			return cond.LastTransitionTime
+		}
+	}
+	return metav1.Time{}
+}
+
 func GetNodePressures(node *coreV1.Node) []string {
 	var pressures []string
 	for _, cond := range node.Status.Conditions {
@@ -147,6 +249,7 @@ func GetNodeIp(node *coreV1.Node, addrType coreV1.NodeAddressType) string {
 	return "<none>"
 }
 
+// SortNodeModels sorts nodes by name
 func SortNodeModels(nodes []NodeModel) {
 	sort.Slice(nodes, func(i, j int) bool {
 		return nodes[i].Name < nodes[j].Name
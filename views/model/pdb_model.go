@@ -0,0 +1,75 @@
+package model
+
+import (
+	"sort"
+
+	coreV1 "k8s.io/api/core/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PDBModel represents a pod disruption budget along with the nodes it currently prevents from being drained
+type PDBModel struct {
+	Namespace          string
+	Name               string
+	MinAvailable       string // empty when not set
+	MaxUnavailable     string // empty when not set
+	CurrentHealthy     int
+	DesiredHealthy     int
+	ExpectedPods       int
+	DisruptionsAllowed int
+
+	// BlockedNodes are the nodes running pods covered by the budget, when no disruption is allowed
+	BlockedNodes []string
+}
+
+// BlocksDrain returns true when the budget allows no disruption of its pods, so that
+// evicting any of them, i.e. to drain their node, fails
+func (m PDBModel) BlocksDrain() bool {
+	return m.DisruptionsAllowed == 0 && m.ExpectedPods > 0
+}
+
+// NewPDBModel returns a model of the budget; pods are matched against the budget selector
+// to find the nodes which drain is blocked.
+func NewPDBModel(pdb *policyV1.PodDisruptionBudget, pods []*coreV1.Pod) PDBModel {
+	m := PDBModel{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		CurrentHealthy:     int(pdb.Status.CurrentHealthy),
+		DesiredHealthy:     int(pdb.Status.DesiredHealthy),
+		ExpectedPods:       int(pdb.Status.ExpectedPods),
+		DisruptionsAllowed: int(pdb.Status.DisruptionsAllowed),
+	}
+	if pdb.Spec.MinAvailable != nil {
+		m.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		m.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	if !m.BlocksDrain() {
+		return m
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return m
+	}
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Namespace != pdb.Namespace || pod.Spec.NodeName == "" {
+			continue
+		}
+		if pod.Status.Phase == coreV1.PodSucceeded || pod.Status.Phase == coreV1.PodFailed {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+	for node := range nodes {
+		m.BlockedNodes = append(m.BlockedNodes, node)
+	}
+	sort.Strings(m.BlockedNodes)
+	return m
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -12,18 +13,31 @@ import (
 	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+// PodModel represents a pod along with its resource requests and usage
 type PodModel struct {
 	Namespace string
 	Name      string
 	Status    string
 	Node      string
+	Scheduler string
 	IP        string
+	// IPs are the pod IPs, one per address family on dual-stack clusters
+	IPs       []string
 	TimeSince string
+	// CreationTime is the pod creation timestamp, used to sort by age
+	CreationTime metav1.Time
+
+	Labels      map[string]string
+	Annotations map[string]string
 
 	PodRequestedCpuQty *resource.Quantity
 	PodRequestedMemQty *resource.Quantity
 	PodUsageCpuQty     *resource.Quantity
 	PodUsageMemQty     *resource.Quantity
+	// PodLimitCpuQty and PodLimitMemQty are the limits of the pod app containers, zero
+	// when an app container sets no limit: the pod is not limited
+	PodLimitCpuQty *resource.Quantity
+	PodLimitMemQty *resource.Quantity
 
 	NodeAllocatableCpuQty *resource.Quantity
 	NodeAllocatableMemQty *resource.Quantity
@@ -35,11 +49,79 @@ type PodModel struct {
 	Restarts        int
 	Volumes         int
 	VolMounts       int
+
+	// RecentRestarts and HourRestarts are the restarts observed by ktop in the last
+	// 10 minutes and in the last hour
+	RecentRestarts int
+	HourRestarts   int
+	// LastRestart is the time of the most recent container restart, zero without restarts
+	LastRestart metav1.Time
+
+	// Workload is the kind/name of the workload managing the pod (i.e. Deployment/web), empty
+	// for standalone pods
+	Workload string
+
+	// QOSClass is the quality of service class of the pod (Guaranteed, Burstable, or BestEffort),
+	// NodePressures the pressure conditions (mem, disk, pid) of its node
+	QOSClass      string
+	NodePressures []string
+
+	// HostNetwork is set for pods in the host network namespace, HostPorts lists
+	// the host ports bound by the pod containers
+	HostNetwork bool
+	HostPorts   []int32
+
+	// HugePagesRequests and HugePagesLimits are the hugepages resources of the pod containers,
+	// by resource name (i.e. hugepages-2Mi), nil without hugepages
+	HugePagesRequests v1.ResourceList
+	HugePagesLimits   v1.ResourceList
+
+	// ThrottlePercent is the percentage of the CPU (CFS) periods of the pod containers that
+	// were throttled between the last two kubelet scrapes; ThrottleSampled is false without samples
+	ThrottlePercent float64
+	ThrottleSampled bool
+
+	// SecurityFlags are the security posture flags of the pod, see PodSecurityFlags
+	SecurityFlags []string
+
+	// Images are the images of the pod app containers, in spec order
+	Images []string
+
+	// Containers are the pod app containers, in spec order
+	Containers []ContainerModel
+}
+
+// ContainerModel represents a pod container along with its resource requests and usage
+type ContainerModel struct {
+	Name     string
+	Ready    bool
+	Status   string
+	Restarts int
+
+	RequestedCpuQty *resource.Quantity
+	RequestedMemQty *resource.Quantity
+	UsageCpuQty     *resource.Quantity
+	UsageMemQty     *resource.Quantity
+	LimitCpuQty     *resource.Quantity
+	LimitMemQty     *resource.Quantity
+}
+
+// ContainerMemoryModel is the memory breakdown of a container: the working set, the usage
+// counted against the memory limit, includes the active page cache besides the RSS.
+// SwapQty is nil when the kubelet does not report swap.
+type ContainerMemoryModel struct {
+	Name          string
+	WorkingSetQty *resource.Quantity
+	RSSQty        *resource.Quantity
+	PageCacheQty  *resource.Quantity
+	SwapQty       *resource.Quantity
 }
 
 type PodContainerSummary struct {
 	RequestedMemQty *resource.Quantity
 	RequestedCpuQty *resource.Quantity
+	LimitMemQty     *resource.Quantity
+	LimitCpuQty     *resource.Quantity
 	VolMounts       int
 	Ports           int
 }
@@ -52,6 +134,7 @@ type ContainerStatusSummary struct {
 	SomeRunning bool
 }
 
+// SortPodModels sorts pods by namespace then name
 func SortPodModels(pods []PodModel) {
 	sort.Slice(pods, func(i, j int) bool {
 		if pods[i].Namespace != pods[j].Namespace {
@@ -61,6 +144,7 @@ func SortPodModels(pods []PodModel) {
 	})
 }
 
+// NewPodModel builds a pod model from a pod and its metrics (metrics may be empty, but not nil)
 func NewPodModel(pod *v1.Pod, podMetrics *metricsV1beta1.PodMetrics, nodeMetrics *metricsV1beta1.NodeMetrics) *PodModel {
 	totalCpu, totalMem := podMetricsTotals(podMetrics)
 	statusSummary := getContainerStatusSummary(pod.Status.ContainerStatuses)
@@ -72,17 +156,25 @@ func NewPodModel(pod *v1.Pod, podMetrics *metricsV1beta1.PodMetrics, nodeMetrics
 		}
 	}
 	containerSummary := GetPodContainerSummary(pod)
+	hugePagesRequests, hugePagesLimits := PodHugePages(pod)
 	return &PodModel{
 		Namespace:          pod.GetNamespace(),
 		Name:               pod.Name,
 		Status:             statusSummary.Status,
 		TimeSince:          timeSince(pod.CreationTimestamp),
+		CreationTime:       pod.CreationTimestamp,
 		IP:                 pod.Status.PodIP,
+		IPs:                PodIPs(pod),
 		Node:               pod.Spec.NodeName,
+		Scheduler:          pod.Spec.SchedulerName,
+		Labels:             pod.Labels,
+		Annotations:        pod.Annotations,
 		Volumes:            len(pod.Spec.Volumes),
 		VolMounts:          containerSummary.VolMounts,
 		PodRequestedMemQty: containerSummary.RequestedMemQty,
 		PodRequestedCpuQty: containerSummary.RequestedCpuQty,
+		PodLimitMemQty:     containerSummary.LimitMemQty,
+		PodLimitCpuQty:     containerSummary.LimitCpuQty,
 		NodeUsageCpuQty:    nodeMetrics.Usage.Cpu(),
 		NodeUsageMemQty:    nodeMetrics.Usage.Memory(),
 		PodUsageCpuQty:     totalCpu,
@@ -90,6 +182,137 @@ func NewPodModel(pod *v1.Pod, podMetrics *metricsV1beta1.PodMetrics, nodeMetrics
 		ReadyContainers:    statusSummary.Ready,
 		TotalContainers:    statusSummary.Total,
 		Restarts:           statusSummary.Restarts,
+		LastRestart:        LastRestartTime(pod),
+		Images:             getContainerImages(pod),
+		SecurityFlags:      PodSecurityFlags(pod),
+		Containers:         getContainerModels(pod, podMetrics),
+		Workload:           PodWorkload(pod),
+		QOSClass:           string(pod.Status.QOSClass),
+		HostNetwork:        pod.Spec.HostNetwork,
+		HostPorts:          PodHostPorts(pod),
+		HugePagesRequests:  hugePagesRequests,
+		HugePagesLimits:    hugePagesLimits,
+	}
+}
+
+// getContainerModels returns a model for each app container of pod with its status and usage
+func getContainerModels(pod *v1.Pod, podMetrics *metricsV1beta1.PodMetrics) []ContainerModel {
+	statuses := make(map[string]v1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+	usages := make(map[string]v1.ResourceList, len(podMetrics.Containers))
+	for _, metrics := range podMetrics.Containers {
+		usages[metrics.Name] = metrics.Usage
+	}
+
+	containers := make([]ContainerModel, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		status := statuses[container.Name]
+		usage := usages[container.Name]
+		containers = append(containers, ContainerModel{
+			Name:            container.Name,
+			Ready:           status.Ready,
+			Status:          containerStatus(status),
+			Restarts:        int(status.RestartCount),
+			RequestedCpuQty: container.Resources.Requests.Cpu(),
+			RequestedMemQty: container.Resources.Requests.Memory(),
+			UsageCpuQty:     usage.Cpu(),
+			UsageMemQty:     usage.Memory(),
+			LimitCpuQty:     container.Resources.Limits.Cpu(),
+			LimitMemQty:     container.Resources.Limits.Memory(),
+		})
+	}
+	return containers
+}
+
+// LastRestartTime returns the time of the most recent container restart of pod, the
+// end of the previous container run, or a zero time when no container restarted
+func LastRestartTime(pod *v1.Pod) metav1.Time {
+	var last metav1.Time
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			terminated := status.LastTerminationState.Terminated
+			if status.RestartCount == 0 || terminated == nil {
+				continue
+			}
+			if terminated.FinishedAt.After(last.Time) {
+				last = terminated.FinishedAt
+			}
+		}
+	}
+	return last
+}
+
+// TimeSinceLastRestart returns the age of the most recent container restart, or "-" without restarts
+func (m PodModel) TimeSinceLastRestart() string {
+	if m.LastRestart.IsZero() {
+		return "-"
+	}
+	return timeSince(m.LastRestart)
+}
+
+// PodIPs returns the IPs of pod (status.podIPs), or its primary IP when the
+// API server does not report podIPs
+func PodIPs(pod *v1.Pod) []string {
+	var ips []string
+	for _, ip := range pod.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, pod.Status.PodIP)
+	}
+	return ips
+}
+
+// PodWorkload returns the kind/name of the workload managing pod: the controller of the pod,
+// or the deployment of its replica set (i.e. Deployment/web), empty for standalone pods
+func PodWorkload(pod *v1.Pod) string {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return ""
+	}
+	if hash := pod.Labels["pod-template-hash"]; ref.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+		return "Deployment/" + strings.TrimSuffix(ref.Name, "-"+hash)
+	}
+	return ref.Kind + "/" + ref.Name
+}
+
+// PodHostPorts returns the host ports bound by the containers of pod, in spec order
+func PodHostPorts(pod *v1.Pod) []int32 {
+	var ports []int32
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				ports = append(ports, port.HostPort)
+			}
+		}
+	}
+	return ports
+}
+
+// getContainerImages returns the images of the app containers of pod
+func getContainerImages(pod *v1.Pod) []string {
+	images := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// containerStatus returns the container state, or its reason when not running
+func containerStatus(status v1.ContainerStatus) string {
+	switch {
+	case status.State.Running != nil:
+		return "Running"
+	case status.State.Waiting != nil:
+		return status.State.Waiting.Reason
+	case status.State.Terminated != nil && status.State.Terminated.Reason != "":
+		return status.State.Terminated.Reason
+	case status.State.Terminated != nil:
+		return fmt.Sprintf("Exit:%d", status.State.Terminated.ExitCode)
+	default:
+		return "..."
 	}
 }
 
@@ -144,33 +367,52 @@ func timeSince(ts metav1.Time) string {
 	return duration.HumanDuration(time.Since(ts.Time))
 }
 
+// GetPodContainerSummary sums the resource requests, ports, and volume mounts of the pod containers.
+// The limits of the app containers are summed when each of them sets one, and zero otherwise.
 func GetPodContainerSummary(pod *v1.Pod) PodContainerSummary {
 	mems := resource.NewQuantity(0, resource.DecimalSI)
 	cpus := resource.NewQuantity(0, resource.DecimalSI)
+	memLimits := resource.NewQuantity(0, resource.DecimalSI)
+	cpuLimits := resource.NewQuantity(0, resource.DecimalSI)
 	var ports int
 	var mounts int
+	memLimited, cpuLimited := len(pod.Spec.Containers) > 0, len(pod.Spec.Containers) > 0
 	for _, container := range pod.Spec.Containers {
 		mems.Add(*container.Resources.Requests.Memory())
 		cpus.Add(*container.Resources.Requests.Cpu())
+		memLimit, cpuLimit := container.Resources.Limits.Memory(), container.Resources.Limits.Cpu()
+		memLimited = memLimited && !memLimit.IsZero()
+		cpuLimited = cpuLimited && !cpuLimit.IsZero()
+		memLimits.Add(*memLimit)
+		cpuLimits.Add(*cpuLimit)
 		ports += len(container.Ports)
 		mounts += len(container.VolumeMounts)
 	}
+	// a container without limit can use all the node resources, so can the pod
+	if !memLimited {
+		memLimits = resource.NewQuantity(0, resource.DecimalSI)
+	}
+	if !cpuLimited {
+		cpuLimits = resource.NewQuantity(0, resource.DecimalSI)
+	}
 
 	for _, container := range pod.Spec.InitContainers {
 		mems.Add(*container.Resources.Requests.Memory())
-		mems.Add(*container.Resources.Requests.Cpu())
+		cpus.Add(*container.Resources.Requests.Cpu())
 		ports += len(container.Ports)
 		mounts += len(container.VolumeMounts)
 	}
 
 	if pod.Spec.Overhead != nil {
 		mems.Add(*pod.Spec.Overhead.Memory())
-		mems.Add(*pod.Spec.Overhead.Cpu())
+		cpus.Add(*pod.Spec.Overhead.Cpu())
 	}
 
 	return PodContainerSummary{
 		RequestedMemQty: mems,
 		RequestedCpuQty: cpus,
+		LimitMemQty:     memLimits,
+		LimitCpuQty:     cpuLimits,
 		VolMounts:       mounts,
 		Ports:           ports,
 	}
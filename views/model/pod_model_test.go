@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGetPodContainerSummaryLimits(t *testing.T) {
+	container := func(cpuLimit, memLimit string) coreV1.Container {
+		limits := coreV1.ResourceList{}
+		if cpuLimit != "" {
+			limits[coreV1.ResourceCPU] = resource.MustParse(cpuLimit)
+		}
+		if memLimit != "" {
+			limits[coreV1.ResourceMemory] = resource.MustParse(memLimit)
+		}
+		return coreV1.Container{Resources: coreV1.ResourceRequirements{Limits: limits}}
+	}
+
+	requests := coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("100m"), coreV1.ResourceMemory: resource.MustParse("32Mi")}
+
+	tests := []struct {
+		name           string
+		containers     []coreV1.Container
+		initContainers []coreV1.Container
+		overhead       coreV1.ResourceList
+		cpuLimit       string
+		memLimit       string
+		// cpuRequest and memRequest are checked when set
+		cpuRequest string
+		memRequest string
+	}{
+		{name: "all limited", containers: []coreV1.Container{container("500m", "256Mi"), container("250m", "128Mi")}, cpuLimit: "750m", memLimit: "384Mi"},
+		{name: "one container without CPU limit", containers: []coreV1.Container{container("500m", "256Mi"), container("", "128Mi")}, cpuLimit: "0", memLimit: "384Mi"},
+		{name: "one container without limits", containers: []coreV1.Container{container("500m", "256Mi"), container("", "")}, cpuLimit: "0", memLimit: "0"},
+		{name: "no limits", containers: []coreV1.Container{container("", "")}, cpuLimit: "0", memLimit: "0"},
+		{name: "no containers", cpuLimit: "0", memLimit: "0"},
+		{
+			// the init container and overhead CPU requests are counted as CPU, not memory
+			name:           "init containers and overhead requests",
+			containers:     []coreV1.Container{{Resources: coreV1.ResourceRequirements{Requests: requests}}},
+			initContainers: []coreV1.Container{{Resources: coreV1.ResourceRequirements{Requests: requests}}},
+			overhead:       coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("250m"), coreV1.ResourceMemory: resource.MustParse("64Mi")},
+			cpuLimit:       "0",
+			memLimit:       "0",
+			cpuRequest:     "450m",
+			memRequest:     "128Mi",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			pod := &coreV1.Pod{Spec: coreV1.PodSpec{
+				Containers: tc.containers,
+				// init containers do not count in the pod limits
+				InitContainers: append(tc.initContainers, container("", "")),
+				Overhead:       tc.overhead,
+			}}
+			summary := GetPodContainerSummary(pod)
+			if summary.LimitCpuQty.Cmp(resource.MustParse(tc.cpuLimit)) != 0 {
+				t.Errorf("expecting CPU limit %s, got %s", tc.cpuLimit, summary.LimitCpuQty)
+			}
+			if summary.LimitMemQty.Cmp(resource.MustParse(tc.memLimit)) != 0 {
+				t.Errorf("expecting memory limit %s, got %s", tc.memLimit, summary.LimitMemQty)
+			}
+			if tc.cpuRequest != "" && summary.RequestedCpuQty.Cmp(resource.MustParse(tc.cpuRequest)) != 0 {
+				t.Errorf("expecting CPU request %s, got %s", tc.cpuRequest, summary.RequestedCpuQty)
+			}
+			if tc.memRequest != "" && summary.RequestedMemQty.Cmp(resource.MustParse(tc.memRequest)) != 0 {
+				t.Errorf("expecting memory request %s, got %s", tc.memRequest, summary.RequestedMemQty)
+			}
+		})
+	}
+}
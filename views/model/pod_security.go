@@ -0,0 +1,76 @@
+package model
+
+import (
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Pod security posture flags, in display order
+const (
+	SecurityPrivileged        = "privileged"
+	SecurityRoot              = "root"
+	SecurityHostPID           = "hostPID"
+	SecurityHostNetwork       = "hostNetwork"
+	SecurityNoSecurityContext = "noSecCtx"
+)
+
+// PodSecurityFlags returns the security posture flags of pod: privileged containers,
+// containers that may run as root, host PID or network namespaces, and no security
+// context set at the pod or container level. A container may run as root unless
+// runAsNonRoot is true, or its effective runAsUser is not 0: without runAsUser, the
+// container runs as the image user, root unless the image sets another one.
+func PodSecurityFlags(pod *v1.Pod) []string {
+	var flags []string
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+
+	privileged, root, secCtx := false, false, !isEmptyPodSecurityContext(pod.Spec.SecurityContext)
+	for _, container := range containers {
+		runAsUser, runAsNonRoot := podRunAsUser(pod)
+		if ctx := container.SecurityContext; ctx != nil {
+			secCtx = true
+			if ctx.Privileged != nil && *ctx.Privileged {
+				privileged = true
+			}
+			if ctx.RunAsUser != nil {
+				runAsUser = ctx.RunAsUser
+			}
+			if ctx.RunAsNonRoot != nil {
+				runAsNonRoot = ctx.RunAsNonRoot
+			}
+		}
+		nonRoot := (runAsNonRoot != nil && *runAsNonRoot) || (runAsUser != nil && *runAsUser != 0)
+		if !nonRoot {
+			root = true
+		}
+	}
+
+	if privileged {
+		flags = append(flags, SecurityPrivileged)
+	}
+	if root {
+		flags = append(flags, SecurityRoot)
+	}
+	if pod.Spec.HostPID {
+		flags = append(flags, SecurityHostPID)
+	}
+	if pod.Spec.HostNetwork {
+		flags = append(flags, SecurityHostNetwork)
+	}
+	if !secCtx {
+		flags = append(flags, SecurityNoSecurityContext)
+	}
+	return flags
+}
+
+// podRunAsUser returns the pod level user, and runAsNonRoot, of the containers, nil when not set
+func podRunAsUser(pod *v1.Pod) (*int64, *bool) {
+	if pod.Spec.SecurityContext == nil {
+		return nil, nil
+	}
+	return pod.Spec.SecurityContext.RunAsUser, pod.Spec.SecurityContext.RunAsNonRoot
+}
+
+func isEmptyPodSecurityContext(ctx *v1.PodSecurityContext) bool {
+	return ctx == nil || reflect.DeepEqual(*ctx, v1.PodSecurityContext{})
+}
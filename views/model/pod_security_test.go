@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestPodSecurityFlags(t *testing.T) {
+	user := func(uid int64) *int64 { return &uid }
+	boolean := func(b bool) *bool { return &b }
+	pod := func(podCtx *coreV1.PodSecurityContext, containerCtxs ...*coreV1.SecurityContext) *coreV1.Pod {
+		pod := &coreV1.Pod{Spec: coreV1.PodSpec{SecurityContext: podCtx}}
+		for _, ctx := range containerCtxs {
+			pod.Spec.Containers = append(pod.Spec.Containers, coreV1.Container{Name: "app", SecurityContext: ctx})
+		}
+		return pod
+	}
+
+	hostPod := pod(&coreV1.PodSecurityContext{RunAsNonRoot: boolean(true)}, nil)
+	hostPod.Spec.HostPID, hostPod.Spec.HostNetwork = true, true
+
+	tests := []struct {
+		name     string
+		pod      *coreV1.Pod
+		expected string
+	}{
+		{name: "no security context", pod: pod(nil, nil), expected: "root,noSecCtx"},
+		{name: "image user", pod: pod(&coreV1.PodSecurityContext{FSGroup: user(2000)}, nil), expected: "root"},
+		{name: "run as non root", pod: pod(&coreV1.PodSecurityContext{RunAsNonRoot: boolean(true)}, nil), expected: ""},
+		{name: "pod user", pod: pod(&coreV1.PodSecurityContext{RunAsUser: user(1000)}, nil), expected: ""},
+		{name: "pod root user", pod: pod(&coreV1.PodSecurityContext{RunAsUser: user(0)}, nil), expected: "root"},
+		{
+			name:     "container root user",
+			pod:      pod(&coreV1.PodSecurityContext{RunAsUser: user(1000)}, &coreV1.SecurityContext{RunAsUser: user(0)}),
+			expected: "root",
+		},
+		{
+			name:     "container non root",
+			pod:      pod(nil, &coreV1.SecurityContext{RunAsNonRoot: boolean(true)}, &coreV1.SecurityContext{RunAsUser: user(1000)}),
+			expected: "",
+		},
+		{
+			name:     "one container as image user",
+			pod:      pod(nil, &coreV1.SecurityContext{RunAsNonRoot: boolean(true)}, &coreV1.SecurityContext{ReadOnlyRootFilesystem: boolean(true)}),
+			expected: "root",
+		},
+		{
+			name:     "container overrides run as non root",
+			pod:      pod(&coreV1.PodSecurityContext{RunAsNonRoot: boolean(true)}, &coreV1.SecurityContext{RunAsNonRoot: boolean(false)}),
+			expected: "root",
+		},
+		{
+			name:     "privileged",
+			pod:      pod(nil, &coreV1.SecurityContext{Privileged: boolean(true), RunAsUser: user(1000)}),
+			expected: "privileged",
+		},
+		{name: "host namespaces", pod: hostPod, expected: "hostPID,hostNetwork"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			flags := strings.Join(PodSecurityFlags(tc.pod), ",")
+			if flags != tc.expected {
+				t.Errorf("expecting flags %q, got %q", tc.expected, flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"sort"
+	"strconv"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// deploymentRevisionAnnotation is the revision of a deployment, and of its replica sets
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// defaultRevisionHistoryLimit is the revisionHistoryLimit of deployments when not set
+const defaultRevisionHistoryLimit = 10
+
+// deploymentAvailableReason is the reason of the Progressing condition of a deployment
+// once its rollout is complete
+const deploymentAvailableReason = "NewReplicaSetAvailable"
+
+// Replica set issues
+const (
+	// ReplicaSetOrphaned is a replica set without owner, i.e. left by a deployment deleted
+	// with the orphan propagation policy, still holding pods
+	ReplicaSetOrphaned = "orphaned"
+	// ReplicaSetOldWithPods is a replica set of an old deployment revision still holding pods,
+	// while the deployment is not rolling out
+	ReplicaSetOldWithPods = "old revision with pods"
+	// ReplicaSetExcessRevision is a scaled down replica set retained beyond the
+	// revisionHistoryLimit of its deployment
+	ReplicaSetExcessRevision = "beyond revision history limit"
+)
+
+// ReplicaSetModel represents a replica set along with the issue explaining why it is
+// still around, i.e. "ghost" pods during rollbacks
+type ReplicaSetModel struct {
+	Namespace string
+	Name      string
+	// Owner is the kind/name of the controller of the replica set, empty when orphaned
+	Owner    string
+	Revision string
+	Desired  int
+	Pods     int
+	Age      string
+	Issue    string
+}
+
+// ReplicaSetIssues returns the models of the replica sets with an issue: orphaned replica sets
+// holding pods, old revisions of deployments holding pods after the rollout (the rollout scales
+// them down), or past its progress deadline, and scaled down revisions retained
+// beyond the revisionHistoryLimit of their deployment (the oldest first, as the deployment
+// controller deletes them). Models are sorted by namespace and name.
+func ReplicaSetIssues(replicaSets []*appsV1.ReplicaSet, deployments []*appsV1.Deployment) []ReplicaSetModel {
+	byUID := make(map[string]*appsV1.Deployment)
+	for _, dep := range deployments {
+		byUID[string(dep.UID)] = dep
+	}
+
+	var models []ReplicaSetModel
+	retained := make(map[string][]*appsV1.ReplicaSet) // scaled down old replica sets, by deployment UID
+	for _, rs := range replicaSets {
+		m := newReplicaSetModel(rs)
+		ref := metav1.GetControllerOf(rs)
+		switch {
+		case ref == nil:
+			if m.Pods > 0 {
+				m.Issue = ReplicaSetOrphaned
+				models = append(models, m)
+			}
+			continue
+		case ref.Kind != "Deployment":
+			continue
+		}
+		dep, ok := byUID[string(ref.UID)]
+		if !ok || rs.Annotations[deploymentRevisionAnnotation] == dep.Annotations[deploymentRevisionAnnotation] {
+			continue
+		}
+		switch {
+		case m.Pods > 0:
+			if deploymentRollingOut(dep) {
+				continue
+			}
+			m.Issue = ReplicaSetOldWithPods
+			models = append(models, m)
+		case m.Desired == 0:
+			retained[string(dep.UID)] = append(retained[string(dep.UID)], rs)
+		}
+	}
+
+	for uid, old := range retained {
+		limit := defaultRevisionHistoryLimit
+		if dep := byUID[uid]; dep.Spec.RevisionHistoryLimit != nil {
+			limit = int(*dep.Spec.RevisionHistoryLimit)
+		}
+		if len(old) <= limit {
+			continue
+		}
+		sort.Slice(old, func(i, j int) bool { return replicaSetRevision(old[i]) < replicaSetRevision(old[j]) })
+		for _, rs := range old[:len(old)-limit] {
+			m := newReplicaSetModel(rs)
+			m.Issue = ReplicaSetExcessRevision
+			models = append(models, m)
+		}
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models
+}
+
+// deploymentRollingOut returns true while dep rolls out a new revision within its progress
+// deadline: the old replica sets still hold pods until the new one is available
+func deploymentRollingOut(dep *appsV1.Deployment) bool {
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsV1.DeploymentProgressing {
+			return cond.Status == coreV1.ConditionTrue && cond.Reason != deploymentAvailableReason
+		}
+	}
+	return false
+}
+
+// newReplicaSetModel returns a model of rs, without issue
+func newReplicaSetModel(rs *appsV1.ReplicaSet) ReplicaSetModel {
+	m := ReplicaSetModel{
+		Namespace: rs.Namespace,
+		Name:      rs.Name,
+		Revision:  rs.Annotations[deploymentRevisionAnnotation],
+		Desired:   1,
+		Pods:      int(rs.Status.Replicas),
+		Age:       timeSince(rs.CreationTimestamp),
+	}
+	if rs.Spec.Replicas != nil {
+		m.Desired = int(*rs.Spec.Replicas)
+	}
+	if ref := metav1.GetControllerOf(rs); ref != nil {
+		m.Owner = ref.Kind + "/" + ref.Name
+	}
+	return m
+}
+
+// replicaSetRevision returns the deployment revision of rs, 0 when not set
+func replicaSetRevision(rs *appsV1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+	return revision
+}
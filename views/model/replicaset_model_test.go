@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReplicaSetIssues(t *testing.T) {
+	controller := true
+	deployment := func(revision string, progressing coreV1.ConditionStatus, reason string) *appsV1.Deployment {
+		dep := &appsV1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "web",
+			UID:         "dep-uid",
+			Annotations: map[string]string{deploymentRevisionAnnotation: revision},
+		}}
+		if progressing != "" {
+			dep.Status.Conditions = []appsV1.DeploymentCondition{{Type: appsV1.DeploymentProgressing, Status: progressing, Reason: reason}}
+		}
+		return dep
+	}
+	replicaSet := func(revision, pods int32, owner types.UID) *appsV1.ReplicaSet {
+		rs := &appsV1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        fmt.Sprintf("web-%d", revision),
+				Annotations: map[string]string{deploymentRevisionAnnotation: fmt.Sprint(revision)},
+			},
+			Spec:   appsV1.ReplicaSetSpec{Replicas: &pods},
+			Status: appsV1.ReplicaSetStatus{Replicas: pods},
+		}
+		if owner != "" {
+			rs.OwnerReferences = []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: owner, Controller: &controller}}
+		}
+		return rs
+	}
+	limit := func(dep *appsV1.Deployment, limit int32) *appsV1.Deployment {
+		dep.Spec.RevisionHistoryLimit = &limit
+		return dep
+	}
+
+	tests := []struct {
+		name        string
+		replicaSets []*appsV1.ReplicaSet
+		deployment  *appsV1.Deployment
+		expected    []string
+	}{
+		{
+			name:        "rolling out",
+			replicaSets: []*appsV1.ReplicaSet{replicaSet(1, 2, "dep-uid"), replicaSet(2, 1, "dep-uid")},
+			deployment:  deployment("2", coreV1.ConditionTrue, "ReplicaSetUpdated"),
+		},
+		{
+			name:        "rollout complete",
+			replicaSets: []*appsV1.ReplicaSet{replicaSet(1, 2, "dep-uid"), replicaSet(2, 3, "dep-uid")},
+			deployment:  deployment("2", coreV1.ConditionTrue, deploymentAvailableReason),
+			expected:    []string{"web-1: " + ReplicaSetOldWithPods},
+		},
+		{
+			name:        "progress deadline exceeded",
+			replicaSets: []*appsV1.ReplicaSet{replicaSet(1, 2, "dep-uid"), replicaSet(2, 1, "dep-uid")},
+			deployment:  deployment("2", coreV1.ConditionFalse, "ProgressDeadlineExceeded"),
+			expected:    []string{"web-1: " + ReplicaSetOldWithPods},
+		},
+		{
+			name:        "no progress condition",
+			replicaSets: []*appsV1.ReplicaSet{replicaSet(1, 2, "dep-uid"), replicaSet(2, 3, "dep-uid")},
+			deployment:  deployment("2", "", ""),
+			expected:    []string{"web-1: " + ReplicaSetOldWithPods},
+		},
+		{
+			name:        "orphaned",
+			replicaSets: []*appsV1.ReplicaSet{replicaSet(1, 2, ""), replicaSet(2, 0, "")},
+			deployment:  deployment("2", coreV1.ConditionTrue, deploymentAvailableReason),
+			expected:    []string{"web-1: " + ReplicaSetOrphaned},
+		},
+		{
+			name: "beyond revision history limit",
+			replicaSets: []*appsV1.ReplicaSet{
+				replicaSet(3, 0, "dep-uid"), replicaSet(1, 0, "dep-uid"), replicaSet(2, 0, "dep-uid"), replicaSet(4, 3, "dep-uid"),
+			},
+			deployment: limit(deployment("4", coreV1.ConditionTrue, deploymentAvailableReason), 1),
+			expected:   []string{"web-1: " + ReplicaSetExcessRevision, "web-2: " + ReplicaSetExcessRevision},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			var issues []string
+			for _, m := range ReplicaSetIssues(tc.replicaSets, []*appsV1.Deployment{tc.deployment}) {
+				issues = append(issues, m.Name+": "+m.Issue)
+			}
+			if strings.Join(issues, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("expecting issues %q, got %q", tc.expected, issues)
+			}
+		})
+	}
+}
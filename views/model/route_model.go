@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+const (
+	// RouteKindIngress designates an Ingress route
+	RouteKindIngress = "Ingress"
+	// RouteKindHTTPRoute designates a Gateway API HTTPRoute
+	RouteKindHTTPRoute = "HTTPRoute"
+)
+
+// RouteModel represents an Ingress, or a Gateway API HTTPRoute, with its backends
+type RouteModel struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Hosts     []string
+	TLS       string // TLS secrets of an Ingress, empty when not known (HTTPRoute)
+	Backends  []RouteBackend
+}
+
+// RouteBackend is a path of a route and the service it is routed to, along with the service health
+type RouteBackend struct {
+	Path              string
+	Service           string
+	Port              string
+	ServiceFound      bool
+	EndpointsReady    int
+	EndpointsNotReady int
+}
+
+// Healthy returns true when the backend service exists and has ready endpoints
+func (b RouteBackend) Healthy() bool {
+	return b.ServiceFound && b.EndpointsReady > 0
+}
+
+// NewIngressModel returns a model of the ingress; the backend health is not set
+func NewIngressModel(ing *networkingV1.Ingress) RouteModel {
+	m := RouteModel{Kind: RouteKindIngress, Namespace: ing.Namespace, Name: ing.Name}
+
+	hosts := make(map[string]bool)
+	if backend := ing.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		m.Backends = append(m.Backends, RouteBackend{Path: "(default)", Service: backend.Service.Name, Port: ingressPort(backend.Service.Port)})
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts[rule.Host] = true
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue // resource backend
+			}
+			m.Backends = append(m.Backends, RouteBackend{
+				Path:    rule.Host + routePath(path.Path),
+				Service: path.Backend.Service.Name,
+				Port:    ingressPort(path.Backend.Service.Port),
+			})
+		}
+	}
+	for host := range hosts {
+		m.Hosts = append(m.Hosts, host)
+	}
+	sort.Strings(m.Hosts)
+
+	var secrets []string
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" {
+			secrets = append(secrets, tls.SecretName)
+		}
+	}
+	if len(ing.Spec.TLS) > 0 && len(secrets) == 0 {
+		secrets = append(secrets, "(default)")
+	}
+	m.TLS = strings.Join(secrets, ",")
+	if m.TLS == "" {
+		m.TLS = "none"
+	}
+	return m
+}
+
+func ingressPort(port networkingV1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	if port.Number == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", port.Number)
+}
+
+// routePath returns path, or / when the path is empty
+func routePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// ServiceModel represents a service along with the readiness of its endpoints
+type ServiceModel struct {
+	Namespace         string
+	Name              string
+	Type              string
+	ClusterIP         string
+	Ports             string
+	EndpointsReady    int
+	EndpointsNotReady int
+}
+
+// NoReadyEndpoints returns true when the service has no ready endpoint to route traffic
+// to, i.e. requests fail with 503 errors. ExternalName services have no endpoints.
+func (m ServiceModel) NoReadyEndpoints() bool {
+	return m.Type != string(coreV1.ServiceTypeExternalName) && m.EndpointsReady == 0
+}
+
+// NewServiceModel returns a model of the service with the ready and not ready addresses of its
+// endpoints, which can be nil when the service has none.
+func NewServiceModel(svc *coreV1.Service, endpoints *coreV1.Endpoints) ServiceModel {
+	m := ServiceModel{
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+	}
+	var ports []string
+	for _, port := range svc.Spec.Ports {
+		ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+	}
+	m.Ports = strings.Join(ports, ",")
+
+	if endpoints != nil {
+		for _, subset := range endpoints.Subsets {
+			m.EndpointsReady += len(subset.Addresses)
+			m.EndpointsNotReady += len(subset.NotReadyAddresses)
+		}
+	}
+	return m
+}
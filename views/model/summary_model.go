@@ -1,17 +1,29 @@
 package model
 
 import (
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
 )
 
+// ClusterSummary aggregates resource counts and usage for the whole cluster (or namespace scope)
 type ClusterSummary struct {
 	Uptime                  metav1.Time // oldest running node
 	NodesReady              int
 	NodesCount              int
 	Namespaces              int
+	NamespacesRecent        int // namespaces created within NamespaceRecentAge
+	NamespacesTerminating   int
+	NamespacesStuck         int // namespaces Terminating for longer than NamespaceStuckAge
 	PodsRunning             int
 	PodsAvailable           int
+	PodsPending             int
+	PodsFailed              int
+	PodsUnschedulable       int // pending pods the scheduler could not place
+	PodsSucceeded           int
+	PodsUnknown             int
+	NodesByRole             map[string]int
 	Pressures               int
 	ImagesCount             int
 	VolumesAttached         int
@@ -27,6 +39,8 @@ type ClusterSummary struct {
 	ReplicaSetsDesired      int
 	AllocatableNodeCpuTotal *resource.Quantity
 	AllocatableNodeMemTotal *resource.Quantity
+	CapacityNodeCpuTotal    *resource.Quantity
+	CapacityNodeMemTotal    *resource.Quantity
 	RequestedPodCpuTotal    *resource.Quantity
 	RequestedPodMemTotal    *resource.Quantity
 	UsageNodeCpuTotal       *resource.Quantity
@@ -35,4 +49,74 @@ type ClusterSummary struct {
 	PVsTotal                *resource.Quantity
 	PVCCount                int
 	PVCsTotal               *resource.Quantity
+	ServicesCount           int
+	ServicesByType          map[string]int
+	EndpointsReady          int // ready endpoint addresses
+	EndpointsNotReady       int // not ready endpoint addresses
+	IngressesCount          int
+	Quota                   *QuotaUsage // most constrained resource quota, nil without quotas
+	KubeletVersions         map[string]int
+	SkewedNodes             int // nodes with a kubelet version outside the supported skew window
+}
+
+// KubeletSkewSupported returns true if kubeletVersion is within the version skew supported
+// against apiVersion: not newer, and at most 2 (3 from 1.28) minor versions older.
+// Versions that cannot be parsed are considered supported.
+func KubeletSkewSupported(apiVersion, kubeletVersion string) bool {
+	api, err := version.ParseGeneric(apiVersion)
+	if err != nil {
+		return true
+	}
+	kubelet, err := version.ParseGeneric(kubeletVersion)
+	if err != nil {
+		return true
+	}
+	if kubelet.Major() != api.Major() {
+		return false
+	}
+	window := uint(2)
+	if api.Minor() >= 28 {
+		window = 3
+	}
+	return kubelet.Minor() <= api.Minor() && api.Minor()-kubelet.Minor() <= window
+}
+
+// QuotaUsage is the usage of one resource of a resource quota
+type QuotaUsage struct {
+	Namespace string
+	Name      string
+	Resource  string
+	Used      resource.Quantity
+	Hard      resource.Quantity
+}
+
+// Ratio returns the used to hard ratio of the quota resource
+func (q *QuotaUsage) Ratio() float64 {
+	if q.Hard.IsZero() {
+		if q.Used.IsZero() {
+			return 0
+		}
+		return 1
+	}
+	return q.Used.AsApproximateFloat64() / q.Hard.AsApproximateFloat64()
+}
+
+// MostConstrainedQuota returns the quota resource with the highest used to hard ratio, or nil without quotas
+func MostConstrainedQuota(quotas []*coreV1.ResourceQuota) *QuotaUsage {
+	var result *QuotaUsage
+	for _, quota := range quotas {
+		for name, hard := range quota.Status.Hard {
+			usage := &QuotaUsage{
+				Namespace: quota.Namespace,
+				Name:      quota.Name,
+				Resource:  string(name),
+				Used:      quota.Status.Used[name],
+				Hard:      hard,
+			}
+			if result == nil || usage.Ratio() > result.Ratio() {
+				result = usage
+			}
+		}
+	}
+	return result
 }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMostConstrainedQuota(t *testing.T) {
+	quota := func(namespace, name string, hard, used map[string]string) *coreV1.ResourceQuota {
+		status := coreV1.ResourceQuotaStatus{Hard: coreV1.ResourceList{}, Used: coreV1.ResourceList{}}
+		for resourceName, qty := range hard {
+			status.Hard[coreV1.ResourceName(resourceName)] = resource.MustParse(qty)
+		}
+		for resourceName, qty := range used {
+			status.Used[coreV1.ResourceName(resourceName)] = resource.MustParse(qty)
+		}
+		return &coreV1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Status: status}
+	}
+
+	tests := []struct {
+		name     string
+		quotas   []*coreV1.ResourceQuota
+		expected string // namespace/name/resource of the most constrained quota resource
+		ratio    float64
+	}{
+		{name: "no quotas"},
+		{name: "quota without resources", quotas: []*coreV1.ResourceQuota{quota("default", "compute", nil, nil)}},
+		{
+			name:     "resources of a quota",
+			quotas:   []*coreV1.ResourceQuota{quota("default", "compute", map[string]string{"requests.cpu": "4", "requests.memory": "8Gi"}, map[string]string{"requests.cpu": "1", "requests.memory": "6Gi"})},
+			expected: "default/compute/requests.memory",
+			ratio:    0.75,
+		},
+		{
+			name: "quotas of namespaces",
+			quotas: []*coreV1.ResourceQuota{
+				quota("default", "compute", map[string]string{"pods": "10"}, map[string]string{"pods": "5"}),
+				quota("payments", "objects", map[string]string{"services": "10", "pods": "20"}, map[string]string{"services": "9", "pods": "2"}),
+			},
+			expected: "payments/objects/services",
+			ratio:    0.9,
+		},
+		{
+			name:     "unused resource",
+			quotas:   []*coreV1.ResourceQuota{quota("default", "compute", map[string]string{"pods": "10", "secrets": "10"}, map[string]string{"pods": "1"})},
+			expected: "default/compute/pods",
+			ratio:    0.1,
+		},
+		{
+			name:     "used zero hard resource",
+			quotas:   []*coreV1.ResourceQuota{quota("default", "compute", map[string]string{"pods": "10", "services.loadbalancers": "0"}, map[string]string{"pods": "9", "services.loadbalancers": "1"})},
+			expected: "default/compute/services.loadbalancers",
+			ratio:    1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			usage := MostConstrainedQuota(tc.quotas)
+			if tc.expected == "" {
+				if usage != nil {
+					t.Errorf("expecting no quota usage, got %s/%s/%s", usage.Namespace, usage.Name, usage.Resource)
+				}
+				return
+			}
+			if usage == nil {
+				t.Fatalf("expecting quota usage %s, got none", tc.expected)
+			}
+			if actual := usage.Namespace + "/" + usage.Name + "/" + usage.Resource; actual != tc.expected {
+				t.Errorf("expecting quota usage %s, got %s", tc.expected, actual)
+			}
+			if usage.Ratio() != tc.ratio {
+				t.Errorf("expecting ratio %g, got %g", tc.ratio, usage.Ratio())
+			}
+		})
+	}
+}
+
+func TestKubeletSkewSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		api      string
+		kubelet  string
+		expected bool
+	}{
+		{name: "same version", api: "v1.27.3", kubelet: "v1.27.3", expected: true},
+		{name: "older patch", api: "v1.27.3", kubelet: "v1.27.1", expected: true},
+		{name: "two minor versions older", api: "v1.27.3", kubelet: "v1.25.9", expected: true},
+		{name: "three minor versions older before 1.28", api: "v1.27.3", kubelet: "v1.24.9", expected: false},
+		{name: "three minor versions older from 1.28", api: "v1.28.0", kubelet: "v1.25.9", expected: true},
+		{name: "four minor versions older", api: "v1.29.0", kubelet: "v1.25.9", expected: false},
+		{name: "newer kubelet", api: "v1.27.3", kubelet: "v1.28.0", expected: false},
+		{name: "other major version", api: "v1.27.3", kubelet: "v2.27.3", expected: false},
+		{name: "distribution suffix", api: "v1.28.2-eks-a5df82a", kubelet: "v1.26.7-eks-8ccc7ba", expected: true},
+		{name: "unparsable kubelet version", api: "v1.27.3", kubelet: "unknown", expected: true},
+		{name: "unparsable API version", api: "", kubelet: "v1.20.0", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if actual := KubeletSkewSupported(tc.api, tc.kubelet); actual != tc.expected {
+				t.Errorf("expecting kubelet %s supported against %s %t, got %t", tc.kubelet, tc.api, tc.expected, actual)
+			}
+		})
+	}
+}
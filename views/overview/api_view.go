@@ -0,0 +1,94 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// showAPIResources displays the resources served by the API server, with their verbs, from
+// discovery. Typing in the filter field narrows down the list by group, version, name, kind,
+// or short name; Enter (or Down) moves to the list and '/' back to the filter.
+func (p *MainPanel) showAPIResources() {
+	client := p.app.GetK8sClient()
+	go func() {
+		resources, err := client.GetAPIResources()
+		p.app.QueueUpdateDraw(func() {
+			if err != nil && len(resources) == 0 {
+				p.app.ShowMessage(fmt.Sprintf("Failed to discover API resources: %s", err))
+				return
+			}
+			p.app.ShowNamedModal("API resources", newAPIResourcesView(p.app.Focus, resources, err))
+		})
+	}()
+}
+
+func newAPIResourcesView(focus func(tview.Primitive), resources []model.APIResourceModel, discoveryErr error) tview.Primitive {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+
+	filter := tview.NewInputField().SetLabel("Filter: ").SetFieldWidth(0)
+
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(filter, 1, 0, true).
+		AddItem(table, 0, 1, false)
+	root.SetBorder(true)
+	root.SetTitleAlign(tview.AlignLeft)
+
+	draw := func(text string) {
+		table.Clear()
+		for i, col := range []string{"GROUP/VERSION", "RESOURCE", "KIND", "SHORTNAMES", "NAMESPACED", "VERBS"} {
+			table.SetCell(0, i,
+				tview.NewTableCell(col).
+					SetTextColor(tcell.ColorWhite).
+					SetBackgroundColor(tcell.ColorDarkGreen).
+					SetAlign(tview.AlignLeft).
+					SetExpansion(100).
+					SetSelectable(false),
+			)
+		}
+		row := 1
+		for _, res := range resources {
+			if !res.Matches(text) {
+				continue
+			}
+			values := []string{
+				res.GroupVersion(),
+				res.Name,
+				res.Kind,
+				valueOrDash(strings.Join(res.ShortNames, ",")),
+				fmt.Sprintf("%t", res.Namespaced),
+				strings.Join(res.Verbs, ","),
+			}
+			for j, val := range values {
+				table.SetCell(row, j, tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100))
+			}
+			row++
+		}
+		title := fmt.Sprintf(" API resources (%d of %d) (Enter: list, /: filter, Esc: close) ", row-1, len(resources))
+		if discoveryErr != nil {
+			title = fmt.Sprintf(" API resources (%d of %d, [red]some groups failed discovery[white]) (Enter: list, /: filter, Esc: close) ", row-1, len(resources))
+		}
+		root.SetTitle(title)
+		table.ScrollToBeginning()
+	}
+	draw("")
+
+	filter.SetChangedFunc(draw)
+	filter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter || key == tcell.KeyDown {
+			focus(table)
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' {
+			focus(filter)
+			return nil
+		}
+		return event
+	})
+	return root
+}
@@ -0,0 +1,127 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// showAutoscaling displays the node autoscaling activity: the Cluster Autoscaler status,
+// Karpenter NodeClaims, unneeded nodes, pending pods along with the last autoscaler
+// decision about them, and the recent scale-ups, scale-downs, and failures. The status
+// and NodeClaims are read from the API server, in the background, when the view is opened.
+func (p *MainPanel) showAutoscaling() {
+	ctrl := p.app.GetK8sClient().Controller()
+	go func() {
+		autoscaling, err := ctrl.GetAutoscalingModel(p.ctx)
+		p.app.QueueUpdateDraw(func() {
+			if err != nil {
+				p.app.ShowMessage(fmt.Sprintf("Failed to get the autoscaling status: %s", err))
+				return
+			}
+			p.app.ShowNamedModal("autoscaling", newAutoscalingTable(autoscaling))
+		})
+	}()
+}
+
+func newAutoscalingTable(autoscaling model.AutoscalingModel) *tview.Table {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"TYPE", "OBJECT", "STATUS", "AGE", "DETAILS"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	row := 1
+	addRow := func(color tcell.Color, values ...string) {
+		for i, val := range values {
+			table.SetCell(row, i, tview.NewTableCell(tview.Escape(val)).SetTextColor(color).SetExpansion(100))
+		}
+		row++
+	}
+
+	for _, status := range autoscaling.Status {
+		color := tcell.ColorYellow
+		if !strings.HasPrefix(status.Status, "Healthy") && status.Name == "Health" {
+			color = tcell.ColorRed
+		}
+		addRow(color, "status", model.AutoscalerClusterAutoscaler, status.Name, "-", status.Status)
+	}
+	for _, claim := range autoscaling.NodeClaims {
+		color := tcell.ColorYellow
+		switch {
+		case claim.Failed:
+			color = tcell.ColorRed
+		case claim.Status != "Ready":
+			color = tcell.ColorOrange
+		}
+		details := strings.Join(nonEmpty(claim.NodePool, claim.InstanceType, claim.Node, claim.Message), ", ")
+		addRow(color, "nodeclaim", claim.Name, claim.Status, age(claim.CreationTime), valueOrDash(details))
+	}
+	for _, node := range autoscaling.UnneededNodes {
+		addRow(tcell.ColorOrange, "unneeded", "node/"+node, "ScaleDownCandidate", "-", "-")
+	}
+
+	failures, scaleUps := 0, 0
+	for _, pod := range autoscaling.PendingPods {
+		status, details, color := "NoActivity", "-", tcell.ColorOrange
+		if pod.Activity != nil {
+			status, details = pod.Activity.Reason, pod.Activity.Message
+			if pod.Activity.Kind == model.ActivityFailure {
+				color = tcell.ColorRed
+			}
+		}
+		addRow(color, "pending", fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name), status, age(pod.CreationTime), details)
+	}
+	for _, activity := range autoscaling.Activities {
+		color := tcell.ColorYellow
+		switch activity.Kind {
+		case model.ActivityFailure:
+			color = tcell.ColorRed
+			failures++
+		case model.ActivityScaleUp:
+			scaleUps++
+		}
+		addRow(color, activity.Kind, activity.Object, activity.Reason, age(activity.Time), activity.Message)
+	}
+
+	autoscalers := strings.Join(autoscaling.Autoscalers, ", ")
+	if autoscalers == "" {
+		autoscalers = "no autoscaler found"
+	}
+	table.SetTitle(fmt.Sprintf(" Autoscaling: %s (%d pending pods, %d scale-ups, %d failures) (Esc: close) ",
+		autoscalers, len(autoscaling.PendingPods), scaleUps, failures))
+	return table
+}
+
+// age returns the time elapsed since t, or "-" for a zero time
+func age(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
+// nonEmpty returns the non empty values
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, val := range values {
+		if val != "" {
+			result = append(result, val)
+		}
+	}
+	return result
+}
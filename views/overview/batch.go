@@ -0,0 +1,165 @@
+package overview
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/detail"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// showBatchActions displays the actions that can be applied to the marked pods
+func (p *MainPanel) showBatchActions() {
+	pods := p.podPanel.markedPods()
+	if len(pods) == 0 {
+		p.app.ShowMessage("No pods marked (use Space to mark pods)")
+		return
+	}
+
+	items := []string{"Delete", "Export YAML", "Label", "Clear marks"}
+	p.app.ShowMenu(fmt.Sprintf("%d marked pod(s)", len(pods)), items, func(index int) {
+		switch index {
+		case 0:
+			p.deletePods(pods)
+		case 1:
+			p.exportPods(pods)
+		case 2:
+			p.labelPods(pods)
+		case 3:
+			p.podPanel.clearMarks()
+		}
+	})
+}
+
+// deletePods deletes, after confirmation, the specified pods
+func (p *MainPanel) deletePods(pods []model.PodModel) {
+	if !p.writable() {
+		return
+	}
+	msg := fmt.Sprintf("Delete %d marked pod(s)?\n\n%s", len(pods), podListPreview(pods, 5))
+	p.app.ShowConfirm(msg, "Delete", func() {
+		ctrl := p.app.GetK8sClient().Controller()
+		p.applyToPods(pods, "Deleted", func(pod model.PodModel) error {
+			return ctrl.DeletePod(p.ctx, pod.Namespace, pod.Name)
+		})
+	})
+}
+
+// labelPods prompts for labels (key=value to set, key- to remove) then applies them to the pods
+func (p *MainPanel) labelPods(pods []model.PodModel) {
+	if !p.writable() {
+		return
+	}
+	p.app.ShowInput(fmt.Sprintf("Label %d pod(s)", len(pods)), "key=value key-: ", "", func(text string) {
+		labels, err := parseLabelArgs(text)
+		if err != nil {
+			p.app.ShowMessage(err.Error())
+			return
+		}
+		ctrl := p.app.GetK8sClient().Controller()
+		p.applyToPods(pods, "Labeled", func(pod model.PodModel) error {
+			return ctrl.LabelPod(p.ctx, pod.Namespace, pod.Name, labels)
+		})
+	})
+}
+
+// exportPods writes the manifests of the pods, as a multi-document YAML file, to the working directory
+func (p *MainPanel) exportPods(pods []model.PodModel) {
+	ctrl := p.app.GetK8sClient().Controller()
+	var buf bytes.Buffer
+	for _, pod := range pods {
+		obj, err := ctrl.GetPod(p.ctx, pod.Namespace, pod.Name)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+			return
+		}
+		data, err := detail.ToYAML(obj)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+			return
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	fileName := fmt.Sprintf("ktop-pods-%s.yaml", time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(fileName, buf.Bytes(), 0644); err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+		return
+	}
+	p.app.Notify(fmt.Sprintf("%d pod(s) exported to %s", len(pods), fileName))
+}
+
+// applyToPods runs action for each pod in the background then reports the result
+func (p *MainPanel) applyToPods(pods []model.PodModel, verb string, action func(model.PodModel) error) {
+	go func() {
+		var done int
+		var errs []string
+		for _, pod := range pods {
+			if err := action(pod); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", pod.Name, err))
+				continue
+			}
+			done++
+		}
+		result := fmt.Sprintf("%s %d of %d pod(s)", verb, done, len(pods))
+		if len(errs) > 0 {
+			result = fmt.Sprintf("%s\n\n%s", result, strings.Join(errs, "\n"))
+		}
+		p.app.QueueUpdateDraw(func() {
+			p.app.ShowMessage(result)
+		})
+	}()
+}
+
+// writable returns true when actions that modify the cluster are allowed,
+// otherwise it tells the user that ktop runs in read-only mode.
+func (p *MainPanel) writable() bool {
+	if p.app.ReadOnly() {
+		p.app.ShowMessage("Action disabled: ktop is running with --read-only")
+		return false
+	}
+	return true
+}
+
+// podListPreview returns the namespace/name of the first max pods, one per line
+func podListPreview(pods []model.PodModel, max int) string {
+	var lines []string
+	for i, pod := range pods {
+		if i == max {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(pods)-max))
+			break
+		}
+		lines = append(lines, podKey(pod.Namespace, pod.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseLabelArgs parses space separated, kubectl style, label arguments:
+// key=value sets a label and key- removes it (returned with a nil value).
+func parseLabelArgs(text string) (map[string]*string, error) {
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no labels specified")
+	}
+	labels := make(map[string]*string, len(args))
+	for _, arg := range args {
+		switch {
+		case strings.HasSuffix(arg, "-") && !strings.Contains(arg, "="):
+			labels[strings.TrimSuffix(arg, "-")] = nil
+		case strings.Contains(arg, "="):
+			parts := strings.SplitN(arg, "=", 2)
+			if parts[0] == "" {
+				return nil, fmt.Errorf("invalid label %q: missing key", arg)
+			}
+			value := parts[1]
+			labels[parts[0]] = &value
+		default:
+			return nil, fmt.Errorf("invalid label %q: expecting key=value or key-", arg)
+		}
+	}
+	return labels, nil
+}
@@ -0,0 +1,51 @@
+package overview
+
+import (
+	"testing"
+)
+
+func TestParseLabelArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		set       map[string]string
+		removed   []string
+		shouldErr bool
+	}{
+		{name: "empty", text: "  ", shouldErr: true},
+		{name: "set", text: "app=web tier=", set: map[string]string{"app": "web", "tier": ""}},
+		{name: "remove", text: "app-", removed: []string{"app"}},
+		{name: "set and remove", text: "app=web env-", set: map[string]string{"app": "web"}, removed: []string{"env"}},
+		{name: "missing key", text: "=web", shouldErr: true},
+		{name: "bad arg", text: "app", shouldErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			labels, err := parseLabelArgs(tc.text)
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatal("expecting error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(labels) != len(tc.set)+len(tc.removed) {
+				t.Fatalf("expecting %d labels, got %d", len(tc.set)+len(tc.removed), len(labels))
+			}
+			for key, val := range tc.set {
+				if labels[key] == nil || *labels[key] != val {
+					t.Errorf("expecting label %s=%s, got %v", key, val, labels[key])
+				}
+			}
+			for _, key := range tc.removed {
+				if val, ok := labels[key]; !ok || val != nil {
+					t.Errorf("expecting label %s to be removed", key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/ui"
+)
+
+// showColumnChooser displays the available node and pod columns with check boxes;
+// selecting a column shows, or hides, it immediately. Keys '<' and '>' move the
+// selected column and key 's' saves the column order to the state file, which is
+// otherwise saved when ktop exits.
+func (p *MainPanel) showColumnChooser() {
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true)
+	list.SetTitle(" Columns (Enter: show/hide, <,>: move, s: save, Esc: close) ")
+	list.SetTitleAlign(tview.AlignLeft)
+
+	type columnSet struct {
+		kind  string
+		all   *[]string
+		order *[]string // the order of all the columns saved to the state
+		shown func() []string
+		apply func([]string)
+	}
+	state := p.app.State()
+	sets := []columnSet{
+		{kind: "Nodes", all: &p.allNodeColumns, order: &state.NodeColumnOrder, shown: func() []string { return p.nodePanel.listCols }, apply: p.setNodeColumns},
+		{kind: "Pods", all: &p.allPodColumns, order: &state.PodColumnOrder, shown: func() []string { return p.podPanel.listCols }, apply: p.setPodColumns},
+	}
+
+	// item returns the column set and column index of list item index
+	item := func(index int) (columnSet, int) {
+		for _, set := range sets {
+			if index < len(*set.all) {
+				return set, index
+			}
+			index -= len(*set.all)
+		}
+		return columnSet{}, -1
+	}
+
+	var fill func()
+	fill = func() {
+		current := list.GetCurrentItem()
+		list.Clear()
+		for _, set := range sets {
+			set := set
+			for _, col := range *set.all {
+				col := col
+				list.AddItem(columnItemText(set.kind, col, containsColumn(set.shown(), col)), "", 0, func() {
+					cols := toggleColumn(*set.all, set.shown(), col)
+					if len(cols) == 0 {
+						return // keep at least one column
+					}
+					set.apply(cols)
+					fill()
+				})
+			}
+		}
+		list.SetCurrentItem(current)
+	}
+	fill()
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case '<', '>':
+			set, idx := item(list.GetCurrentItem())
+			if idx < 0 {
+				return nil
+			}
+			to := idx - 1
+			if event.Rune() == '>' {
+				to = idx + 1
+			}
+			if to < 0 || to >= len(*set.all) {
+				return nil
+			}
+			all := *set.all
+			all[idx], all[to] = all[to], all[idx]
+			*set.order = append([]string{}, all...)
+			set.apply(orderColumns(set.shown(), all))
+			list.SetCurrentItem(list.GetCurrentItem() + to - idx)
+			fill()
+			return nil
+		case 's':
+			p.saveColumnLayout()
+			return nil
+		}
+		return event
+	})
+
+	p.app.ShowNamedModal("columns", list, ui.KeyHint{Key: "Enter", Name: "show/hide"}, ui.KeyHint{Key: "<,>", Name: "move"}, ui.KeyHint{Key: "s", Name: "save"})
+}
+
+// saveColumnLayout saves the displayed columns, and the order of all the columns, to the
+// state file; the configuration file, written by hand, is left unchanged
+func (p *MainPanel) saveColumnLayout() {
+	state := p.app.State()
+	state.NodeColumnOrder = append([]string{}, p.allNodeColumns...)
+	state.PodColumnOrder = append([]string{}, p.allPodColumns...)
+	if err := state.Save(); err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to save column layout: %s", err))
+		return
+	}
+	p.app.Notify(fmt.Sprintf("Column layout saved to %s", state.Path()))
+}
+
+// setNodeColumns displays the specified node columns
+func (p *MainPanel) setNodeColumns(cols []string) {
+	p.nodePanel.DrawHeader(cols)
+	p.app.State().NodeColumns = cols
+	p.drawNodes()
+}
+
+// setPodColumns displays the specified pod columns
+func (p *MainPanel) setPodColumns(cols []string) {
+	p.podPanel.DrawHeader(cols)
+	p.app.State().PodColumns = cols
+	p.drawPods()
+}
+
+func columnItemText(kind, col string, shown bool) string {
+	check := " "
+	if shown {
+		check = "x"
+	}
+	return fmt.Sprintf("[%s[] %-6s %s", check, kind, col)
+}
+
+// toggleColumn shows, or hides, col and returns the shown columns in the order of all
+func toggleColumn(all, shown []string, col string) []string {
+	show := !containsColumn(shown, col)
+	var result []string
+	for _, c := range all {
+		if c == col {
+			if show {
+				result = append(result, c)
+			}
+			continue
+		}
+		if containsColumn(shown, c) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func containsColumn(cols []string, col string) bool {
+	for _, c := range cols {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/config"
+)
+
+// optionalNodeColumns and optionalPodColumns are the columns hidden by default,
+// shown from the column chooser or the --node-columns and --pod-columns flags
+var (
+	optionalNodeColumns = []string{"INTERNAL-IP", "EXTERNAL-IP", "HUGEPAGES-2Mi", "HUGEPAGES-1Gi"}
+	optionalPodColumns  = []string{"LAST RESTART", "SCHEDULER", "IMAGE", "SECURITY", "HUGEPAGES-2Mi", "HUGEPAGES-1Gi", "HOST NET", "EVICTION"}
+)
+
+// withoutColumns returns the columns of cols not in excluded
+func withoutColumns(cols, excluded []string) []string {
+	var result []string
+	for _, col := range cols {
+		if !containsColumn(excluded, col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+// orderColumns returns the columns of all with the columns listed in order
+// first, in the listed order, followed by the unlisted columns.
+func orderColumns(all []string, order []string) []string {
+	result := make([]string, 0, len(all))
+	for _, name := range order {
+		for _, col := range all {
+			if strings.EqualFold(col, name) && !containsColumn(result, col) {
+				result = append(result, col)
+				break
+			}
+		}
+	}
+	for _, col := range all {
+		if !containsColumn(result, col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+// layoutNames returns the column names of layouts
+func layoutNames(layouts []config.ColumnLayout) []string {
+	names := make([]string, 0, len(layouts))
+	for _, layout := range layouts {
+		names = append(names, layout.Name)
+	}
+	return names
+}
+
+// columnWidths returns the column layouts that set a width, keyed by upper-cased column name
+func columnWidths(layouts []config.ColumnLayout) map[string]config.ColumnLayout {
+	widths := make(map[string]config.ColumnLayout)
+	for _, layout := range layouts {
+		if layout.MinWidth > 0 || layout.MaxWidth > 0 {
+			widths[strings.ToUpper(layout.Name)] = layout
+		}
+	}
+	return widths
+}
+
+// applyColumnWidths sets the min and max widths of the table columns mapped in colMap.
+// The minimum width pads the header cell; the maximum width truncates every cell
+// and stops the column from expanding.
+func applyColumnWidths(table *tview.Table, colMap map[string]int, widths map[string]config.ColumnLayout) {
+	for col, colIdx := range colMap {
+		width, ok := widths[strings.ToUpper(col)]
+		if !ok {
+			continue
+		}
+		if header := table.GetCell(0, colIdx); header != nil {
+			if width.MinWidth > 0 {
+				header.SetText(fmt.Sprintf("%-*s", width.MinWidth, header.Text))
+			}
+			if width.MaxWidth > 0 {
+				header.SetExpansion(0)
+			}
+		}
+		if width.MaxWidth == 0 {
+			continue
+		}
+		for row := 0; row < table.GetRowCount(); row++ {
+			if cell := table.GetCell(row, colIdx); cell != nil {
+				cell.SetMaxWidth(width.MaxWidth)
+			}
+		}
+	}
+}
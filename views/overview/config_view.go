@@ -0,0 +1,91 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// showConfigInventory displays, per namespace, the count, total size, and age of the
+// ConfigMaps and Secrets, flagging the large objects. The objects are listed from the
+// API server, in the background, when the view is opened.
+func (p *MainPanel) showConfigInventory() {
+	ctrl := p.app.GetK8sClient().Controller()
+	go func() {
+		inventory, err := ctrl.GetConfigInventory(p.ctx)
+		p.app.QueueUpdateDraw(func() {
+			if err != nil {
+				p.app.ShowMessage(fmt.Sprintf("Failed to list ConfigMaps and Secrets: %s", err))
+				return
+			}
+			p.app.ShowNamedModal("config inventory", newConfigInventoryTable(inventory))
+		})
+	}()
+}
+
+func newConfigInventoryTable(inventory []model.ConfigInventoryModel) *tview.Table {
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "CONFIGMAPS", "SECRETS", "SIZE", "OLDEST", "NEWEST", "LARGE OBJECTS"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	var configMaps, secrets, large int
+	var size int64
+	for i, ns := range inventory {
+		configMaps += ns.ConfigMaps
+		secrets += ns.Secrets
+		size += ns.Size
+		large += len(ns.Large)
+
+		largeText, largeColor := "-", tcell.ColorYellow
+		if len(ns.Large) > 0 {
+			largeText = fmt.Sprintf("%c %s", ui.Icons.Warning, strings.Join(ns.Large, ","))
+			largeColor = tcell.ColorRed
+		}
+		values := []string{
+			ns.Namespace,
+			fmt.Sprintf("%d", ns.ConfigMaps),
+			fmt.Sprintf("%d", ns.Secrets),
+			formatBytes(ns.Size),
+			ns.TimeSinceOldest(),
+			ns.TimeSinceNewest(),
+			largeText,
+		}
+		for j, val := range values {
+			cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+			if j == 6 {
+				cell.SetTextColor(largeColor)
+			}
+			table.SetCell(i+1, j, cell)
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" ConfigMaps (%d) and Secrets (%d): %s, %d larger than %s (Esc: close) ",
+		configMaps, secrets, formatBytes(size), large, formatBytes(model.LargeConfigObjectSize)))
+	return table
+}
+
+// formatBytes returns size in bytes, KiB, or MiB
+func formatBytes(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1fMiB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1fKiB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%dB", size)
+	}
+}
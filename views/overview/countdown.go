@@ -0,0 +1,54 @@
+package overview
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// countdownInterval is the interval between two updates of the refresh countdowns
+const countdownInterval = time.Second
+
+// refreshCountdown tracks the last refresh of a panel to show the time left until the
+// next refresh, so that a panel waiting on slow data is told from an idle one.
+type refreshCountdown struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newRefreshCountdown(interval time.Duration) *refreshCountdown {
+	return &refreshCountdown{interval: interval}
+}
+
+// mark records a refresh at now
+func (c *refreshCountdown) mark(now time.Time) {
+	c.mu.Lock()
+	c.last = now
+	c.mu.Unlock()
+}
+
+// info returns the refresh interval and the time left, at now, until the next refresh
+// (i.e. "every 3s, next: 2s"), or how late the refresh is once overdue; it returns
+// an empty string before the first refresh.
+func (c *refreshCountdown) info(now time.Time) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last.IsZero() {
+		return ""
+	}
+	left := c.last.Add(c.interval).Sub(now)
+	if left < -countdownInterval {
+		return fmt.Sprintf("every %s, [orange]late: %s[-]", c.interval, roundSeconds(-left))
+	}
+	if left < 0 {
+		left = 0
+	}
+	return fmt.Sprintf("every %s, next: %s", c.interval, roundSeconds(left))
+}
+
+// roundSeconds rounds d up to the second
+func roundSeconds(d time.Duration) time.Duration {
+	return time.Duration(math.Ceil(d.Seconds())) * time.Second
+}
@@ -0,0 +1,36 @@
+package overview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshCountdown(t *testing.T) {
+	last := time.Now()
+
+	tests := []struct {
+		name     string
+		last     time.Time
+		now      time.Time
+		expected string
+	}{
+		{name: "not refreshed", now: last, expected: ""},
+		{name: "just refreshed", last: last, now: last, expected: "every 3s, next: 3s"},
+		{name: "rounded up", last: last, now: last.Add(1500 * time.Millisecond), expected: "every 3s, next: 2s"},
+		{name: "due", last: last, now: last.Add(3500 * time.Millisecond), expected: "every 3s, next: 0s"},
+		{name: "late", last: last, now: last.Add(6 * time.Second), expected: "every 3s, [orange]late: 3s[-]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			countdown := newRefreshCountdown(3 * time.Second)
+			if !tc.last.IsZero() {
+				countdown.mark(tc.last)
+			}
+			if info := countdown.info(tc.now); info != tc.expected {
+				t.Fatalf("expecting info %q, got %q", tc.expected, info)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package overview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// showCronJobs displays the cronjobs with their schedule, last successful and next runs,
+// and active and failed jobs, flagging the cronjobs that missed a scheduled run.
+func (p *MainPanel) showCronJobs() {
+	cronJobs, err := p.app.GetK8sClient().Controller().GetCronJobModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list cronjobs: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "SCHEDULE", "LAST SCHEDULE", "LAST SUCCESS", "NEXT RUN", "ACTIVE", "FAILED", "MISSED"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	now := time.Now()
+	missed := 0
+	for i, cronJob := range cronJobs {
+		schedule, next, missedRun := cronJob.Description, relativeTime(cronJob.NextRun, now), "-"
+		switch {
+		case cronJob.ScheduleError != "":
+			schedule = fmt.Sprintf("%s (invalid)", cronJob.Schedule)
+		case cronJob.Suspended:
+			next = "suspended"
+		}
+		if cronJob.MissedRun {
+			missedRun = "yes"
+			missed++
+		}
+		values := []string{
+			cronJob.Namespace,
+			cronJob.Name,
+			schedule,
+			relativeTime(cronJob.LastSchedule, now),
+			relativeTime(cronJob.LastSuccess, now),
+			next,
+			fmt.Sprintf("%d", cronJob.ActiveJobs),
+			fmt.Sprintf("%d", cronJob.FailedJobs),
+			missedRun,
+		}
+		color := cronJobColor(cronJob)
+		for j, val := range values {
+			table.SetCell(i+1, j, tview.NewTableCell(val).SetTextColor(color).SetExpansion(100))
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" CronJobs (%d, %d missed runs) (Esc: close) ", len(cronJobs), missed))
+	p.app.ShowNamedModal("cron jobs", table)
+}
+
+// relativeTime returns t relative to now, i.e. "5m ago" or "in 2h", "-" when zero
+func relativeTime(t, now time.Time) string {
+	switch {
+	case t.IsZero():
+		return "-"
+	case t.After(now):
+		return "in " + duration.HumanDuration(t.Sub(now))
+	}
+	return duration.HumanDuration(now.Sub(t)) + " ago"
+}
+
+// cronJobColor returns the row color of cronJob: red when it missed a run or has an invalid
+// schedule, orange with failed jobs, gray when suspended, yellow otherwise
+func cronJobColor(cronJob model.CronJobModel) tcell.Color {
+	switch {
+	case cronJob.MissedRun || cronJob.ScheduleError != "":
+		return tcell.ColorRed
+	case cronJob.FailedJobs > 0:
+		return tcell.ColorOrange
+	case cronJob.Suspended:
+		return tcell.ColorGray
+	}
+	return tcell.ColorYellow
+}
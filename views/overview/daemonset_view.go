@@ -0,0 +1,61 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showDaemonSets displays the daemon sets with their desired, current, and ready pods,
+// and the nodes their pod is missing, or not ready, on.
+func (p *MainPanel) showDaemonSets() {
+	daemonSets, err := p.app.GetK8sClient().Controller().GetDaemonSetModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list daemon sets: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "DESIRED", "CURRENT", "READY", "MISSING ON", "NOT READY ON"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	uncovered := 0
+	for i, ds := range daemonSets {
+		color := tcell.ColorYellow
+		if !ds.Covered() {
+			color = tcell.ColorRed
+			uncovered++
+		}
+		values := []string{
+			ds.Namespace,
+			ds.Name,
+			fmt.Sprintf("%d", ds.Desired),
+			fmt.Sprintf("%d", ds.Current),
+			fmt.Sprintf("%d", ds.Ready),
+			valueOrDash(strings.Join(ds.MissingNodes, ",")),
+			valueOrDash(strings.Join(ds.NotReadyNodes, ",")),
+		}
+		for j, val := range values {
+			cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+			if j >= 4 {
+				cell.SetTextColor(color)
+			}
+			table.SetCell(i+1, j, cell)
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Daemon sets (%d, %d not fully covered) (Esc: close) ", len(daemonSets), uncovered))
+	p.app.ShowNamedModal("daemon sets", table)
+}
@@ -0,0 +1,52 @@
+package overview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// showDiagnostics displays, per resource, the API calls that recently failed (i.e.
+// "pods/metrics: forbidden"), which explain panels or columns missing data.
+func (p *MainPanel) showDiagnostics() {
+	apiErrors := p.app.GetK8sClient().Controller().GetAPIErrors()
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	table.SetTitle(fmt.Sprintf(" Diagnostics: %d resources with failed API calls (Esc: close) ", len(apiErrors)))
+	for i, col := range []string{"RESOURCE", "REASON", "COUNT", "LAST SEEN", "MESSAGE"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+	if len(apiErrors) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No failed API calls").SetTextColor(tcell.ColorGreen))
+	}
+	for i, apiErr := range apiErrors {
+		values := []string{
+			apiErr.Resource,
+			apiErr.Reason,
+			fmt.Sprintf("%d", apiErr.Count),
+			duration.HumanDuration(time.Since(apiErr.LastSeen)) + " ago",
+			apiErr.Message,
+		}
+		for j, val := range values {
+			cell := tview.NewTableCell(tview.Escape(val)).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+			if j == 1 {
+				cell.SetTextColor(tcell.ColorRed)
+			}
+			table.SetCell(i+1, j, cell)
+		}
+	}
+	p.app.ShowNamedModal("diagnostics", table)
+}
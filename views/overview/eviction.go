@@ -0,0 +1,65 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// Eviction risks of the EVICTION column, in increasing order
+const (
+	evictionRiskNone = iota
+	evictionRiskLow
+	evictionRiskMedium
+	evictionRiskHigh
+)
+
+// podEvictionRisk returns the eviction risk of pod, and its reasons, from the QoS class of the pod,
+// its memory usage over requests, and the pressure conditions of its node: the kubelet evicts the
+// BestEffort pods, then the pods using more than their requests, first when its node is under pressure.
+func podEvictionRisk(pod model.PodModel) (int, []string) {
+	if pod.QOSClass == string(coreV1.PodQOSGuaranteed) || pod.QOSClass == "" {
+		return evictionRiskNone, nil
+	}
+
+	var reasons []string
+	overRequest := pod.PodUsageMemQty != nil && pod.PodRequestedMemQty != nil &&
+		pod.PodUsageMemQty.Cmp(*pod.PodRequestedMemQty) > 0
+	switch {
+	case pod.QOSClass == string(coreV1.PodQOSBestEffort):
+		reasons = append(reasons, "BestEffort")
+	case overRequest:
+		reasons = append(reasons, "over request")
+	}
+	for _, pressure := range pod.NodePressures {
+		reasons = append(reasons, pressure+" pressure")
+	}
+
+	switch underPressure := len(pod.NodePressures) > 0; {
+	case underPressure && (pod.QOSClass == string(coreV1.PodQOSBestEffort) || overRequest):
+		return evictionRiskHigh, reasons
+	case underPressure:
+		return evictionRiskMedium, append([]string{"Burstable"}, reasons...)
+	case len(reasons) > 0:
+		return evictionRiskLow, reasons
+	}
+	return evictionRiskNone, nil
+}
+
+// podEviction returns the EVICTION column text and color: the eviction risk badge of the pod
+// with its reasons, i.e. "high: BestEffort, mem pressure", or "-" without risk
+func podEviction(pod model.PodModel) (string, tcell.Color) {
+	risk, reasons := podEvictionRisk(pod)
+	switch risk {
+	case evictionRiskHigh:
+		return fmt.Sprintf("high: %s", strings.Join(reasons, ", ")), tcell.ColorRed
+	case evictionRiskMedium:
+		return fmt.Sprintf("medium: %s", strings.Join(reasons, ", ")), tcell.ColorOrange
+	case evictionRiskLow:
+		return fmt.Sprintf("low: %s", strings.Join(reasons, ", ")), tcell.ColorYellow
+	}
+	return "-", tcell.ColorYellow
+}
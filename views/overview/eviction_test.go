@@ -0,0 +1,47 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodEviction(t *testing.T) {
+	request, usage := resource.MustParse("256Mi"), resource.MustParse("512Mi")
+	overRequest := model.PodModel{QOSClass: "Burstable", PodRequestedMemQty: &request, PodUsageMemQty: &usage}
+	tests := []struct {
+		name  string
+		pod   model.PodModel
+		text  string
+		color tcell.Color
+	}{
+		{name: "guaranteed", pod: model.PodModel{QOSClass: "Guaranteed", NodePressures: []string{"mem"}}, text: "-", color: tcell.ColorYellow},
+		{name: "burstable within request", pod: model.PodModel{QOSClass: "Burstable", PodRequestedMemQty: &usage, PodUsageMemQty: &request}, text: "-", color: tcell.ColorYellow},
+		{name: "best effort", pod: model.PodModel{QOSClass: "BestEffort"}, text: "low: BestEffort", color: tcell.ColorYellow},
+		{name: "over request", pod: overRequest, text: "low: over request", color: tcell.ColorYellow},
+		{
+			name:  "burstable under pressure",
+			pod:   model.PodModel{QOSClass: "Burstable", NodePressures: []string{"disk"}},
+			text:  "medium: Burstable, disk pressure",
+			color: tcell.ColorOrange,
+		},
+		{
+			name:  "best effort under pressure",
+			pod:   model.PodModel{QOSClass: "BestEffort", NodePressures: []string{"mem"}},
+			text:  "high: BestEffort, mem pressure",
+			color: tcell.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			text, color := podEviction(tc.pod)
+			if text != tc.text || color != tc.color {
+				t.Fatalf("expecting %q (%v), got %q (%v)", tc.text, tc.color, text, color)
+			}
+		})
+	}
+}
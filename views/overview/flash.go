@@ -0,0 +1,96 @@
+package overview
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// flashDuration is how long a CPU or MEMORY cell is highlighted after a significant change
+	flashDuration = 3 * time.Second
+	// flashChangeRatio is the change, relative to the previous value, of a significant change
+	flashChangeRatio = 0.25
+	// flashMinCPU and flashMinMem are the smallest significant changes, in millicores and bytes,
+	// so idle pods moving from 1m to 2m do not flash
+	flashMinCPU = 10
+	flashMinMem = 16 * 1024 * 1024
+)
+
+// usageSample is the CPU (millicores) and memory (bytes) usage of a pod at a refresh
+type usageSample struct {
+	cpu, mem int64
+}
+
+// flashTracker compares the usage of the pods at each refresh with the previous one, and
+// keeps until when the CPU and MEMORY cells of the pods that changed significantly flash.
+type flashTracker struct {
+	mu    sync.Mutex
+	last  map[string]usageSample // keyed by namespace/name
+	until map[string]time.Time   // keyed by namespace/name/column
+}
+
+func newFlashTracker() *flashTracker {
+	return &flashTracker{last: make(map[string]usageSample), until: make(map[string]time.Time)}
+}
+
+// record compares the usage of pods, observed at now, with the previous refresh and flashes
+// the cells of the significant changes. Pods without metrics are ignored.
+func (t *flashTracker) record(pods []model.PodModel, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	last := make(map[string]usageSample, len(pods))
+	for _, pod := range pods {
+		if pod.PodUsageCpuQty == nil || pod.PodUsageMemQty == nil {
+			continue
+		}
+		key := podKey(pod.Namespace, pod.Name)
+		sample := usageSample{cpu: pod.PodUsageCpuQty.MilliValue(), mem: pod.PodUsageMemQty.Value()}
+		if prev, ok := t.last[key]; ok {
+			if significantChange(prev.cpu, sample.cpu, flashMinCPU) {
+				t.until[key+"/CPU"] = now.Add(flashDuration)
+			}
+			if significantChange(prev.mem, sample.mem, flashMinMem) {
+				t.until[key+"/MEMORY"] = now.Add(flashDuration)
+			}
+		}
+		last[key] = sample
+	}
+	t.last = last
+	for key, until := range t.until {
+		if !until.After(now) {
+			delete(t.until, key)
+		}
+	}
+}
+
+// color returns the background color of the column cell of the pod keyed by namespace/name
+// at now: bright right after a significant change, then fading to the default background
+func (t *flashTracker) color(key, column string, now time.Time) tcell.Color {
+	t.mu.Lock()
+	until, ok := t.until[key+"/"+column]
+	t.mu.Unlock()
+	left := until.Sub(now)
+	switch {
+	case !ok || left <= 0:
+		return tcell.ColorDefault
+	case left > flashDuration*2/3:
+		return tcell.ColorOlive
+	case left > flashDuration/3:
+		return tcell.ColorDarkOliveGreen
+	default:
+		return tcell.ColorDarkSlateGray
+	}
+}
+
+// significantChange returns true when cur differs from prev by at least min and by at
+// least flashChangeRatio of prev
+func significantChange(prev, cur, min int64) bool {
+	diff := cur - prev
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff >= min && float64(diff) >= flashChangeRatio*float64(prev)
+}
@@ -0,0 +1,55 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFlashTracker(t *testing.T) {
+	usage := func(cpu, mem int64) model.PodModel {
+		return model.PodModel{
+			Namespace:      "default",
+			Name:           "web-0",
+			PodUsageCpuQty: resource.NewMilliQuantity(cpu, resource.DecimalSI),
+			PodUsageMemQty: resource.NewQuantity(mem*1024*1024, resource.BinarySI),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		prev   model.PodModel
+		cur    model.PodModel
+		cpu    bool
+		memory bool
+	}{
+		{name: "steady", prev: usage(100, 256), cur: usage(110, 260)},
+		{name: "cpu spike", prev: usage(100, 256), cur: usage(300, 256), cpu: true},
+		{name: "memory drop", prev: usage(100, 256), cur: usage(100, 128), memory: true},
+		{name: "small absolute change", prev: usage(2, 8), cur: usage(8, 20)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			now := time.Now()
+			tracker := newFlashTracker()
+			tracker.record([]model.PodModel{tc.prev}, now)
+			tracker.record([]model.PodModel{tc.cur}, now)
+
+			key := podKey("default", "web-0")
+			if flashing := tracker.color(key, "CPU", now) != tcell.ColorDefault; flashing != tc.cpu {
+				t.Errorf("CPU flashing: expected %t, got %t", tc.cpu, flashing)
+			}
+			if flashing := tracker.color(key, "MEMORY", now) != tcell.ColorDefault; flashing != tc.memory {
+				t.Errorf("MEMORY flashing: expected %t, got %t", tc.memory, flashing)
+			}
+			if color := tracker.color(key, "CPU", now.Add(flashDuration)); color != tcell.ColorDefault {
+				t.Errorf("expecting the flash to fade after %s, got %v", flashDuration, color)
+			}
+		})
+	}
+}
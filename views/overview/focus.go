@@ -0,0 +1,23 @@
+package overview
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// focusedColor is the border, and title, color of the focused panel
+const focusedColor = tcell.ColorYellow
+
+// highlightPanel sets the border and title style of a panel root: the focused panel has
+// a bold yellow border and title so that it is clear which panel the keys affect.
+func highlightPanel(root *tview.Flex, focused bool) {
+	if focused {
+		root.SetBorderColor(focusedColor)
+		root.SetBorderAttributes(tcell.AttrBold)
+		root.SetTitleColor(focusedColor)
+		return
+	}
+	root.SetBorderColor(tview.Styles.BorderColor)
+	root.SetBorderAttributes(tcell.AttrNone)
+	root.SetTitleColor(tview.Styles.TitleColor)
+}
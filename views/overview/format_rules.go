@@ -0,0 +1,301 @@
+package overview
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// formatConditionExpr matches a rule condition: <field> <operator> <value>, i.e. MEMORY% > 85
+var formatConditionExpr = regexp.MustCompile(`^([A-Za-z%_-]+)\s*(>=|<=|==|!=|>|<|=)\s*(.+)$`)
+
+// formatCondition is the condition of a formatting rule, on a model field or label
+type formatCondition struct {
+	label   bool   // the condition is on the label key
+	field   string // field name (upper case), or label key
+	op      string // comparison operator; = is an alias of ==
+	value   string
+	numeric bool    // the field is numeric
+	number  float64 // value, for numeric fields
+}
+
+// formatStyle is the style applied to the rows matching a formatting rule
+type formatStyle struct {
+	color      tcell.Color // row text color, default to leave unchanged
+	background tcell.Color // row background color, default to leave unchanged
+	bold       bool
+}
+
+// formatRule is a compiled formatting rule of the configuration file
+type formatRule struct {
+	target string
+	cond   formatCondition
+	style  formatStyle
+}
+
+// podFormatFields and nodeFormatFields are the fields the rule conditions can test, numeric when set
+var (
+	podFormatFields  = map[string]bool{"CPU%": true, "MEMORY%": true, "RESTARTS": true, "NAMESPACE": false, "NAME": false, "STATUS": false, "NODE": false}
+	nodeFormatFields = map[string]bool{"CPU%": true, "MEMORY%": true, "PODS": true, "NAME": false, "STATUS": false}
+)
+
+// compileFormatRules compiles the formatting rules of the configuration file
+func compileFormatRules(rules []config.FormatRule) ([]formatRule, error) {
+	var compiled []formatRule
+	for i, rule := range rules {
+		target := rule.Target
+		if target == "" {
+			target = config.ColumnTargetPod
+		}
+		fields := podFormatFields
+		if target == config.ColumnTargetNode {
+			fields = nodeFormatFields
+		}
+		cond, err := parseFormatCondition(rule.If, fields)
+		if err != nil {
+			return nil, fmt.Errorf("formatRules[%d]: if: %s", i, err)
+		}
+		style, err := parseFormatStyle(rule.Then)
+		if err != nil {
+			return nil, fmt.Errorf("formatRules[%d]: then: %s", i, err)
+		}
+		compiled = append(compiled, formatRule{target: target, cond: cond, style: style})
+	}
+	return compiled, nil
+}
+
+// ValidateFormatRules returns an error when a formatting rule of the configuration file is invalid
+func ValidateFormatRules(rules []config.FormatRule) error {
+	_, err := compileFormatRules(rules)
+	return err
+}
+
+// parseFormatCondition parses a condition on a field, i.e. "MEMORY% > 85", or on a label,
+// i.e. "label team=payments"
+func parseFormatCondition(expr string, fields map[string]bool) (formatCondition, error) {
+	expr = strings.TrimSpace(expr)
+	if rest := strings.TrimPrefix(expr, "label "); rest != expr {
+		rest = strings.TrimSpace(rest)
+		op := "=="
+		key, value, found := strings.Cut(rest, "!=")
+		if found {
+			op = "!="
+		} else if key, value, found = strings.Cut(rest, "="); !found {
+			return formatCondition{}, fmt.Errorf("invalid label condition %q (want label <key>=<value> or label <key>!=<value>)", expr)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return formatCondition{}, fmt.Errorf("missing label key in %q", expr)
+		}
+		return formatCondition{label: true, field: key, op: op, value: strings.TrimSpace(strings.TrimPrefix(value, "="))}, nil
+	}
+
+	match := formatConditionExpr.FindStringSubmatch(expr)
+	if match == nil {
+		return formatCondition{}, fmt.Errorf("invalid condition %q (want <field> <operator> <value>, i.e. MEMORY%% > 85)", expr)
+	}
+	cond := formatCondition{field: strings.ToUpper(match[1]), op: match[2], value: strings.TrimSpace(match[3])}
+	if cond.op == "=" {
+		cond.op = "=="
+	}
+	numeric, ok := fields[cond.field]
+	if !ok {
+		return formatCondition{}, fmt.Errorf("unsupported field %s", match[1])
+	}
+	if numeric {
+		cond.numeric = true
+		number, err := strconv.ParseFloat(cond.value, 64)
+		if err != nil {
+			return formatCondition{}, fmt.Errorf("field %s requires a number, got %q", cond.field, cond.value)
+		}
+		cond.number = number
+	} else if cond.op != "==" && cond.op != "!=" {
+		return formatCondition{}, fmt.Errorf("field %s only supports the == and != operators", cond.field)
+	}
+	return cond, nil
+}
+
+// parseFormatStyle parses a comma-separated list of styles: row=<color>, bg=<color>, and bold
+func parseFormatStyle(expr string) (formatStyle, error) {
+	style := formatStyle{color: tcell.ColorDefault, background: tcell.ColorDefault}
+	for _, item := range strings.Split(expr, ",") {
+		item = strings.TrimSpace(item)
+		key, value, _ := strings.Cut(item, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		switch key {
+		case "bold":
+			style.bold = true
+		case "row", "bg":
+			color := tcell.GetColor(strings.ToLower(strings.TrimSpace(value)))
+			if color == tcell.ColorDefault {
+				return formatStyle{}, fmt.Errorf("unknown color %q", value)
+			}
+			if key == "row" {
+				style.color = color
+			} else {
+				style.background = color
+			}
+		default:
+			return formatStyle{}, fmt.Errorf("unsupported style %q (want row=<color>, bg=<color>, or bold)", item)
+		}
+	}
+	return style, nil
+}
+
+// match returns true when the field value, or label, satisfies the condition
+func (c formatCondition) match(labels map[string]string, field func(name string) (string, float64)) bool {
+	if c.label {
+		value, ok := labels[c.field]
+		if c.op == "!=" {
+			return !ok || value != c.value
+		}
+		return ok && value == c.value
+	}
+
+	text, number := field(c.field)
+	switch c.op {
+	case "==":
+		if !c.numeric {
+			return strings.EqualFold(text, c.value)
+		}
+		return number == c.number
+	case "!=":
+		if !c.numeric {
+			return !strings.EqualFold(text, c.value)
+		}
+		return number != c.number
+	case ">":
+		return number > c.number
+	case ">=":
+		return number >= c.number
+	case "<":
+		return number < c.number
+	case "<=":
+		return number <= c.number
+	}
+	return false
+}
+
+// podFormatField returns the value of the named field of pod: text for the text fields,
+// number for the numeric fields
+func podFormatField(pod model.PodModel) func(name string) (string, float64) {
+	return func(name string) (string, float64) {
+		switch name {
+		case "CPU%":
+			if pod.PodUsageCpuQty == nil || pod.PodRequestedCpuQty == nil {
+				return "", 0
+			}
+			return "", percentOf(float64(pod.PodUsageCpuQty.MilliValue()), float64(pod.PodRequestedCpuQty.MilliValue()))
+		case "MEMORY%":
+			if pod.PodUsageMemQty == nil || pod.PodRequestedMemQty == nil {
+				return "", 0
+			}
+			return "", percentOf(float64(pod.PodUsageMemQty.Value()), float64(pod.PodRequestedMemQty.Value()))
+		case "RESTARTS":
+			return "", float64(pod.Restarts)
+		case "NAMESPACE":
+			return pod.Namespace, 0
+		case "NAME":
+			return pod.Name, 0
+		case "STATUS":
+			return pod.Status, 0
+		case "NODE":
+			return pod.Node, 0
+		}
+		return "", 0
+	}
+}
+
+// nodeFormatField returns the value of the named field of node, like podFormatField
+func nodeFormatField(node model.NodeModel) func(name string) (string, float64) {
+	return func(name string) (string, float64) {
+		switch name {
+		case "CPU%":
+			if node.UsageCpuQty == nil || node.AllocatableCpuQty == nil {
+				return "", 0
+			}
+			return "", percentOf(float64(node.UsageCpuQty.MilliValue()), float64(node.AllocatableCpuQty.MilliValue()))
+		case "MEMORY%":
+			if node.UsageMemQty == nil || node.AllocatableMemQty == nil {
+				return "", 0
+			}
+			return "", percentOf(float64(node.UsageMemQty.Value()), float64(node.AllocatableMemQty.Value()))
+		case "PODS":
+			return "", float64(node.PodsCount)
+		case "NAME":
+			return node.Name, 0
+		case "STATUS":
+			return node.Status, 0
+		}
+		return "", 0
+	}
+}
+
+// percentOf returns used as a percentage of total, 0 without total
+func percentOf(used, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return used / total * 100
+}
+
+// podFormatStyles and nodeFormatStyles return the styles of the rules, of the target, matching
+// the pod or node, in rule order: later rules override the colors of earlier ones
+func podFormatStyles(rules []formatRule, pod model.PodModel) []formatStyle {
+	var styles []formatStyle
+	for _, rule := range rules {
+		if rule.target == config.ColumnTargetPod && rule.cond.match(pod.Labels, podFormatField(pod)) {
+			styles = append(styles, rule.style)
+		}
+	}
+	return styles
+}
+
+func nodeFormatStyles(rules []formatRule, node model.NodeModel) []formatStyle {
+	var styles []formatStyle
+	for _, rule := range rules {
+		if rule.target == config.ColumnTargetNode && rule.cond.match(node.Labels, nodeFormatField(node)) {
+			styles = append(styles, rule.style)
+		}
+	}
+	return styles
+}
+
+// formatBackground returns the row background color of the styles, as applied by
+// applyFormatStyles: the background of the last style setting one, or the default
+func formatBackground(styles []formatStyle) tcell.Color {
+	background := tcell.ColorDefault
+	for _, style := range styles {
+		if style.background != tcell.ColorDefault {
+			background = style.background
+		}
+	}
+	return background
+}
+
+// applyFormatStyles applies the styles to the cells of the table row
+func applyFormatStyles(table *tview.Table, row int, styles []formatStyle) {
+	for _, style := range styles {
+		for col := 0; col < table.GetColumnCount(); col++ {
+			cell := table.GetCell(row, col)
+			if cell == nil {
+				continue
+			}
+			if style.color != tcell.ColorDefault {
+				cell.SetTextColor(style.color)
+			}
+			if style.background != tcell.ColorDefault {
+				cell.SetBackgroundColor(style.background)
+			}
+			if style.bold {
+				cell.SetAttributes(cell.Attributes | tcell.AttrBold)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFormatRules(t *testing.T) {
+	pod := model.PodModel{
+		Namespace:          "payments",
+		Name:               "api-0",
+		Status:             "Running",
+		Restarts:           3,
+		Labels:             map[string]string{"team": "payments"},
+		PodUsageMemQty:     resource.NewQuantity(900*1024*1024, resource.BinarySI),
+		PodRequestedMemQty: resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+	}
+
+	tests := []struct {
+		name    string
+		rule    config.FormatRule
+		matched bool
+		style   formatStyle
+		err     bool
+	}{
+		{
+			name:    "memory percent",
+			rule:    config.FormatRule{If: "MEMORY% > 85", Then: "row=red"},
+			matched: true,
+			style:   formatStyle{color: tcell.ColorRed, background: tcell.ColorDefault},
+		},
+		{name: "memory percent not reached", rule: config.FormatRule{If: "memory% >= 90", Then: "row=red"}},
+		{
+			name:    "label",
+			rule:    config.FormatRule{If: "label team=payments", Then: "bold, bg=darkblue"},
+			matched: true,
+			style:   formatStyle{color: tcell.ColorDefault, background: tcell.ColorDarkBlue, bold: true},
+		},
+		{name: "label not matching", rule: config.FormatRule{If: "label team!=payments", Then: "bold"}},
+		{
+			name:    "text field",
+			rule:    config.FormatRule{If: "STATUS == running", Then: "row=green"},
+			matched: true,
+			style:   formatStyle{color: tcell.ColorGreen, background: tcell.ColorDefault},
+		},
+		{name: "node rule", rule: config.FormatRule{Target: "node", If: "PODS > 0", Then: "bold"}},
+		{name: "unsupported field", rule: config.FormatRule{If: "OWNER == me", Then: "bold"}, err: true},
+		{name: "text field operator", rule: config.FormatRule{If: "STATUS > 1", Then: "bold"}, err: true},
+		{name: "numeric field value", rule: config.FormatRule{If: "RESTARTS > many", Then: "bold"}, err: true},
+		{name: "unknown color", rule: config.FormatRule{If: "RESTARTS > 1", Then: "row=blurple"}, err: true},
+		{name: "unsupported style", rule: config.FormatRule{If: "RESTARTS > 1", Then: "blink"}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			rules, err := compileFormatRules([]config.FormatRule{tc.rule})
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expecting an error for rule %+v", tc.rule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			styles := podFormatStyles(rules, pod)
+			if matched := len(styles) > 0; matched != tc.matched {
+				t.Fatalf("expected matched %t, got %t", tc.matched, matched)
+			}
+			if tc.matched && styles[0] != tc.style {
+				t.Errorf("expected style %+v, got %+v", tc.style, styles[0])
+			}
+		})
+	}
+}
+
+func TestFormatBackground(t *testing.T) {
+	tests := []struct {
+		name     string
+		styles   []formatStyle
+		expected tcell.Color
+	}{
+		{name: "no style", expected: tcell.ColorDefault},
+		{name: "text color only", styles: []formatStyle{{color: tcell.ColorRed, background: tcell.ColorDefault}}, expected: tcell.ColorDefault},
+		{
+			name:     "last background",
+			styles:   []formatStyle{{background: tcell.ColorDarkBlue}, {background: tcell.ColorMaroon}, {color: tcell.ColorRed, background: tcell.ColorDefault}},
+			expected: tcell.ColorMaroon,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if background := formatBackground(tc.styles); background != tc.expected {
+				t.Errorf("expecting background %v, got %v", tc.expected, background)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// podHostNetwork returns the HOST NET column text and color: a badge for pods in the host
+// network namespace (red) and for the host ports bound by the pod (orange), "-" otherwise
+func podHostNetwork(pod model.PodModel) (string, tcell.Color) {
+	var badges []string
+	color := tcell.ColorYellow
+	if pod.HostNetwork {
+		badges = append(badges, "hostNetwork")
+		color = tcell.ColorRed
+	}
+	if len(pod.HostPorts) > 0 {
+		ports := make([]string, len(pod.HostPorts))
+		for i, port := range pod.HostPorts {
+			ports[i] = fmt.Sprintf("%d", port)
+		}
+		badges = append(badges, "hostPort:"+strings.Join(ports, ","))
+		if !pod.HostNetwork {
+			color = tcell.ColorOrange
+		}
+	}
+	if len(badges) == 0 {
+		return "-", tcell.ColorYellow
+	}
+	return strings.Join(badges, " "), color
+}
@@ -0,0 +1,37 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestPodHostNetwork(t *testing.T) {
+	tests := []struct {
+		name  string
+		pod   model.PodModel
+		text  string
+		color tcell.Color
+	}{
+		{name: "pod network", pod: model.PodModel{}, text: "-", color: tcell.ColorYellow},
+		{name: "host ports", pod: model.PodModel{HostPorts: []int32{80, 443}}, text: "hostPort:80,443", color: tcell.ColorOrange},
+		{name: "host network", pod: model.PodModel{HostNetwork: true}, text: "hostNetwork", color: tcell.ColorRed},
+		{
+			name:  "host network and ports",
+			pod:   model.PodModel{HostNetwork: true, HostPorts: []int32{9100}},
+			text:  "hostNetwork hostPort:9100",
+			color: tcell.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			text, color := podHostNetwork(tc.pod)
+			if text != tc.text || color != tc.color {
+				t.Fatalf("expecting %q (%v), got %q (%v)", tc.text, tc.color, text, color)
+			}
+		})
+	}
+}
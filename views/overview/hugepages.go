@@ -0,0 +1,49 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// hugePagesColumns maps the hugepages columns, of nodes and pods, to their resource
+var hugePagesColumns = map[string]coreV1.ResourceName{
+	"HUGEPAGES-2Mi": "hugepages-2Mi",
+	"HUGEPAGES-1Gi": "hugepages-1Gi",
+}
+
+// podHugePages returns the text of a pod hugepages column: the requests and limits
+// of the resource, or "-" when the pod does not use the resource
+func podHugePages(pod model.PodModel, name coreV1.ResourceName) string {
+	requests, limits := model.HugePagesQty(pod.HugePagesRequests, name), model.HugePagesQty(pod.HugePagesLimits, name)
+	if requests == nil && limits == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s/%s", qtyOrDash(requests), qtyOrDash(limits))
+}
+
+// nodeHugePages returns the text and color of a node hugepages column: the resource requested
+// by the node pods and allocatable, in red when 90% or more is requested, or "-" when not allocatable
+func nodeHugePages(node model.NodeModel, name coreV1.ResourceName) (string, tcell.Color) {
+	allocatable := model.HugePagesQty(node.AllocatableHugePages, name)
+	if allocatable == nil {
+		return "-", tcell.ColorYellow
+	}
+	requested := node.RequestedPodHugePages[name]
+	text := fmt.Sprintf("%s/%s", requested.String(), allocatable.String())
+	if requested.Value()*10 >= allocatable.Value()*9 {
+		return text, tcell.ColorRed
+	}
+	return text, tcell.ColorYellow
+}
+
+// qtyOrDash returns the quantity, or "-" when nil
+func qtyOrDash(qty *resource.Quantity) string {
+	if qty == nil {
+		return "-"
+	}
+	return qty.String()
+}
@@ -0,0 +1,45 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNodeHugePages(t *testing.T) {
+	allocatable := coreV1.ResourceList{"hugepages-2Mi": resource.MustParse("1Gi")}
+	tests := []struct {
+		name  string
+		node  model.NodeModel
+		text  string
+		color tcell.Color
+	}{
+		{name: "not allocatable", node: model.NodeModel{}, text: "-", color: tcell.ColorYellow},
+		{name: "not requested", node: model.NodeModel{AllocatableHugePages: allocatable}, text: "0/1Gi", color: tcell.ColorYellow},
+		{
+			name:  "requested",
+			node:  model.NodeModel{AllocatableHugePages: allocatable, RequestedPodHugePages: coreV1.ResourceList{"hugepages-2Mi": resource.MustParse("512Mi")}},
+			text:  "512Mi/1Gi",
+			color: tcell.ColorYellow,
+		},
+		{
+			name:  "exhausted",
+			node:  model.NodeModel{AllocatableHugePages: allocatable, RequestedPodHugePages: coreV1.ResourceList{"hugepages-2Mi": resource.MustParse("1Gi")}},
+			text:  "1Gi/1Gi",
+			color: tcell.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			text, color := nodeHugePages(tc.node, "hugepages-2Mi")
+			if text != tc.text || color != tc.color {
+				t.Fatalf("expecting %q (%v), got %q (%v)", tc.text, tc.color, text, color)
+			}
+		})
+	}
+}
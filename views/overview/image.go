@@ -0,0 +1,79 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// imageColumnWidth is the width, in characters, images are truncated to in the IMAGE column
+	imageColumnWidth = 40
+	// imageDigestLength is the number of digest characters kept by the IMAGE column
+	imageDigestLength = 12
+)
+
+// podImage returns the IMAGE column text: the truncated first container image,
+// followed by "+N" when the pod has N more images
+func podImage(pod model.PodModel) string {
+	if len(pod.Images) == 0 {
+		return ""
+	}
+	image := shortImage(pod.Images[0], imageColumnWidth)
+	if len(pod.Images) > 1 {
+		image = fmt.Sprintf("%s +%d", image, len(pod.Images)-1)
+	}
+	return image
+}
+
+// shortImage truncates image to width characters while keeping its tag, or shortened
+// digest, visible: the registry host is removed first, then the repository path,
+// then the start of the repository name.
+func shortImage(image string, width int) string {
+	name, ref := splitImage(image)
+	if strings.HasPrefix(ref, "@") {
+		if i := strings.Index(ref, ":"); i >= 0 && len(ref) > i+1+imageDigestLength {
+			ref = ref[:i+1+imageDigestLength]
+		}
+	}
+	if utf8.RuneCountInString(name+ref) <= width {
+		return name + ref
+	}
+
+	path := strings.Split(name, "/")
+	if len(path) > 1 && isRegistryHost(path[0]) {
+		path = path[1:]
+		if name = strings.Join(path, "/"); utf8.RuneCountInString(name+ref) <= width {
+			return name + ref
+		}
+	}
+	if len(path) > 1 {
+		if name = string(ui.Icons.Ellipsis) + "/" + path[len(path)-1]; utf8.RuneCountInString(name+ref) <= width {
+			return name + ref
+		}
+	}
+	name = path[len(path)-1]
+	if keep := width - len(ref) - 1; keep > 0 && keep < len(name) {
+		return string(ui.Icons.Ellipsis) + name[len(name)-keep:] + ref
+	}
+	return name + ref
+}
+
+// splitImage splits image into its name and reference, the ":tag" or "@digest" suffix
+func splitImage(image string) (name, ref string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[:i], image[i:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i:]
+	}
+	return image, ""
+}
+
+// isRegistryHost returns true if the first component of an image name is a registry host
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
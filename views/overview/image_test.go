@@ -0,0 +1,40 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestShortImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		width    int
+		expected string
+	}{
+		{name: "short", image: "nginx:1.25", width: 20, expected: "nginx:1.25"},
+		{name: "registry port", image: "localhost:5000/app:v2", width: 40, expected: "localhost:5000/app:v2"},
+		{name: "registry removed", image: "registry.example.com/team/app:v1.2.3", width: 20, expected: "team/app:v1.2.3"},
+		{name: "path removed", image: "registry.example.com/team/platform/app:v1.2.3", width: 20, expected: "…/app:v1.2.3"},
+		{name: "name truncated", image: "registry.example.com/team/very-long-application-name:v1", width: 16, expected: "…ication-name:v1"},
+		{name: "digest shortened", image: "ghcr.io/org/app@sha256:0123456789abcdef0123456789abcdef", width: 40, expected: "ghcr.io/org/app@sha256:0123456789ab"},
+		{name: "no registry", image: "team/platform/app:v1", width: 10, expected: "…/app:v1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if image := shortImage(tc.image, tc.width); image != tc.expected {
+				t.Fatalf("expecting image %q, got %q", tc.expected, image)
+			}
+		})
+	}
+}
+
+func TestPodImage(t *testing.T) {
+	pod := model.PodModel{Images: []string{"nginx:1.25", "envoy:v1.28", "busybox"}}
+	if image := podImage(pod); image != "nginx:1.25 +2" {
+		t.Fatalf("expecting image %q, got %q", "nginx:1.25 +2", image)
+	}
+}
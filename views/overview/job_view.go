@@ -0,0 +1,83 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// showJobs displays the jobs with their run duration, backoff count, and the reasons
+// their pods failed, flagging the jobs that exceeded their active deadline.
+func (p *MainPanel) showJobs() {
+	jobs, err := p.app.GetK8sClient().Controller().GetJobModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list jobs: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "STATUS", "COMPLETIONS", "DURATION", "DEADLINE", "BACKOFF", "FAILED POD REASONS"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	exceeded := 0
+	for i, job := range jobs {
+		if job.DeadlineExceeded {
+			exceeded++
+		}
+		deadline := "-"
+		if job.ActiveDeadline > 0 {
+			deadline = duration.HumanDuration(job.ActiveDeadline)
+		}
+		values := []string{
+			job.Namespace,
+			job.Name,
+			job.Status,
+			fmt.Sprintf("%d/%d", job.Succeeded, job.Completions),
+			jobDuration(job),
+			deadline,
+			fmt.Sprintf("%d/%d", job.Failed, job.BackoffLimit),
+			valueOrDash(strings.Join(job.FailedReasons, ", ")),
+		}
+		color := jobColor(job)
+		for j, val := range values {
+			table.SetCell(i+1, j, tview.NewTableCell(val).SetTextColor(color).SetExpansion(100))
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Jobs (%d, %d past deadline) (Esc: close) ", len(jobs), exceeded))
+	p.app.ShowNamedModal("jobs", table)
+}
+
+// jobDuration returns the run duration of job, "-" when not started
+func jobDuration(job model.JobModel) string {
+	if job.Duration == 0 {
+		return "-"
+	}
+	return duration.HumanDuration(job.Duration)
+}
+
+// jobColor returns the row color of job: red when failed or past its active deadline,
+// orange when some of its pods failed, yellow otherwise
+func jobColor(job model.JobModel) tcell.Color {
+	switch {
+	case job.DeadlineExceeded || job.Status == model.JobFailed:
+		return tcell.ColorRed
+	case job.Failed > 0:
+		return tcell.ColorOrange
+	}
+	return tcell.ColorYellow
+}
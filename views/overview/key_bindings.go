@@ -0,0 +1,153 @@
+package overview
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/ui"
+)
+
+// keyScope is the view a key binding applies to
+type keyScope int
+
+const (
+	scopeAll   keyScope = iota // any view of the page
+	scopePods                  // the pods panel, when focused
+	scopeNodes                 // the nodes panel, when focused
+)
+
+// keyBinding is a key binding of the overview page: Do is called when the key (or rune, for
+// tcell.KeyRune) is pressed in scope. Hint shows the binding in the key hint line.
+type keyBinding struct {
+	key   tcell.Key
+	ch    rune
+	name  string
+	scope keyScope
+	hint  bool
+	do    func()
+}
+
+// keyName returns the name of the key of the binding, shown in the hint line
+func (b keyBinding) keyName() string {
+	if b.key != tcell.KeyRune {
+		return tcell.KeyNames[b.key]
+	}
+	if b.ch == ' ' {
+		return "Space"
+	}
+	return string(b.ch)
+}
+
+// matches returns true when the binding applies to the event in scope
+func (b keyBinding) matches(event *tcell.EventKey, scope keyScope) bool {
+	if b.scope != scopeAll && b.scope != scope {
+		return false
+	}
+	if b.key != tcell.KeyRune {
+		return event.Key() == b.key
+	}
+	return event.Key() == tcell.KeyRune && event.Rune() == b.ch
+}
+
+// keyHints returns the hints of the bindings shown in the hint line for scope: the bindings of
+// the scope first, followed by the bindings of all the views
+func keyHints(bindings []keyBinding, scope keyScope) []ui.KeyHint {
+	var scoped, all []ui.KeyHint
+	for _, b := range bindings {
+		if !b.hint {
+			continue
+		}
+		hint := ui.KeyHint{Key: b.keyName(), Name: b.name}
+		switch b.scope {
+		case scopeAll:
+			all = append(all, hint)
+		case scope:
+			scoped = append(scoped, hint)
+		}
+	}
+	return append(scoped, all...)
+}
+
+// keyBindings returns the key bindings of the overview page, along with their handlers
+func (p *MainPanel) keyBindings() []keyBinding {
+	runeKey := func(ch rune, name string, scope keyScope, hint bool, do func()) keyBinding {
+		return keyBinding{key: tcell.KeyRune, ch: ch, name: name, scope: scope, hint: hint, do: do}
+	}
+	bindings := []keyBinding{
+		{key: tcell.KeyRight, name: "expand", scope: scopePods, hint: true, do: func() { p.podPanel.expandSelected(true) }},
+		{key: tcell.KeyLeft, name: "collapse", scope: scopePods, do: func() { p.podPanel.expandSelected(false) }},
+		runeKey(' ', "mark", scopePods, true, p.podPanel.toggleMark),
+		runeKey('f', "focus", scopePods, true, p.showPodFocus),
+		runeKey('p', "pin", scopePods, true, func() {
+			p.podPanel.togglePin()
+			p.drawPods()
+		}),
+		runeKey('d', "describe", scopeAll, true, p.describeSelected),
+		runeKey('y', "YAML", scopeAll, true, p.showSelectedYAML),
+		runeKey('/', "filter", scopeAll, true, p.showPodFilter),
+		runeKey('F', "jump", scopeAll, true, p.showPodJump),
+		// 's' rather than 'S', bound to the cron jobs before the sort chooser
+		runeKey('s', "sort", scopeAll, true, p.showSortChooser),
+		runeKey('+', "secondary sort", scopeAll, false, func() { p.secondarySortKey = true }),
+		runeKey('a', "batch", scopeAll, true, p.showBatchActions),
+		runeKey('C', "columns", scopeAll, true, p.showColumnChooser),
+		runeKey('|', "split", scopeAll, true, p.toggleSplit),
+		runeKey('m', "summary", scopeAll, true, p.toggleSummary),
+		runeKey('!', "diagnostics", scopeAll, true, p.showDiagnostics),
+		runeKey('e', "export HTML", scopeAll, false, p.exportHTML),
+		runeKey('X', "clean up failed pods", scopeAll, false, p.cleanupFailedPods),
+		runeKey('b', "disruption budgets", scopeAll, false, p.showPDBs),
+		runeKey('D', "daemon sets", scopeAll, false, p.showDaemonSets),
+		runeKey('J', "jobs", scopeAll, false, p.showJobs),
+		runeKey('S', "cron jobs", scopeAll, false, p.showCronJobs),
+		runeKey('w', "replica sets", scopeAll, false, p.showReplicaSetIssues),
+		runeKey('n', "namespaces", scopeAll, false, p.showNamespaceLifecycle),
+		runeKey('v', "services", scopeAll, false, p.showServices),
+		runeKey('i', "routes", scopeAll, false, p.showRoutes),
+		runeKey('c', "config", scopeAll, false, p.showConfigInventory),
+		runeKey('K', "autoscaling", scopeAll, false, p.showAutoscaling),
+		runeKey('r', "API resources", scopeAll, false, p.showAPIResources),
+		runeKey('o', "node OS", scopeAll, false, p.cycleNodeOS),
+		runeKey('6', "IP family", scopeAll, false, p.toggleIPFamily),
+		runeKey('%', "percentages", scopeAll, false, func() { p.toggleMetricStyle(stylePercent) }),
+		runeKey('#', "numeric", scopeAll, false, func() { p.toggleMetricStyle(styleNumeric) }),
+		runeKey('u', "usage base", scopeAll, false, p.cycleUsageBase),
+		runeKey('t', "node capacity", scopeAll, false, p.toggleNodeCapacity),
+	}
+
+	// sort keys, listed by the sort chooser; after '+', a sort key selects the secondary sort column
+	for key, column := range nodeSortKeys {
+		column := column
+		bindings = append(bindings, runeKey(key, "sort by "+column, scopeNodes, false, func() {
+			p.sortNodesBy(column, p.takeSecondarySortKey())
+		}))
+	}
+	for key, column := range podSortKeys {
+		column := column
+		bindings = append(bindings, runeKey(key, "sort by "+column, scopePods, false, func() {
+			p.sortPodsBy(column, p.takeSecondarySortKey())
+		}))
+	}
+	return bindings
+}
+
+// takeSecondarySortKey returns true, once, after key '+' was pressed
+func (p *MainPanel) takeSecondarySortKey() bool {
+	secondary := p.secondarySortKey
+	p.secondarySortKey = false
+	return secondary
+}
+
+// focusedScope returns the scope of the focused panel
+func (p *MainPanel) focusedScope() keyScope {
+	switch {
+	case p.podPanel.list.HasFocus():
+		return scopePods
+	case p.nodePanel.list.HasFocus():
+		return scopeNodes
+	}
+	return scopeAll
+}
+
+// KeyHints returns the key bindings of the focused panel, and of the page, shown in the hint line
+func (p *MainPanel) KeyHints() []ui.KeyHint {
+	return keyHints(p.bindings, p.focusedScope())
+}
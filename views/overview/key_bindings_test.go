@@ -0,0 +1,114 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestKeyBindings(t *testing.T) {
+	noop := func() {}
+	bindings := []keyBinding{
+		{key: tcell.KeyRight, name: "expand", scope: scopePods, hint: true, do: noop},
+		{key: tcell.KeyRune, ch: ' ', name: "mark", scope: scopePods, hint: true, do: noop},
+		{key: tcell.KeyRune, ch: 'd', name: "describe", scope: scopeAll, hint: true, do: noop},
+		{key: tcell.KeyRune, ch: 'e', name: "export HTML", scope: scopeAll, do: noop},
+		{key: tcell.KeyRune, ch: 'N', name: "sort by NAME", scope: scopeNodes, do: noop},
+	}
+
+	tests := []struct {
+		name    string
+		scope   keyScope
+		event   *tcell.EventKey
+		hints   []string
+		matched string
+	}{
+		{
+			name:    "pods",
+			scope:   scopePods,
+			event:   tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone),
+			hints:   []string{"Right expand", "Space mark", "d describe"},
+			matched: "mark",
+		},
+		{
+			name:    "nodes",
+			scope:   scopeNodes,
+			event:   tcell.NewEventKey(tcell.KeyRune, 'N', tcell.ModNone),
+			hints:   []string{"d describe"},
+			matched: "sort by NAME",
+		},
+		{
+			name:  "out of scope",
+			scope: scopeNodes,
+			event: tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+			hints: []string{"d describe"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			hints := keyHints(bindings, tc.scope)
+			if len(hints) != len(tc.hints) {
+				t.Fatalf("expecting hints %v, got %v", tc.hints, hints)
+			}
+			for i, hint := range hints {
+				if hint.Key+" "+hint.Name != tc.hints[i] {
+					t.Fatalf("expecting hint %q at %d, got %v", tc.hints[i], i, hint)
+				}
+			}
+			matched := ""
+			for _, binding := range bindings {
+				if binding.matches(tc.event, tc.scope) {
+					matched = binding.name
+					break
+				}
+			}
+			if matched != tc.matched {
+				t.Fatalf("expecting binding %q, got %q", tc.matched, matched)
+			}
+		})
+	}
+}
+
+func TestSecondarySortKey(t *testing.T) {
+	var sorted []bool
+	p := &MainPanel{
+		podPanel:  &podPanel{list: tview.NewTable()},
+		nodePanel: &nodePanel{list: tview.NewTable()},
+	}
+	p.bindings = []keyBinding{
+		{key: tcell.KeyRune, ch: '+', name: "secondary sort", scope: scopeAll, do: func() { p.secondarySortKey = true }},
+		{key: tcell.KeyRune, ch: 'd', name: "describe", scope: scopeAll, do: func() {}},
+		{key: tcell.KeyRune, ch: 'N', name: "sort by NAME", scope: scopeAll, do: func() { sorted = append(sorted, p.takeSecondarySortKey()) }},
+	}
+
+	tests := []struct {
+		name      string
+		keys      string
+		secondary bool
+	}{
+		{name: "sort key", keys: "N"},
+		{name: "secondary sort key", keys: "+N", secondary: true},
+		{name: "after a secondary sort key", keys: "+NN"},
+		{name: "after another binding", keys: "+dN"},
+		{name: "after an unbound key", keys: "+xN"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			sorted = nil
+			for _, key := range tc.keys {
+				p.HandleInput(tcell.NewEventKey(tcell.KeyRune, key, tcell.ModNone))
+			}
+			if last := sorted[len(sorted)-1]; last != tc.secondary {
+				t.Errorf("expecting secondary sort %t, got %t", tc.secondary, last)
+			}
+			if p.secondarySortKey {
+				t.Error("expecting the secondary sort flag cleared")
+			}
+		})
+	}
+}
@@ -3,15 +3,27 @@ package overview
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/config"
 	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/columns"
+	"github.com/vladimirvivien/ktop/views/detail"
 	"github.com/vladimirvivien/ktop/views/model"
+	"github.com/vladimirvivien/ktop/views/snapshot"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// nodeSwapTimeout bounds the kubelet requests of the node description swap section, shown
+// once the requests are done
+const nodeSwapTimeout = 2 * time.Second
+
 type MainPanel struct {
 	app                 *application.Application
 	title               string
@@ -19,12 +31,44 @@ type MainPanel struct {
 	root                *tview.Flex
 	children            []tview.Primitive
 	selPanelIndex       int
-	nodePanel           ui.Panel
-	podPanel            ui.Panel
-	clusterSummaryPanel ui.Panel
+	nodePanel           *nodePanel
+	podPanel            *podPanel
+	clusterSummaryPanel *clusterSummaryPanel
 	showAllColumns      bool
 	nodeColumns         []string
 	podColumns          []string
+	allNodeColumns      []string
+	allPodColumns       []string
+	colRegistry         *columns.Registry
+	ctx                 context.Context
+
+	// latest models received from the controller
+	mu          sync.Mutex
+	lastSummary model.ClusterSummary
+	lastNodes   []model.NodeModel
+	lastPods    []model.PodModel
+	restarts    *restartTracker // restart counts history of the RESTART RATE column
+	// selected rows, and scroll positions, of the previous session restored once listed
+	restore config.ContextState
+
+	// view state, restored from and saved to the application state
+	nodeSort  config.SortState
+	podSort   config.SortState
+	podFilter string
+	nodeOS    string // operating system the nodes are filtered by, empty for all nodes
+	// the next sort key selects the secondary sort column
+	secondarySortKey bool
+	// key bindings of the page, set once the panels are created
+	bindings []keyBinding
+
+	// pods matching the filter, shown in the status bar
+	podsMatched int
+
+	// single pod focus view, updated on pods refresh while shown
+	focus *podFocus
+	// podsPane holds the pods panel and, in split mode, the detail pane of the selected pod
+	podsPane *tview.Flex
+	split    *podFocus
 }
 
 func New(app *application.Application, title string) *MainPanel {
@@ -40,6 +84,7 @@ func NewWithColumnOptions(app *application.Application, title string, showAllCol
 		showAllColumns: showAllColumns,
 		nodeColumns:    nodeColumns,
 		podColumns:     podColumns,
+		restarts:       newRestartTracker(),
 	}
 
 	return ctrl
@@ -47,12 +92,39 @@ func NewWithColumnOptions(app *application.Application, title string, showAllCol
 
 func (p *MainPanel) Layout(data interface{}) {
 	// Define the default columns
-	allNodeColumns := []string{"NAME", "STATUS", "AGE", "VERSION", "INT/EXT IPs", "OS/ARC", "PODS/IMGs", "DISK", "CPU", "MEM"}
-	allPodColumns := []string{"NAMESPACE", "POD", "READY", "STATUS", "RESTARTS", "AGE", "VOLS", "IP", "NODE", "CPU", "MEMORY"}
+	allNodeColumns := []string{"NAME", "STATUS", "AGE", "VERSION", "INT/EXT IPs", "OS/ARC", "PODS/IMGs", "OS", "DISK", "CPU", "MEM"}
+	allPodColumns := []string{"NAMESPACE", "POD", "READY", "STATUS", "RESTARTS", "RESTART RATE", "LAST RESTART", "AGE", "VOLS", "IP", "NODE", "SCHEDULER", "IMAGE", "SECURITY", "CPU"}
+	if p.app.GetK8sClient().CPUThrottlingEnabled() {
+		allPodColumns = append(allPodColumns, "THROTTLE%")
+	}
+	allPodColumns = append(allPodColumns, "MEMORY")
+	// optional columns not listed above are appended, the listed ones keep their position
+	allNodeColumns = append(allNodeColumns, withoutColumns(optionalNodeColumns, allNodeColumns)...)
+	allPodColumns = append(allPodColumns, withoutColumns(optionalPodColumns, allPodColumns)...)
+
+	// Append custom columns from registered column providers
+	allNodeColumns = append(allNodeColumns, p.colRegistry.NodeColumns()...)
+	allPodColumns = append(allPodColumns, p.colRegistry.PodColumns()...)
+
+	// Order columns as declared in the configuration file, then as saved in the previous session
+	cfg := p.app.Config()
+	state := p.app.State()
+	allNodeColumns = orderColumns(orderColumns(orderColumns(allNodeColumns, layoutNames(cfg.NodeColumns)), state.NodeColumnOrder), state.NodeColumns)
+	allPodColumns = orderColumns(orderColumns(orderColumns(allPodColumns, layoutNames(cfg.PodColumns)), state.PodColumnOrder), state.PodColumns)
+	p.allNodeColumns, p.allPodColumns = allNodeColumns, allPodColumns
+
+	// Restore the sort and filter of the previous session, or the configured sort
+	p.nodeSort, p.podSort, p.podFilter, p.nodeOS = state.NodeSort, state.PodSort, state.PodFilter, state.NodeOS
+	if p.nodeSort.Column == "" && cfg.NodeSort != nil {
+		p.nodeSort = *cfg.NodeSort
+	}
+	if p.podSort.Column == "" && cfg.PodSort != nil {
+		p.podSort = *cfg.PodSort
+	}
 	
-	// Use filtered columns if specified
-	nodeColumnsToDisplay := allNodeColumns
-	podColumnsToDisplay := allPodColumns
+	// Use filtered columns if specified, optional columns are hidden by default
+	nodeColumnsToDisplay := withoutColumns(allNodeColumns, optionalNodeColumns)
+	podColumnsToDisplay := withoutColumns(allPodColumns, optionalPodColumns)
 	
 	if !p.showAllColumns {
 		if len(p.nodeColumns) > 0 {
@@ -64,17 +136,44 @@ func (p *MainPanel) Layout(data interface{}) {
 			// Filter pod columns
 			podColumnsToDisplay = filterColumns(allPodColumns, p.podColumns)
 		}
+	} else {
+		// Restore the columns displayed in the previous session
+		if len(state.NodeColumns) > 0 {
+			nodeColumnsToDisplay = filterColumns(allNodeColumns, state.NodeColumns)
+		}
+		if len(state.PodColumns) > 0 {
+			podColumnsToDisplay = filterColumns(allPodColumns, state.PodColumns)
+		}
 	}
 	
-	p.nodePanel = NewNodePanel(p.app, fmt.Sprintf(" %c Nodes ", ui.Icons.Factory))
+	// formatting rules are validated when the configuration is loaded
+	formatRules, _ := compileFormatRules(cfg.FormatRules)
+	units := newDisplayUnits(cfg)
+
+	p.nodePanel = NewNodePanel(p.app, fmt.Sprintf(" %c Nodes ", ui.Icons.Factory), p.colRegistry)
+	p.nodePanel.widths = columnWidths(cfg.NodeColumns)
+	p.nodePanel.rules = formatRules
+	p.nodePanel.units = units
+	p.nodePanel.style = parseMetricStyle(state.MetricStyle)
+	p.nodePanel.capacity = state.NodeCapacity
 	p.nodePanel.DrawHeader(nodeColumnsToDisplay)
 
 	p.clusterSummaryPanel = NewClusterSummaryPanel(p.app, fmt.Sprintf(" %c Cluster Summary ", ui.Icons.Thermometer))
+	p.clusterSummaryPanel.units = units
+	p.clusterSummaryPanel.capacity = state.NodeCapacity
 	p.clusterSummaryPanel.Layout(nil)
 	p.clusterSummaryPanel.DrawHeader(nil)
 
-	p.podPanel = NewPodPanel(p.app, fmt.Sprintf(" %c Pods ", ui.Icons.Package))
+	p.podPanel = NewPodPanel(p.app, fmt.Sprintf(" %c Pods ", ui.Icons.Package), p.colRegistry)
+	p.podPanel.widths = columnWidths(cfg.PodColumns)
+	p.podPanel.ipv6 = state.PreferIPv6
+	p.podPanel.newWindow = cfg.NewPodDuration()
+	p.podPanel.rules = formatRules
+	p.podPanel.units = units
+	p.podPanel.style = parseMetricStyle(state.MetricStyle)
+	p.podPanel.base = parseUsageBase(state.UsageBase)
 	p.podPanel.DrawHeader(podColumnsToDisplay)
+	p.bindings = p.keyBindings()
 
 	p.children = []tview.Primitive{
 		p.clusterSummaryPanel.GetRootView(),
@@ -82,10 +181,23 @@ func (p *MainPanel) Layout(data interface{}) {
 		p.podPanel.GetRootView(),
 	}
 
+	p.updateStatus()
+
+	p.podsPane = tview.NewFlex()
+	p.layoutPods(nil)
+	p.podPanel.list.SetSelectionChangedFunc(func(_, _ int) {
+		p.followSelection()
+		p.recordSession()
+	})
+	p.nodePanel.list.SetSelectionChangedFunc(func(_, _ int) {
+		p.recordSession()
+	})
+	p.restore = *p.app.Session()
+
 	view := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(p.clusterSummaryPanel.GetRootView(), 4, 1, true).
+		AddItem(p.clusterSummaryPanel.GetRootView(), p.clusterSummaryPanel.setExpanded(state.SummaryExpanded), 1, true).
 		AddItem(p.nodePanel.GetRootView(), 15, 1, true).
-		AddItem(p.podPanel.GetRootView(), 0, 1, true)
+		AddItem(p.podsPane, 0, 1, true)
 
 	p.root = view
 }
@@ -106,23 +218,56 @@ func (p *MainPanel) GetChildrenViews() []tview.Primitive {
 }
 
 func (p *MainPanel) Run(ctx context.Context) error {
+	registry, err := columns.FromConfig(p.app.Config().Columns, p.app.GetK8sClient())
+	if err != nil {
+		return err
+	}
+	p.colRegistry = registry
+	p.ctx = ctx
+
 	p.Layout(nil)
 	ctrl := p.app.GetK8sClient().Controller()
 	ctrl.SetClusterSummaryRefreshFunc(p.refreshWorkloadSummary)
 	ctrl.SetNodeRefreshFunc(p.refreshNodeView)
 	ctrl.SetPodRefreshFunc(p.refreshPods)
 
-	if err := ctrl.Start(ctx, time.Second*10); err != nil {
-		panic(fmt.Sprintf("main panel: controller start: %s", err))
+	if err := ctrl.Start(ctx, p.app.Resync()); err != nil {
+		return fmt.Errorf("main panel: controller start: %w", err)
 	}
+	go p.showCountdowns(ctx)
 	return nil
 }
 
+// showCountdowns updates, until ctx is done, the refresh countdowns of the panel titles
+func (p *MainPanel) showCountdowns(ctx context.Context) {
+	ticker := time.NewTicker(countdownInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.app.QueueUpdateDraw(func() {
+				p.podPanel.drawCountdown()
+				p.clusterSummaryPanel.drawCountdown()
+			})
+		}
+	}
+}
+
 func (p *MainPanel) refreshNodeView(ctx context.Context, models []model.NodeModel) error {
 	model.SortNodeModels(models)
 
-	p.nodePanel.Clear()
-	p.nodePanel.DrawBody(models)
+	p.mu.Lock()
+	p.lastNodes = models
+	p.mu.Unlock()
+	if p.app.GetK8sClient().AssertMetricsAvailable() == nil {
+		p.nodePanel.history.record(models)
+	}
+	p.nodePanel.alerts.record(models, time.Now())
+	p.app.SetAlert(p.nodePanel.alerts.banner())
+
+	p.drawNodes()
 
 	// required: always schedule screen refresh
 	if p.refresh != nil {
@@ -135,9 +280,18 @@ func (p *MainPanel) refreshNodeView(ctx context.Context, models []model.NodeMode
 func (p *MainPanel) refreshPods(ctx context.Context, models []model.PodModel) error {
 	model.SortPodModels(models)
 
+	p.mu.Lock()
+	p.restarts.record(models, time.Now())
+	p.lastPods = models
+	p.mu.Unlock()
+	if p.app.GetK8sClient().AssertMetricsAvailable() == nil {
+		p.podPanel.flashes.record(models, time.Now())
+	}
+	p.podPanel.countdown.mark(time.Now())
+
 	// refresh pod list
-	p.podPanel.Clear()
-	p.podPanel.DrawBody(models)
+	p.drawPods()
+	p.refreshFocus(ctx, models)
 
 	// required: always refresh screen
 	if p.refresh != nil {
@@ -146,15 +300,448 @@ func (p *MainPanel) refreshPods(ctx context.Context, models []model.PodModel) er
 	return nil
 }
 
+// toggleSummary expands, or collapses, the cluster summary panel
+func (p *MainPanel) toggleSummary() {
+	state := p.app.State()
+	state.SummaryExpanded = !state.SummaryExpanded
+	height := p.clusterSummaryPanel.setExpanded(state.SummaryExpanded)
+	p.root.ResizeItem(p.clusterSummaryPanel.GetRootView(), height, 1)
+}
+
+// showPodFocus dedicates the page to the selected pod until the view is closed
+func (p *MainPanel) showPodFocus() {
+	pod, ok := p.podPanel.selectedPod()
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	models := p.lastPods
+	p.mu.Unlock()
+
+	focus := newPodFocus(p.app, pod.Namespace, pod.Name)
+	focus.draw(focus.collect(p.ctx, p.app.GetK8sClient().Controller(), models))
+	p.mu.Lock()
+	p.focus = focus
+	p.mu.Unlock()
+	p.app.ShowNamedModal(fmt.Sprintf("focus pod/%s/%s", pod.Namespace, pod.Name), focus.root)
+}
+
+// refreshFocus updates the pod focus view, and the detail pane, if shown, with the latest pod models
+func (p *MainPanel) refreshFocus(ctx context.Context, models []model.PodModel) {
+	p.mu.Lock()
+	focus, split := p.focus, p.split
+	p.mu.Unlock()
+	if split != nil {
+		p.drawSplit(split, models, false)
+	}
+	if focus == nil {
+		return
+	}
+
+	data := focus.collect(ctx, p.app.GetK8sClient().Controller(), models)
+	p.app.QueueUpdateDraw(func() {
+		if !p.app.ModalShown(focus.root) {
+			p.mu.Lock()
+			if p.focus == focus {
+				p.focus = nil // view closed
+			}
+			p.mu.Unlock()
+			return
+		}
+		focus.draw(data)
+	})
+}
+
 func (p *MainPanel) refreshWorkloadSummary(ctx context.Context, summary model.ClusterSummary) error {
+	p.mu.Lock()
+	p.lastSummary = summary
+	p.mu.Unlock()
+
+	p.clusterSummaryPanel.countdown.mark(time.Now())
 	p.clusterSummaryPanel.Clear()
 	p.clusterSummaryPanel.DrawBody(summary)
+	p.clusterSummaryPanel.drawCountdown()
+	// the requested graphs are added below the used graphs once the metrics are available
+	p.root.ResizeItem(p.clusterSummaryPanel.GetRootView(), p.clusterSummaryPanel.height(), 1)
 	if p.refresh != nil {
 		p.refresh()
 	}
 	return nil
 }
 
+// drawNodes redraws the latest nodes with the current sort
+func (p *MainPanel) drawNodes() {
+	p.mu.Lock()
+	nodes := make([]model.NodeModel, len(p.lastNodes))
+	copy(nodes, p.lastNodes)
+	spec, nodeOS := p.nodeSort, p.nodeOS
+	p.mu.Unlock()
+
+	nodes = filterNodesByOS(nodes, nodeOS)
+	sortNodes(nodes, spec)
+	p.updateStatus()
+	var info []string
+	if nodeOS != "" {
+		info = append(info, "os: "+nodeOS)
+	}
+	if sortInfo := sortInfo(spec); sortInfo != "" {
+		info = append(info, "sort: "+sortInfo)
+	}
+	p.nodePanel.info = strings.Join(info, ", ")
+	p.nodePanel.Clear()
+	p.nodePanel.DrawBody(nodes)
+	if len(nodes) > 0 {
+		p.restoreSession(false, true)
+	}
+}
+
+// drawPods redraws the latest pods with the current filter and sort
+func (p *MainPanel) drawPods() {
+	p.mu.Lock()
+	pods := make([]model.PodModel, len(p.lastPods))
+	copy(pods, p.lastPods)
+	spec, filter := p.podSort, p.podFilter
+	p.mu.Unlock()
+
+	all := pods
+	pods = filterPods(all, filter)
+	sortPods(pods, spec)
+
+	p.mu.Lock()
+	p.podsMatched = len(pods)
+	p.mu.Unlock()
+	p.updateStatus()
+	p.podPanel.pruneMarks(all)
+	pods = pinPods(all, pods, p.podPanel.pinnedKeys())
+
+	var info []string
+	if filter != "" {
+		info = append(info, "filter: "+tview.Escape(filter))
+	}
+	if sort := sortInfo(spec); sort != "" {
+		info = append(info, "sort: "+sort)
+	}
+	p.podPanel.info = strings.Join(info, ", ")
+	p.podPanel.Clear()
+	p.podPanel.DrawBody(pods)
+	if len(pods) > 0 {
+		p.restoreSession(true, false)
+	}
+}
+
+// updateStatus displays the pod filter, sort columns, and matched vs total pod counts
+// in the application status bar
+func (p *MainPanel) updateStatus() {
+	p.mu.Lock()
+	filter, podSort, nodeSort := p.podFilter, p.podSort, p.nodeSort
+	matched, total := p.podsMatched, len(p.lastPods)
+	p.mu.Unlock()
+
+	if filter == "" {
+		filter = "none"
+	}
+	podSortInfo, nodeSortInfo := sortInfo(podSort), sortInfo(nodeSort)
+	if podSortInfo == "" {
+		podSortInfo = "NAMESPACE/POD"
+	}
+	if nodeSortInfo == "" {
+		nodeSortInfo = "NAME"
+	}
+
+	p.app.SetStatus(fmt.Sprintf(
+		"[green]filter: [white]%s  [green]pod sort: [white]%s  [green]node sort: [white]%s  [green]pods: [white]%d/%d",
+		tview.Escape(filter), podSortInfo, nodeSortInfo, matched, total,
+	))
+}
+
+// sortNodesBy sorts the nodes by column, or by secondary column, reversing the direction when already sorted by column
+func (p *MainPanel) sortNodesBy(column string, secondary bool) {
+	p.mu.Lock()
+	if secondary {
+		p.nodeSort = toggleSecondarySort(p.nodeSort, column)
+	} else {
+		p.nodeSort = toggleSort(p.nodeSort, column)
+	}
+	p.app.State().NodeSort = p.nodeSort
+	p.mu.Unlock()
+	p.drawNodes()
+}
+
+// sortPodsBy sorts the pods by column, or by secondary column, reversing the direction when already sorted by column
+func (p *MainPanel) sortPodsBy(column string, secondary bool) {
+	p.mu.Lock()
+	if secondary {
+		p.podSort = toggleSecondarySort(p.podSort, column)
+	} else {
+		p.podSort = toggleSort(p.podSort, column)
+	}
+	p.app.State().PodSort = p.podSort
+	p.mu.Unlock()
+	p.drawPods()
+}
+
+// showPodFilter prompts for the text used to filter pods by namespace/name, or by
+// regular expression when prefixed with ~ (empty to clear)
+func (p *MainPanel) showPodFilter() {
+	p.mu.Lock()
+	filter := p.podFilter
+	p.mu.Unlock()
+
+	p.app.ShowInput("Filter pods by namespace/name, or ~regex for pod names", "/", filter, func(text string) {
+		text = strings.TrimSpace(text)
+		if _, err := podMatcher(text); err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Invalid filter %s: %s", text, err))
+			return
+		}
+		p.mu.Lock()
+		p.podFilter = text
+		p.app.State().PodFilter = p.podFilter
+		p.mu.Unlock()
+		p.drawPods()
+	})
+}
+
+// cycleNodeOS filters the nodes by the next operating system of the nodes, in name
+// order, then shows all the nodes again
+func (p *MainPanel) cycleNodeOS() {
+	p.mu.Lock()
+	p.nodeOS = nextNodeOS(p.lastNodes, p.nodeOS)
+	p.app.State().NodeOS = p.nodeOS
+	p.mu.Unlock()
+	p.drawNodes()
+}
+
+// toggleIPFamily lists the IPv6, or IPv4, pod IPs first in the IP column
+func (p *MainPanel) toggleIPFamily() {
+	p.podPanel.ipv6 = !p.podPanel.ipv6
+	p.app.State().PreferIPv6 = p.podPanel.ipv6
+	p.drawPods()
+}
+
+// toggleMetricStyle displays the CPU and memory cells of the pods and nodes in style, or
+// restores the graphs when style is already displayed
+func (p *MainPanel) toggleMetricStyle(style metricStyle) {
+	style = p.podPanel.style.toggle(style)
+	p.app.State().MetricStyle = style.String()
+	p.podPanel.style = style
+	p.nodePanel.style = style
+	p.podPanel.DrawHeader(p.podPanel.listCols)
+	p.nodePanel.DrawHeader(p.nodePanel.listCols)
+	p.drawPods()
+	p.drawNodes()
+}
+
+// cycleUsageBase compares the pod CPU and memory usage to the next usage base: the pod
+// requests, the pod limits, or the allocatable resources of the pod node
+func (p *MainPanel) cycleUsageBase() {
+	p.podPanel.base = p.podPanel.base.next()
+	p.app.State().UsageBase = p.podPanel.base.String()
+	p.podPanel.DrawHeader(p.podPanel.listCols)
+	p.drawPods()
+	p.app.Notify(fmt.Sprintf("Pod CPU and memory usage percentages of the %s", p.podPanel.base.description()))
+}
+
+// toggleNodeCapacity compares the node usage, in the nodes and summary panels, to the node
+// capacity, showing the resources reserved for the system, or to the allocatable resources
+func (p *MainPanel) toggleNodeCapacity() {
+	state := p.app.State()
+	state.NodeCapacity = !state.NodeCapacity
+	p.nodePanel.capacity = state.NodeCapacity
+	p.clusterSummaryPanel.capacity = state.NodeCapacity
+	p.nodePanel.DrawHeader(p.nodePanel.listCols)
+	p.drawNodes()
+	p.mu.Lock()
+	summary := p.lastSummary
+	p.mu.Unlock()
+	if summary.AllocatableNodeCpuTotal != nil {
+		p.clusterSummaryPanel.DrawBody(summary)
+	}
+}
+
+// HandleInput handles the overview page key bindings (see keyBindings)
+func (p *MainPanel) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	scope := p.focusedScope()
+	for _, binding := range p.bindings {
+		if binding.matches(event, scope) {
+			binding.do()
+			// '+' applies to the next key only, whether a sort key or not
+			if binding.ch != '+' {
+				p.secondarySortKey = false
+			}
+			return nil
+		}
+	}
+	p.secondarySortKey = false
+	return event
+}
+
+// cleanupFailedPods deletes, after confirmation, the Evicted/Failed pods in the current namespace
+func (p *MainPanel) cleanupFailedPods() {
+	if !p.writable() {
+		return
+	}
+	ctrl := p.app.GetK8sClient().Controller()
+	pods, err := ctrl.GetFailedPods(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list pods: %s", err))
+		return
+	}
+
+	namespace := p.app.GetK8sClient().Namespace()
+	if namespace == "" {
+		namespace = "all namespaces"
+	}
+	if len(pods) == 0 {
+		p.app.ShowMessage(fmt.Sprintf("No Evicted/Failed pods found in %s", namespace))
+		return
+	}
+
+	const maxPreview = 5
+	var preview []string
+	for i, pod := range pods {
+		if i == maxPreview {
+			preview = append(preview, fmt.Sprintf("... and %d more", len(pods)-maxPreview))
+			break
+		}
+		preview = append(preview, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+	}
+	msg := fmt.Sprintf("Delete %d Evicted/Failed pod(s) in %s?\n\n%s", len(pods), namespace, strings.Join(preview, "\n"))
+
+	p.app.ShowConfirm(msg, "Delete", func() {
+		go func() {
+			var deleted int
+			var errs []string
+			for _, pod := range pods {
+				if err := ctrl.DeletePod(p.ctx, pod.Namespace, pod.Name); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %s", pod.Name, err))
+					continue
+				}
+				deleted++
+			}
+			result := fmt.Sprintf("Deleted %d of %d pod(s)", deleted, len(pods))
+			if len(errs) > 0 {
+				result = fmt.Sprintf("%s\n\n%s", result, strings.Join(errs, "\n"))
+			}
+			p.app.QueueUpdateDraw(func() {
+				p.app.ShowMessage(result)
+			})
+		}()
+	})
+}
+
+// describeSelected displays the description of the pod or node selected in the focused panel
+func (p *MainPanel) describeSelected() {
+	ctrl := p.app.GetK8sClient().Controller()
+	switch {
+	case p.podPanel.list.HasFocus():
+		pod, ok := p.podPanel.selectedPod()
+		if !ok {
+			return
+		}
+		obj, err := ctrl.GetPod(p.ctx, pod.Namespace, pod.Name)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Failed to get pod %s: %s", pod.Name, err))
+			return
+		}
+		events, _ := ctrl.GetObjectEvents(p.ctx, obj.Namespace, obj.UID)
+		name := fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)
+		detail.ShowText(p.app, name, detail.DescribePod(obj, events), yamlAction(p.app, name, obj))
+	case p.nodePanel.list.HasFocus():
+		node, ok := p.nodePanel.selectedNode()
+		if !ok {
+			return
+		}
+		obj, err := ctrl.GetNode(p.ctx, node.Name)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Failed to get node %s: %s", node.Name, err))
+			return
+		}
+		pods, _ := ctrl.GetNodePods(p.ctx, node.Name)
+		events, _ := ctrl.GetObjectEvents(p.ctx, "", obj.UID)
+		// the swap settings and usage are read from the kubelet, off the UI goroutine
+		go func() {
+			ctx, cancel := context.WithTimeout(p.ctx, nodeSwapTimeout)
+			defer cancel()
+			swap, _ := ctrl.GetNodeSwap(ctx, node.Name)
+			p.app.QueueUpdateDraw(func() {
+				name := fmt.Sprintf("node/%s", node.Name)
+				actions := append([]detail.Action{yamlAction(p.app, name, obj)}, p.nodeActions(node.Name)...)
+				detail.ShowText(p.app, name, detail.DescribeNode(obj, pods, events, swap), actions...)
+			})
+		}()
+	}
+}
+
+// yamlAction returns the description action showing the manifest of obj, a drill-down
+// the navigation history goes back from
+func yamlAction(app *application.Application, name string, obj runtime.Object) detail.Action {
+	return detail.Action{Key: 'y', Name: "YAML", Do: func() { detail.ShowYAML(app, name, obj) }}
+}
+
+// showSelectedYAML displays the manifest of the pod or node selected in the focused panel
+func (p *MainPanel) showSelectedYAML() {
+	ctrl := p.app.GetK8sClient().Controller()
+	switch {
+	case p.podPanel.list.HasFocus():
+		pod, ok := p.podPanel.selectedPod()
+		if !ok {
+			return
+		}
+		obj, err := ctrl.GetPod(p.ctx, pod.Namespace, pod.Name)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Failed to get pod %s: %s", pod.Name, err))
+			return
+		}
+		detail.ShowYAML(p.app, fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name), obj)
+	case p.nodePanel.list.HasFocus():
+		node, ok := p.nodePanel.selectedNode()
+		if !ok {
+			return
+		}
+		obj, err := ctrl.GetNode(p.ctx, node.Name)
+		if err != nil {
+			p.app.ShowMessage(fmt.Sprintf("Failed to get node %s: %s", node.Name, err))
+			return
+		}
+		detail.ShowYAML(p.app, fmt.Sprintf("node/%s", node.Name), obj)
+	}
+}
+
+// snapshot returns a snapshot of the latest models
+func (p *MainPanel) snapshot() snapshot.Snapshot {
+	client := p.app.GetK8sClient()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return snapshot.Snapshot{
+		Time:             time.Now(),
+		Server:           client.RESTConfig().Host,
+		Context:          client.ClusterContext(),
+		Namespace:        client.Namespace(),
+		MetricsAvailable: client.AssertMetricsAvailable() == nil,
+		Summary:          p.lastSummary,
+		Nodes:            p.lastNodes,
+		Pods:             p.lastPods,
+	}
+}
+
+// exportHTML writes the current overview to an HTML file in the working directory
+func (p *MainPanel) exportHTML() {
+	snap := p.snapshot()
+	fileName := snap.FileName("html")
+	file, err := os.Create(fileName)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+		return
+	}
+	defer file.Close()
+
+	if err := snapshot.WriteHTML(file, snap); err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+		return
+	}
+	p.app.Notify(fmt.Sprintf("Overview exported to %s", fileName))
+}
+
 // filterColumns filters the allColumns based on the user-provided filterCols
 // It returns a slice of columns that match the case-insensitive filter
 func filterColumns(allColumns []string, filterCols []string) []string {
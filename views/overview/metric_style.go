@@ -0,0 +1,75 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// metricStyle is the display of the CPU and memory cells of the pods and nodes panels
+type metricStyle int
+
+const (
+	styleGraph   metricStyle = iota // bar graph, values, and percentage (default)
+	stylePercent                    // colored usage percentage only
+	styleNumeric                    // right-aligned usage, request, and limit (or allocatable) values
+)
+
+// metricStyleNames are the names of the metric styles, saved in the session state
+var metricStyleNames = []string{"graph", "percent", "numeric"}
+
+// numericWidth is the width of the values of the numeric metric cells
+const numericWidth = 8
+
+// parseMetricStyle returns the metric style named name, the graphs for an unknown name
+func parseMetricStyle(name string) metricStyle {
+	for i, styleName := range metricStyleNames {
+		if styleName == name {
+			return metricStyle(i)
+		}
+	}
+	return styleGraph
+}
+
+func (s metricStyle) String() string {
+	return metricStyleNames[s]
+}
+
+// toggle returns style, or the graphs when s is already style
+func (s metricStyle) toggle(style metricStyle) metricStyle {
+	if s == style {
+		return styleGraph
+	}
+	return style
+}
+
+// align returns the alignment of the CPU and memory cells: numeric cells are right-aligned
+func (s metricStyle) align() int {
+	if s == styleNumeric {
+		return tview.AlignRight
+	}
+	return tview.AlignLeft
+}
+
+// numericHeader returns the header of the numeric CPU, or memory, column col: col followed by
+// the labels of the values, right-aligned like the values, i.e. "CPU      USE      REQ      LIM"
+func numericHeader(col string, labels ...string) string {
+	header := col
+	for _, label := range labels {
+		header += fmt.Sprintf(" %*s", numericWidth, label)
+	}
+	return header
+}
+
+// numericMetrics returns the usage, request, and limit (or allocatable) values of a numeric
+// cell, right-aligned in fixed-width fields; an unset request, or limit, is shown as "-"
+func numericMetrics(values []string, request, limit *resource.Quantity) string {
+	if request.IsZero() {
+		values[1] = "-"
+	}
+	if limit.IsZero() {
+		values[2] = "-"
+	}
+	return fmt.Sprintf("%*s %*s %*s", numericWidth, values[0], numericWidth, values[1], numericWidth, values[2])
+}
@@ -0,0 +1,54 @@
+package overview
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNumericMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		request  string
+		limit    string
+		expected string
+	}{
+		{name: "request and limit", values: []string{"250m", "100m", "500m"}, request: "100m", limit: "500m", expected: "    250m     100m     500m"},
+		{name: "no limit", values: []string{"512Mi", "1024Mi", "0Mi"}, request: "1Gi", limit: "0", expected: "   512Mi   1024Mi        -"},
+		{name: "no request nor limit", values: []string{"1m", "0m", "0m"}, request: "0", limit: "0", expected: "      1m        -        -"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			request, limit := resource.MustParse(tc.request), resource.MustParse(tc.limit)
+			if result := numericMetrics(tc.values, &request, &limit); result != tc.expected {
+				t.Fatalf("expecting [%s], got [%s]", tc.expected, result)
+			}
+		})
+	}
+
+	if header := numericHeader("CPU", "USE", "REQ", "LIM"); header != "CPU      USE      REQ      LIM" {
+		t.Fatalf("unexpected numeric header [%s]", header)
+	}
+}
+
+func TestToggleMetricStyle(t *testing.T) {
+	style := parseMetricStyle("")
+	if style != styleGraph {
+		t.Fatalf("expecting the graph style by default, got %s", style)
+	}
+	if style = style.toggle(stylePercent); style != stylePercent {
+		t.Fatalf("expecting the percent style, got %s", style)
+	}
+	if style = style.toggle(styleNumeric); style != styleNumeric {
+		t.Fatalf("expecting the numeric style, got %s", style)
+	}
+	if style = style.toggle(styleNumeric); style != styleGraph {
+		t.Fatalf("expecting the graph style restored, got %s", style)
+	}
+	if style = parseMetricStyle(stylePercent.String()); style != stylePercent {
+		t.Fatalf("expecting the percent style, got %s", style)
+	}
+}
@@ -0,0 +1,66 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// showNamespaceLifecycle displays the Terminating namespaces, flagging the stuck ones with the
+// finalizers blocking their deletion, and the recently created namespaces.
+func (p *MainPanel) showNamespaceLifecycle() {
+	namespaces, err := p.app.GetK8sClient().Controller().GetNamespaceLifecycle(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list namespaces: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "STATUS", "AGE", "TERMINATING FOR", "FINALIZERS", "BLOCKED BY"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	now := time.Now()
+	stuck := 0
+	for i, ns := range namespaces {
+		status, color := "New", tcell.ColorGreen
+		terminatingFor := "-"
+		switch {
+		case ns.Stuck:
+			status, color = "Stuck Terminating", tcell.ColorRed
+			stuck++
+		case ns.Terminating():
+			status, color = "Terminating", tcell.ColorOrange
+		}
+		if ns.Terminating() {
+			terminatingFor = duration.HumanDuration(now.Sub(ns.DeletionTime))
+		}
+		values := []string{
+			ns.Name,
+			status,
+			ns.Age,
+			terminatingFor,
+			valueOrDash(strings.Join(ns.Finalizers, ", ")),
+			valueOrDash(strings.Join(ns.Blockers, "; ")),
+		}
+		for j, val := range values {
+			table.SetCell(i+1, j, tview.NewTableCell(val).SetTextColor(color).SetExpansion(100))
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Namespace lifecycle (%d, %d stuck Terminating) (Esc: close) ", len(namespaces), stuck))
+	p.app.ShowNamedModal("namespaces", table)
+}
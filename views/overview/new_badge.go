@@ -0,0 +1,24 @@
+package overview
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// podNameBadge returns the POD column text and color: the pod name badged "(restarted)", in
+// orange, when a container restarted within window, or "(new)", in green, when the pod was
+// created within window, so the churn of a rollout or a crash loop stands out
+func podNameBadge(pod model.PodModel, now time.Time, window time.Duration) (string, tcell.Color) {
+	switch {
+	case window <= 0:
+		return pod.Name, tcell.ColorYellow
+	case !pod.LastRestart.IsZero() && now.Sub(pod.LastRestart.Time) < window:
+		return pod.Name + " (restarted)", tcell.ColorOrange
+	case !pod.CreationTime.IsZero() && now.Sub(pod.CreationTime.Time) < window:
+		return pod.Name + " (new)", tcell.ColorGreen
+	default:
+		return pod.Name, tcell.ColorYellow
+	}
+}
@@ -0,0 +1,51 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodNameBadge(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+
+	tests := []struct {
+		name     string
+		pod      model.PodModel
+		window   time.Duration
+		expected string
+		color    tcell.Color
+	}{
+		{name: "steady", pod: model.PodModel{Name: "web-0", CreationTime: ago(time.Hour)}, window: 5 * time.Minute, expected: "web-0", color: tcell.ColorYellow},
+		{name: "new", pod: model.PodModel{Name: "web-0", CreationTime: ago(time.Minute)}, window: 5 * time.Minute, expected: "web-0 (new)", color: tcell.ColorGreen},
+		{
+			name:     "restarted",
+			pod:      model.PodModel{Name: "web-0", CreationTime: ago(time.Minute), LastRestart: ago(30 * time.Second)},
+			window:   5 * time.Minute,
+			expected: "web-0 (restarted)",
+			color:    tcell.ColorOrange,
+		},
+		{
+			name:     "restarted before window",
+			pod:      model.PodModel{Name: "web-0", CreationTime: ago(time.Hour), LastRestart: ago(10 * time.Minute)},
+			window:   5 * time.Minute,
+			expected: "web-0",
+			color:    tcell.ColorYellow,
+		},
+		{name: "disabled", pod: model.PodModel{Name: "web-0", CreationTime: ago(time.Minute)}, expected: "web-0", color: tcell.ColorYellow},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			text, color := podNameBadge(tc.pod, now, tc.window)
+			if text != tc.expected || color != tc.color {
+				t.Errorf("expected %q (%v), got %q (%v)", tc.expected, tc.color, text, color)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package overview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// Node states tracked by the node alerts
+const (
+	nodeStateReady       = "Ready"
+	nodeStateNotReady    = "NotReady"
+	nodeStateUnreachable = "Unreachable"
+)
+
+// nodeAlert is a node transition, from ready, to NotReady or unreachable observed during the session
+type nodeAlert struct {
+	state string
+	since time.Time
+}
+
+// nodeAlertTracker keeps the last observed state of each node in order to alert on the
+// nodes that became NotReady, or unreachable, during the session, until they recover.
+type nodeAlertTracker struct {
+	mu     sync.Mutex
+	states map[string]string    // last observed state, by node name
+	alerts map[string]nodeAlert // nodes not ready since a transition, by node name
+}
+
+func newNodeAlertTracker() *nodeAlertTracker {
+	return &nodeAlertTracker{states: make(map[string]string), alerts: make(map[string]nodeAlert)}
+}
+
+// nodeState returns the readiness state of node
+func nodeState(node model.NodeModel) string {
+	switch {
+	case node.Unreachable:
+		return nodeStateUnreachable
+	case node.Status != nodeStateReady:
+		return nodeStateNotReady
+	}
+	return nodeStateReady
+}
+
+// record adds the states of nodes, observed at now: a node leaving the ready state raises
+// an alert, at the time of its Ready condition transition, cleared once the node is ready
+// again. Nodes not ready when first observed, i.e. at startup, raise no alert.
+func (t *nodeAlertTracker) record(nodes []model.NodeModel, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	states := make(map[string]string, len(nodes))
+	alerts := make(map[string]nodeAlert)
+	for _, node := range nodes {
+		state := nodeState(node)
+		states[node.Name] = state
+		if state == nodeStateReady {
+			continue
+		}
+		if alert, ok := t.alerts[node.Name]; ok {
+			alerts[node.Name] = nodeAlert{state: state, since: alert.since}
+			continue
+		}
+		if t.states[node.Name] == nodeStateReady {
+			since := node.ReadyTransition.Time
+			if since.IsZero() {
+				since = now
+			}
+			alerts[node.Name] = nodeAlert{state: state, since: since}
+		}
+	}
+	t.states, t.alerts = states, alerts
+}
+
+// alert returns the alert of the node name, if any
+func (t *nodeAlertTracker) alert(name string) (nodeAlert, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	alert, ok := t.alerts[name]
+	return alert, ok
+}
+
+// banner returns the text of the alerted nodes, earliest first, i.e.
+// "node worker-2 NotReady since 10:42:05", empty without alerts
+func (t *nodeAlertTracker) banner() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.alerts))
+	for name := range t.alerts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := t.alerts[names[i]], t.alerts[names[j]]
+		if !a.since.Equal(b.since) {
+			return a.since.Before(b.since)
+		}
+		return names[i] < names[j]
+	})
+
+	var nodes []string
+	for _, name := range names {
+		alert := t.alerts[name]
+		nodes = append(nodes, fmt.Sprintf("%s %s since %s", name, alert.state, alert.since.Local().Format("15:04:05")))
+	}
+	if len(nodes) == 0 {
+		return ""
+	}
+	return "node " + strings.Join(nodes, ", ")
+}
@@ -0,0 +1,42 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeAlertTracker(t *testing.T) {
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local)
+	transition := metav1.NewTime(start.Add(30 * time.Second))
+	ready := model.NodeModel{Name: "worker-1", Status: "Ready"}
+	notReady := model.NodeModel{Name: "worker-1", Status: "NotReady", ReadyTransition: transition}
+	unreachable := model.NodeModel{Name: "worker-1", Status: "NotReady", Unreachable: true, ReadyTransition: transition}
+
+	tracker := newNodeAlertTracker()
+	tracker.record([]model.NodeModel{ready, {Name: "worker-2", Status: "NotReady"}}, start)
+	if banner := tracker.banner(); banner != "" {
+		t.Fatalf("expecting no alert for nodes not ready at startup, got %q", banner)
+	}
+
+	tracker.record([]model.NodeModel{notReady}, start.Add(time.Minute))
+	alert, ok := tracker.alert("worker-1")
+	if !ok || alert.state != nodeStateNotReady || !alert.since.Equal(transition.Time) {
+		t.Fatalf("expecting a NotReady alert since %s, got %+v (%v)", transition, alert, ok)
+	}
+	if banner, expected := tracker.banner(), "node worker-1 NotReady since 10:00:30"; banner != expected {
+		t.Fatalf("expecting banner %q, got %q", expected, banner)
+	}
+
+	tracker.record([]model.NodeModel{unreachable}, start.Add(2*time.Minute))
+	if alert, _ := tracker.alert("worker-1"); alert.state != nodeStateUnreachable || !alert.since.Equal(transition.Time) {
+		t.Fatalf("expecting an Unreachable alert since %s, got %+v", transition, alert)
+	}
+
+	tracker.record([]model.NodeModel{ready}, start.Add(3*time.Minute))
+	if _, ok := tracker.alert("worker-1"); ok {
+		t.Fatal("expecting the alert cleared once the node is ready")
+	}
+}
@@ -0,0 +1,36 @@
+package overview
+
+import (
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// nodeBaseQtys returns the CPU and memory the usage, and requests, of node are compared to:
+// the node capacity with capacity set, or else the allocatable resources (the memory capacity
+// of Windows nodes, see NodeModel.MemoryBaseQty)
+func nodeBaseQtys(node model.NodeModel, capacity bool) (cpu, mem *resource.Quantity) {
+	if capacity {
+		return qtyOrZero(node.CapacityCpuQty), qtyOrZero(node.CapacityMemQty)
+	}
+	return qtyOrZero(node.AllocatableCpuQty), qtyOrZero(node.MemoryBaseQty())
+}
+
+// reservedQty returns the resources reserved for the system and the kubelet (system-reserved,
+// kube-reserved, and the eviction threshold): capacity minus allocatable, zero when unknown
+func reservedQty(capacity, allocatable *resource.Quantity) *resource.Quantity {
+	reserved := qtyOrZero(capacity).DeepCopy()
+	reserved.Sub(*qtyOrZero(allocatable))
+	if reserved.Sign() < 0 {
+		return resource.NewQuantity(0, resource.DecimalSI)
+	}
+	return &reserved
+}
+
+// capacityHeader returns the header of the CPU, or memory, column col of the nodes: col, or
+// col/CAP when the usage is compared to the node capacity
+func capacityHeader(col string, capacity bool) string {
+	if capacity {
+		return col + "/CAP"
+	}
+	return col
+}
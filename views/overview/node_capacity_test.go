@@ -0,0 +1,67 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNodeBaseQtys(t *testing.T) {
+	qty := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+	tests := []struct {
+		name     string
+		node     model.NodeModel
+		capacity bool
+		cpu      string
+		mem      string
+		reserved string
+	}{
+		{
+			name:     "allocatable",
+			node:     model.NodeModel{AllocatableCpuQty: qty("3800m"), AllocatableMemQty: qty("15Gi"), CapacityCpuQty: qty("4"), CapacityMemQty: qty("16Gi")},
+			cpu:      "3800m",
+			mem:      "15Gi",
+			reserved: "200m",
+		},
+		{
+			name:     "capacity",
+			node:     model.NodeModel{AllocatableCpuQty: qty("3800m"), AllocatableMemQty: qty("15Gi"), CapacityCpuQty: qty("4"), CapacityMemQty: qty("16Gi")},
+			capacity: true,
+			cpu:      "4",
+			mem:      "16Gi",
+			reserved: "200m",
+		},
+		{
+			name:     "windows memory capacity",
+			node:     model.NodeModel{OS: "windows", AllocatableCpuQty: qty("2"), AllocatableMemQty: qty("7Gi"), CapacityCpuQty: qty("2"), CapacityMemQty: qty("8Gi")},
+			cpu:      "2",
+			mem:      "8Gi",
+			reserved: "0",
+		},
+		{
+			name:     "unknown capacity",
+			node:     model.NodeModel{AllocatableCpuQty: qty("2"), AllocatableMemQty: qty("8Gi")},
+			capacity: true,
+			cpu:      "0",
+			mem:      "0",
+			reserved: "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			cpu, mem := nodeBaseQtys(tc.node, tc.capacity)
+			if cpu.Cmp(resource.MustParse(tc.cpu)) != 0 || mem.Cmp(resource.MustParse(tc.mem)) != 0 {
+				t.Fatalf("expecting %s and %s, got %s and %s", tc.cpu, tc.mem, cpu, mem)
+			}
+			if reserved := reservedQty(tc.node.CapacityCpuQty, tc.node.AllocatableCpuQty); reserved.Cmp(resource.MustParse(tc.reserved)) != 0 {
+				t.Fatalf("expecting %s CPU reserved, got %s", tc.reserved, reserved)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/detail"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// nodeActions returns the node maintenance actions of the node detail view,
+// none when ktop runs in read-only mode.
+func (p *MainPanel) nodeActions(nodeName string) []detail.Action {
+	if p.app.ReadOnly() {
+		return nil
+	}
+	return []detail.Action{
+		{Key: 'l', Name: "label", Do: func() { p.labelNode(nodeName) }},
+		{Key: 't', Name: "taint", Do: func() { p.taintNode(nodeName) }},
+	}
+}
+
+// labelNode prompts for labels (key=value to set, key- to remove) then applies them, after confirmation, to the node
+func (p *MainPanel) labelNode(nodeName string) {
+	if !p.writable() {
+		return
+	}
+	p.app.ShowInput(fmt.Sprintf("Label node/%s", nodeName), "key=value key-: ", "", func(text string) {
+		labels, err := parseLabelArgs(text)
+		if err != nil {
+			p.app.ShowMessage(err.Error())
+			return
+		}
+		msg := fmt.Sprintf("Update labels of node %s?\n\n%s", nodeName, strings.Join(strings.Fields(text), "\n"))
+		p.app.ShowConfirm(msg, "Apply", func() {
+			ctrl := p.app.GetK8sClient().Controller()
+			p.applyToNode(nodeName, "labels", func() error {
+				return ctrl.LabelNode(p.ctx, nodeName, labels)
+			})
+		})
+	})
+}
+
+// taintNode prompts for taints (key=value:Effect to add, key:Effect- to remove) then applies them, after confirmation, to the node
+func (p *MainPanel) taintNode(nodeName string) {
+	if !p.writable() {
+		return
+	}
+	p.app.ShowInput(fmt.Sprintf("Taint node/%s", nodeName), "key=value:Effect key:Effect-: ", "", func(text string) {
+		add, remove, err := parseTaintArgs(text)
+		if err != nil {
+			p.app.ShowMessage(err.Error())
+			return
+		}
+		msg := fmt.Sprintf("Update taints of node %s?\n\n%s", nodeName, strings.Join(strings.Fields(text), "\n"))
+		p.app.ShowConfirm(msg, "Apply", func() {
+			ctrl := p.app.GetK8sClient().Controller()
+			p.applyToNode(nodeName, "taints", func() error {
+				return ctrl.TaintNode(p.ctx, nodeName, add, remove)
+			})
+		})
+	})
+}
+
+// applyToNode runs action in the background then reports the result
+func (p *MainPanel) applyToNode(nodeName, what string, action func() error) {
+	go func() {
+		result := fmt.Sprintf("Updated %s of node %s", what, nodeName)
+		if err := action(); err != nil {
+			result = fmt.Sprintf("Failed to update %s of node %s: %s", what, nodeName, err)
+		}
+		p.app.QueueUpdateDraw(func() {
+			p.app.ShowMessage(result)
+		})
+	}()
+}
+
+// parseTaintArgs parses space separated, kubectl style, taint arguments:
+// key[=value]:Effect adds a taint and key[:Effect]- removes the matching taints.
+func parseTaintArgs(text string) (add, remove []coreV1.Taint, err error) {
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("no taints specified")
+	}
+	for _, arg := range args {
+		removal := strings.HasSuffix(arg, "-")
+		spec := strings.TrimSuffix(arg, "-")
+
+		var taint coreV1.Taint
+		if i := strings.LastIndex(spec, ":"); i >= 0 {
+			taint.Effect = coreV1.TaintEffect(spec[i+1:])
+			spec = spec[:i]
+		}
+		if i := strings.Index(spec, "="); i >= 0 {
+			taint.Value = spec[i+1:]
+			spec = spec[:i]
+		}
+		taint.Key = spec
+
+		if taint.Key == "" {
+			return nil, nil, fmt.Errorf("invalid taint %q: missing key", arg)
+		}
+		switch taint.Effect {
+		case coreV1.TaintEffectNoSchedule, coreV1.TaintEffectPreferNoSchedule, coreV1.TaintEffectNoExecute:
+		case "":
+			if !removal {
+				return nil, nil, fmt.Errorf("invalid taint %q: missing effect", arg)
+			}
+		default:
+			return nil, nil, fmt.Errorf("invalid taint %q: unsupported effect %s", arg, taint.Effect)
+		}
+
+		if removal {
+			remove = append(remove, taint)
+		} else {
+			add = append(add, taint)
+		}
+	}
+	return add, remove, nil
+}
@@ -0,0 +1,70 @@
+package overview
+
+import (
+	"reflect"
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestParseTaintArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		add       []coreV1.Taint
+		remove    []coreV1.Taint
+		shouldErr bool
+	}{
+		{name: "empty", text: "  ", shouldErr: true},
+		{
+			name: "key value effect",
+			text: "dedicated=gpu:NoSchedule",
+			add:  []coreV1.Taint{{Key: "dedicated", Value: "gpu", Effect: coreV1.TaintEffectNoSchedule}},
+		},
+		{
+			name: "key effect",
+			text: "maintenance:NoExecute",
+			add:  []coreV1.Taint{{Key: "maintenance", Effect: coreV1.TaintEffectNoExecute}},
+		},
+		{name: "remove key", text: "dedicated-", remove: []coreV1.Taint{{Key: "dedicated"}}},
+		{
+			name:   "remove key effect",
+			text:   "dedicated:PreferNoSchedule-",
+			remove: []coreV1.Taint{{Key: "dedicated", Effect: coreV1.TaintEffectPreferNoSchedule}},
+		},
+		{name: "missing effect", text: "dedicated=gpu", shouldErr: true},
+		{name: "unsupported effect", text: "dedicated=gpu:NoRun", shouldErr: true},
+		{name: "unsupported removal effect", text: "dedicated:NoRun-", shouldErr: true},
+		{name: "missing key", text: "=gpu:NoSchedule", shouldErr: true},
+		{name: "missing removal key", text: ":NoSchedule-", shouldErr: true},
+		{
+			name:   "add and remove",
+			text:   "dedicated=gpu:NoSchedule maintenance- spot=true:PreferNoSchedule",
+			add:    []coreV1.Taint{{Key: "dedicated", Value: "gpu", Effect: coreV1.TaintEffectNoSchedule}, {Key: "spot", Value: "true", Effect: coreV1.TaintEffectPreferNoSchedule}},
+			remove: []coreV1.Taint{{Key: "maintenance"}},
+		},
+		{name: "invalid arg among valid args", text: "dedicated=gpu:NoSchedule spot", shouldErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			add, remove, err := parseTaintArgs(tc.text)
+			if tc.shouldErr {
+				if err == nil {
+					t.Fatal("expecting error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(add, tc.add) {
+				t.Errorf("expecting added taints %v, got %v", tc.add, add)
+			}
+			if !reflect.DeepEqual(remove, tc.remove) {
+				t.Errorf("expecting removed taints %v, got %v", tc.remove, remove)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package overview
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/config"
 	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/columns"
 	"github.com/vladimirvivien/ktop/views/model"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -20,10 +23,83 @@ type nodePanel struct {
 	list     *tview.Table
 	laidout  bool
 	colMap   map[string]int // Maps column name to position index
+	columns  *columns.Registry
+	nodes    []model.NodeModel              // models displayed, in row order
+	widths   map[string]config.ColumnLayout // column widths, keyed by column name
+	info     string                         // view information (i.e. sort) added to the title
+	width    int                            // table width the columns are laid out for
+	dropped  map[string]bool                // low-priority columns hidden for the table width
+	history  *nodeHistory                   // usage samples of the sparklines
+	alerts   *nodeAlertTracker              // nodes that became NotReady, or unreachable, highlighted
+	rules    []formatRule                   // formatting rules of the configuration file
+	units    displayUnits                   // units of the CPU and memory values
+	style    metricStyle                    // display of the CPU and memory cells
+	capacity bool                           // usage compared to the node capacity rather than allocatable
 }
 
-func NewNodePanel(app *application.Application, title string) ui.Panel {
-	p := &nodePanel{app: app, title: title}
+// nodeSparklineSize is the number of usage samples, one per nodes refresh, graphed by the node sparklines
+const nodeSparklineSize = 12
+
+// nodeHistory keeps the last CPU and memory usage ratios, of allocatable, of each node
+type nodeHistory struct {
+	mu  sync.Mutex
+	cpu map[string][]float64
+	mem map[string][]float64
+}
+
+func newNodeHistory() *nodeHistory {
+	return &nodeHistory{cpu: make(map[string][]float64), mem: make(map[string][]float64)}
+}
+
+// record adds the usage of nodes to their history and drops the history of removed nodes
+func (h *nodeHistory) record(nodes []model.NodeModel) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cpu := make(map[string][]float64, len(nodes))
+	mem := make(map[string][]float64, len(nodes))
+	for _, node := range nodes {
+		cpu[node.Name] = appendSparkSample(h.cpu[node.Name], float64(ui.GetRatio(float64(node.UsageCpuQty.MilliValue()), float64(node.AllocatableCpuQty.MilliValue()))))
+		mem[node.Name] = appendSparkSample(h.mem[node.Name], float64(ui.GetRatio(float64(node.UsageMemQty.MilliValue()), float64(node.MemoryBaseQty().MilliValue()))))
+	}
+	h.cpu, h.mem = cpu, mem
+}
+
+// sparklines returns the CPU and memory sparklines of the node
+func (h *nodeHistory) sparklines(name string, colors ui.ColorKeys) (cpu, mem string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return ui.Sparkline(h.cpu[name], 1, nodeSparklineSize, colors), ui.Sparkline(h.mem[name], 1, nodeSparklineSize, colors)
+}
+
+// peaks returns the highest CPU and memory usage ratios in the history of the node
+func (h *nodeHistory) peaks(name string) (cpu, mem ui.Ratio) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return ui.Ratio(maxSample(h.cpu[name])), ui.Ratio(maxSample(h.mem[name]))
+}
+
+// maxSample returns the highest of samples, 0 without samples
+func maxSample(samples []float64) float64 {
+	var max float64
+	for _, sample := range samples {
+		if sample > max {
+			max = sample
+		}
+	}
+	return max
+}
+
+// appendSparkSample appends sample to samples, keeping the last nodeSparklineSize samples
+func appendSparkSample(samples []float64, sample float64) []float64 {
+	samples = append(samples, sample)
+	if len(samples) > nodeSparklineSize {
+		samples = samples[len(samples)-nodeSparklineSize:]
+	}
+	return samples
+}
+
+func NewNodePanel(app *application.Application, title string, cols *columns.Registry) *nodePanel {
+	p := &nodePanel{app: app, title: title, columns: cols, history: newNodeHistory(), alerts: newNodeAlertTracker()}
 	p.Layout(nil)
 	return p
 }
@@ -39,9 +115,15 @@ func (p *nodePanel) Layout(_ interface{}) {
 		p.list.SetFocusFunc(func() {
 			p.list.SetSelectable(true, false)
 			p.list.SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorYellow).Foreground(tcell.ColorBlue))
+			highlightPanel(p.root, true)
 		})
 		p.list.SetBlurFunc(func() {
 			p.list.SetSelectable(false, false)
+			highlightPanel(p.root, false)
+		})
+		p.list.SetDrawFunc(func(_ tcell.Screen, x, y, width, height int) (int, int, int, int) {
+			p.resize(width)
+			return x, y, width, height
 		})
 
 		p.root = tview.NewFlex().SetDirection(tview.FlexRow).
@@ -74,14 +156,28 @@ func (p *nodePanel) DrawHeader(data interface{}) {
 	)
 
 	p.listCols = cols
+	p.dropped = droppedColumns(cols, nodeColumnDrops, p.width)
 	
 	// Set column headers and build column map
-	for i, col := range p.listCols {
-		pos := i + 1
+	pos := 0
+	for _, col := range p.listCols {
+		if p.dropped[col] {
+			continue // hidden in narrow panel
+		}
+		pos++
+		header, align := col, tview.AlignLeft
+		if col == "CPU" || col == "MEM" {
+			header, align = capacityHeader(col, p.capacity), p.style.align()
+			if p.style == styleNumeric && p.capacity {
+				header = numericHeader(col, "USE", "REQ", "CAP")
+			} else if p.style == styleNumeric {
+				header = numericHeader(col, "USE", "REQ", "ALLOC")
+			}
+		}
 		p.list.SetCell(0, pos,
-			tview.NewTableCell(col).
+			tview.NewTableCell(header).
 				SetTextColor(tcell.ColorWhite).
-				SetAlign(tview.AlignLeft).
+				SetAlign(align).
 				SetBackgroundColor(tcell.ColorDarkGreen).
 				SetExpansion(100).
 				SetSelectable(false),
@@ -105,11 +201,18 @@ func (p *nodePanel) DrawBody(data interface{}) {
 	var cpuMetrics, memMetrics string
 	colorKeys := ui.ColorKeys{0: "green", 50: "yellow", 90: "red"}
 
-	p.root.SetTitle(fmt.Sprintf("%s(%d) ", p.GetTitle(), len(nodes)))
+	p.nodes = nodes
+	if p.info != "" {
+		p.root.SetTitle(fmt.Sprintf("%s(%d, %s) ", p.GetTitle(), len(nodes), p.info))
+	} else {
+		p.root.SetTitle(fmt.Sprintf("%s(%d) ", p.GetTitle(), len(nodes)))
+	}
 	p.root.SetTitleAlign(tview.AlignLeft)
 
 	for rowIdx, node := range nodes {
 		rowIdx++ // offset for header-row
+		cpuSpark, memSpark := p.history.sparklines(node.Name, colorKeys)
+		cpuPeak, memPeak := p.history.peaks(node.Name)
 		
 		// Always render the legend column
 		controlLegend := ""
@@ -135,72 +238,38 @@ func (p *nodePanel) DrawBody(data interface{}) {
 			}
 			
 			switch colName {
-			case "NAME":
+			case "NAME", "AGE", "VERSION", "INT/EXT IPs", "INTERNAL-IP", "EXTERNAL-IP", "OS/ARC", "PODS/IMGs", "OS":
+				text, _ := model.NodeColumnText(colName, node)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  node.Name,
+						Text:  text,
 						Color: tcell.ColorYellow,
 						Align: tview.AlignLeft,
 					},
 				)
 				
 			case "STATUS":
+				text, color := node.Status, tcell.ColorYellow
+				if alert, ok := p.alerts.alert(node.Name); ok {
+					text, color = fmt.Sprintf("%s since %s", alert.state, alert.since.Local().Format("15:04:05")), tcell.ColorRed
+				}
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  node.Status,
-						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
-					},
-				)
-				
-			case "AGE":
-				p.list.SetCell(
-					rowIdx, colIdx,
-					&tview.TableCell{
-						Text:  node.TimeSinceStart,
-						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
-					},
-				)
-				
-			case "VERSION":
-				p.list.SetCell(
-					rowIdx, colIdx,
-					&tview.TableCell{
-						Text:  node.KubeletVersion,
-						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
-					},
-				)
-				
-			case "INT/EXT IPs":
-				p.list.SetCell(
-					rowIdx, colIdx,
-					&tview.TableCell{
-						Text:  fmt.Sprintf("%s/%s", node.InternalIP, node.ExternalIP),
-						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
-					},
-				)
-				
-			case "OS/ARC":
-				p.list.SetCell(
-					rowIdx, colIdx,
-					&tview.TableCell{
-						Text:  fmt.Sprintf("%s/%s", node.OSImage, node.Architecture),
-						Color: tcell.ColorYellow,
+						Text:  text,
+						Color: color,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "PODS/IMGs":
+			case "HUGEPAGES-2Mi", "HUGEPAGES-1Gi":
+				text, color := nodeHugePages(node, hugePagesColumns[colName])
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  fmt.Sprintf("%d/%d", node.PodsCount, node.ContainerImagesCount),
-						Color: tcell.ColorYellow,
+						Text:  text,
+						Color: color,
 						Align: tview.AlignLeft,
 					},
 				)
@@ -217,60 +286,180 @@ func (p *nodePanel) DrawBody(data interface{}) {
 				
 			case "CPU":
 				// Calculate CPU metrics
+				cpuBase, _ := nodeBaseQtys(node, p.capacity)
 				if metricsDiabled {
-					cpuRatio = ui.GetRatio(float64(node.RequestedPodCpuQty.MilliValue()), float64(node.AllocatableCpuQty.MilliValue()))
-					cpuGraph = ui.BarGraph(10, cpuRatio, colorKeys)
+					cpuRatio = ui.GetRatio(float64(node.RequestedPodCpuQty.MilliValue()), float64(cpuBase.MilliValue()))
+					cpuGraph = ui.BarGraph(graphWidthFor(p.width, p.app.Compact()), cpuRatio, colorKeys)
 					cpuMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dm/%dm (%1.0f%%)",
-						cpuGraph, node.RequestedPodCpuQty.MilliValue(), node.AllocatableCpuQty.MilliValue(), cpuRatio*100,
+						"[white][%s[white]] %s (%1.0f%%)",
+						cpuGraph, p.units.cpuPair(node.RequestedPodCpuQty, cpuBase), cpuRatio*100,
 					)
 				} else {
-					cpuRatio = ui.GetRatio(float64(node.UsageCpuQty.MilliValue()), float64(node.AllocatableCpuQty.MilliValue()))
-					cpuGraph = ui.BarGraph(10, cpuRatio, colorKeys)
+					cpuRatio = ui.GetRatio(float64(node.UsageCpuQty.MilliValue()), float64(cpuBase.MilliValue()))
+					cpuGraph = ui.BarGraphWithPeak(graphWidthFor(p.width, p.app.Compact()), cpuRatio, cpuPeak, colorKeys)
 					cpuMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dm/%dm (%1.0f%%)",
-						cpuGraph, node.UsageCpuQty.MilliValue(), node.AllocatableCpuQty.MilliValue(), cpuRatio*100,
+						"[white][%s[white]] %s (%1.0f%%)",
+						cpuGraph, p.units.cpuPair(node.UsageCpuQty, cpuBase), cpuRatio*100,
 					)
 				}
+				if p.app.Compact() {
+					if metricsDiabled {
+						cpuMetrics = compactMetrics(cpuGraph, cpuRatio)
+					} else {
+						// used/requested percentages of allocatable, or capacity
+						cpuMetrics = compactMetrics(cpuGraph, cpuRatio, ui.GetRatio(float64(node.RequestedPodCpuQty.MilliValue()), float64(cpuBase.MilliValue())))
+					}
+				} else if p.capacity {
+					// reserved for the system and the kubelet
+					cpuMetrics = fmt.Sprintf("%s [silver]rsv %s", cpuMetrics, p.units.cpuValues(reservedQty(node.CapacityCpuQty, node.AllocatableCpuQty))[0])
+				} else if !metricsDiabled && graphWidthFor(p.width, false) == graphWidth {
+					cpuMetrics = fmt.Sprintf("%s %s", cpuMetrics, cpuSpark)
+				}
+				switch p.style {
+				case stylePercent:
+					cpuMetrics = ui.Percentage(cpuRatio, colorKeys)
+				case styleNumeric:
+					values := p.units.cpuValues(node.UsageCpuQty, node.RequestedPodCpuQty, cpuBase)
+					if metricsDiabled {
+						values[0] = "-"
+					}
+					cpuMetrics = numericMetrics(values, node.RequestedPodCpuQty, cpuBase)
+				}
 				
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
 						Text:  cpuMetrics,
 						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
+						Align: p.style.align(),
 					},
 				)
 				
 			case "MEM":
 				// Calculate memory metrics
+				_, memBaseQty := nodeBaseQtys(node, p.capacity)
+				// requests are compared to the allocatable memory, or the capacity
+				requestBase := memBaseQty
+				if !p.capacity {
+					requestBase = qtyOrZero(node.AllocatableMemQty)
+				}
 				if metricsDiabled {
-					memRatio = ui.GetRatio(float64(node.RequestedPodMemQty.MilliValue()), float64(node.AllocatableMemQty.MilliValue()))
-					memGraph = ui.BarGraph(10, memRatio, colorKeys)
+					memRatio = ui.GetRatio(float64(node.RequestedPodMemQty.MilliValue()), float64(requestBase.MilliValue()))
+					memGraph = ui.BarGraph(graphWidthFor(p.width, p.app.Compact()), memRatio, colorKeys)
 					memMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dGi/%dGi (%1.0f%%)",
-						memGraph, node.RequestedPodMemQty.ScaledValue(resource.Giga), node.AllocatableMemQty.ScaledValue(resource.Giga), memRatio*100,
+						"[white][%s[white]] %s (%1.0f%%)",
+						memGraph, p.units.memPair(node.RequestedPodMemQty, requestBase, config.MemoryUnitGi), memRatio*100,
 					)
 				} else {
-					memRatio = ui.GetRatio(float64(node.UsageMemQty.MilliValue()), float64(node.AllocatableMemQty.MilliValue()))
-					memGraph = ui.BarGraph(10, memRatio, colorKeys)
+					// Windows nodes usage is compared to the memory capacity, see NodeModel.MemoryBaseQty
+					memRatio = ui.GetRatio(float64(node.UsageMemQty.MilliValue()), float64(memBaseQty.MilliValue()))
+					memGraph = ui.BarGraphWithPeak(graphWidthFor(p.width, p.app.Compact()), memRatio, memPeak, colorKeys)
+					memBase := ""
+					if node.IsWindows() && !p.capacity {
+						memBase = " cap"
+					}
 					memMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dGi/%dGi (%1.0f%%)",
-						memGraph, node.UsageMemQty.ScaledValue(resource.Giga), node.AllocatableMemQty.ScaledValue(resource.Giga), memRatio*100,
+						"[white][%s[white]] %s%s (%1.0f%%)",
+						memGraph, p.units.memPair(node.UsageMemQty, memBaseQty, config.MemoryUnitGi), memBase, memRatio*100,
 					)
 				}
+				if p.app.Compact() {
+					if metricsDiabled {
+						memMetrics = compactMetrics(memGraph, memRatio)
+					} else {
+						// used/requested percentages of allocatable, or capacity
+						memMetrics = compactMetrics(memGraph, memRatio, ui.GetRatio(float64(node.RequestedPodMemQty.MilliValue()), float64(requestBase.MilliValue())))
+					}
+				} else if p.capacity {
+					// reserved for the system and the kubelet
+					memMetrics = fmt.Sprintf("%s [silver]rsv %s", memMetrics, p.units.memValues(config.MemoryUnitGi, reservedQty(node.CapacityMemQty, node.AllocatableMemQty))[0])
+				} else if !metricsDiabled && graphWidthFor(p.width, false) == graphWidth {
+					memMetrics = fmt.Sprintf("%s %s", memMetrics, memSpark)
+				}
+				switch p.style {
+				case stylePercent:
+					memMetrics = ui.Percentage(memRatio, colorKeys)
+				case styleNumeric:
+					values := p.units.memValues(config.MemoryUnitGi, node.UsageMemQty, node.RequestedPodMemQty, requestBase)
+					if metricsDiabled {
+						values[0] = "-"
+					}
+					memMetrics = numericMetrics(values, node.RequestedPodMemQty, requestBase)
+				}
 				
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
 						Text:  memMetrics,
 						Color: tcell.ColorYellow,
-						Align: tview.AlignLeft,
+						Align: p.style.align(),
 					},
 				)
+
+			default:
+				// custom column computed by a column provider
+				if val, ok := p.columns.NodeValue(colName, node); ok {
+					p.list.SetCell(
+						rowIdx, colIdx,
+						&tview.TableCell{
+							Text:  val,
+							Color: tcell.ColorYellow,
+							Align: tview.AlignLeft,
+						},
+					)
+				}
 			}
 		}
+
+		applyFormatStyles(p.list, rowIdx, nodeFormatStyles(p.rules, node))
+
+		// highlight the row of a node that became NotReady, or unreachable
+		if _, ok := p.alerts.alert(node.Name); ok {
+			for col := 0; col < p.list.GetColumnCount(); col++ {
+				if cell := p.list.GetCell(rowIdx, col); cell != nil {
+					cell.SetBackgroundColor(tcell.ColorDarkRed)
+				}
+			}
+		}
+	}
+	applyColumnWidths(p.list, p.colMap, p.widths)
+	p.columns.PruneNodes()
+}
+
+// resize lays out the columns, and bar graphs, again when the table width changes
+func (p *nodePanel) resize(width int) {
+	if width == p.width {
+		return
+	}
+	graphChanged := graphWidthFor(width, p.app.Compact()) != graphWidthFor(p.width, p.app.Compact())
+	p.width = width
+	if !graphChanged && sameColumns(p.dropped, droppedColumns(p.listCols, nodeColumnDrops, width)) {
+		return
+	}
+
+	nodes := p.nodes
+	p.Clear()
+	p.DrawBody(nodes)
+}
+
+// selectedNode returns the node displayed at the selected row
+func (p *nodePanel) selectedNode() (model.NodeModel, bool) {
+	row, _ := p.list.GetSelection()
+	if row < 1 || row > len(p.nodes) {
+		return model.NodeModel{}, false
+	}
+	return p.nodes[row-1], true
+}
+
+// selectNode selects the row of the named node. It returns false when the node is not listed.
+func (p *nodePanel) selectNode(name string) bool {
+	for i, node := range p.nodes {
+		if node.Name == name {
+			p.list.Select(i+1, 0)
+			return true
+		}
 	}
+	return false
 }
 
 func (p *nodePanel) DrawFooter(_ interface{}) {}
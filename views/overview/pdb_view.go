@@ -0,0 +1,69 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showPDBs displays the pod disruption budgets with their allowed disruptions and,
+// for the budgets allowing none, the nodes that cannot currently be drained.
+func (p *MainPanel) showPDBs() {
+	pdbs, err := p.app.GetK8sClient().Controller().GetPDBModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list pod disruption budgets: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "MIN AVAILABLE", "MAX UNAVAILABLE", "HEALTHY", "ALLOWED DISRUPTIONS", "BLOCKED NODES"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	blocking := 0
+	for i, pdb := range pdbs {
+		allowedColor := tcell.ColorYellow
+		if pdb.BlocksDrain() {
+			allowedColor = tcell.ColorRed
+			blocking++
+		}
+		values := []string{
+			pdb.Namespace,
+			pdb.Name,
+			valueOrDash(pdb.MinAvailable),
+			valueOrDash(pdb.MaxUnavailable),
+			fmt.Sprintf("%d/%d", pdb.CurrentHealthy, pdb.DesiredHealthy),
+			fmt.Sprintf("%d", pdb.DisruptionsAllowed),
+			valueOrDash(strings.Join(pdb.BlockedNodes, ",")),
+		}
+		for j, val := range values {
+			cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+			if j == 5 {
+				cell.SetTextColor(allowedColor)
+			}
+			table.SetCell(i+1, j, cell)
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Pod disruption budgets (%d, %d blocking drains) (Esc: close) ", len(pdbs), blocking))
+	p.app.ShowNamedModal("disruption budgets", table)
+}
+
+// valueOrDash returns val, or "-" when val is empty
+func valueOrDash(val string) string {
+	if val == "" {
+		return "-"
+	}
+	return val
+}
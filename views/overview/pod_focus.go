@@ -0,0 +1,387 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/duration"
+	metricsV1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// focusHistorySize is the number of usage samples, one per pods refresh, kept by the focus view
+	focusHistorySize = 300
+	// focusGraphHeight is the height, in lines, of the focus view usage graphs
+	focusGraphHeight = 8
+	// focusEventCount is the number of recent events listed by the focus view
+	focusEventCount = 15
+	// focusTransitionCount is the number of observed status transitions kept by the focus view
+	focusTransitionCount = 15
+)
+
+// podFocus is the single pod view: usage graphs with history, containers, recent
+// events, and status transitions of one pod, updated on each pods refresh.
+type podFocus struct {
+	app         *application.Application
+	namespace   string
+	name        string
+	hint        string // key closing the view, shown in the title
+	root        *tview.Flex
+	cpuView     *tview.TextView
+	memView     *tview.TextView
+	containers  *tview.Table
+	events      *tview.TextView
+	transitions *tview.TextView
+
+	cpuHistory  []float64 // CPU usage samples, in millicores
+	memHistory  []float64 // memory usage samples, in bytes
+	status      string
+	statusSince time.Time
+	changes     []statusChange // observed status transitions, oldest first
+}
+
+// statusChange is a pod status transition observed by the focus view
+type statusChange struct {
+	time     time.Time
+	from, to string
+}
+
+// podFocusData is the pod data displayed by the focus view on each refresh
+type podFocusData struct {
+	model   model.PodModel
+	pod     *coreV1.Pod
+	metrics *metricsV1beta1.PodMetrics
+	memory  []model.ContainerMemoryModel // memory breakdown from the kubelet, empty when unreachable
+	events  []*coreV1.Event
+	found   bool
+}
+
+func newPodFocus(app *application.Application, namespace, name string) *podFocus {
+	f := &podFocus{app: app, namespace: namespace, name: name, hint: "Esc: close"}
+
+	f.cpuView = newFocusTextView("CPU")
+	f.memView = newFocusTextView("Memory")
+	f.events = newFocusTextView("Events")
+	f.transitions = newFocusTextView("Status transitions")
+
+	f.containers = tview.NewTable()
+	f.containers.SetFixed(1, 0)
+	f.containers.SetBorder(true)
+	f.containers.SetTitle(" Containers ")
+	f.containers.SetTitleAlign(tview.AlignLeft)
+
+	graphs := tview.NewFlex().
+		AddItem(f.cpuView, 0, 1, false).
+		AddItem(f.memView, 0, 1, false)
+	history := tview.NewFlex().
+		AddItem(f.events, 0, 2, false).
+		AddItem(f.transitions, 0, 1, false)
+
+	f.root = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(graphs, focusGraphHeight+3, 0, false).
+		AddItem(f.containers, 0, 1, true).
+		AddItem(history, 0, 1, false)
+	f.root.SetBorder(true)
+	f.root.SetTitleAlign(tview.AlignLeft)
+	f.root.SetTitle(fmt.Sprintf(" pod/%s/%s (%s) ", namespace, name, f.hint))
+	return f
+}
+
+func newFocusTextView(title string) *tview.TextView {
+	view := tview.NewTextView().SetDynamicColors(true).SetWordWrap(false)
+	view.SetBorder(true)
+	view.SetTitle(" " + title + " ")
+	view.SetTitleAlign(tview.AlignLeft)
+	return view
+}
+
+// collect retrieves, from the controller cache, the data displayed for the focused pod
+func (f *podFocus) collect(ctx context.Context, ctrl *k8s.Controller, models []model.PodModel) podFocusData {
+	var data podFocusData
+	for _, pod := range models {
+		if pod.Namespace == f.namespace && pod.Name == f.name {
+			data.model, data.found = pod, true
+			break
+		}
+	}
+	if !data.found {
+		return data
+	}
+	pod, err := ctrl.GetPod(ctx, f.namespace, f.name)
+	if err != nil {
+		data.found = false
+		return data
+	}
+	data.pod = pod
+	if metrics, err := ctrl.GetPodMetricsByName(ctx, pod); err == nil {
+		data.metrics = metrics
+	}
+	data.memory, _ = ctrl.GetPodMemoryStats(ctx, pod)
+	data.events, _ = ctrl.GetObjectEvents(ctx, pod.Namespace, pod.UID)
+	return data
+}
+
+// draw adds the pod usage to the history then redraws the view; it must run on the UI goroutine
+func (f *podFocus) draw(data podFocusData) {
+	if !data.found {
+		f.root.SetTitle(fmt.Sprintf(" pod/%s/%s [red](deleted)[white] (%s) ", f.namespace, f.name, f.hint))
+		return
+	}
+	pod := data.model
+	now := time.Now()
+	if pod.Status != f.status {
+		if f.status != "" {
+			f.changes = append(f.changes, statusChange{time: now, from: f.status, to: pod.Status})
+			if len(f.changes) > focusTransitionCount {
+				f.changes = f.changes[len(f.changes)-focusTransitionCount:]
+			}
+		}
+		f.status, f.statusSince = pod.Status, now
+	}
+	f.root.SetTitle(fmt.Sprintf(" pod/%s/%s [yellow]%s[white] on %s (%s) ", f.namespace, f.name, tview.Escape(pod.Status), pod.Node, f.hint))
+
+	metricsAvailable := f.app.GetK8sClient().AssertMetricsAvailable() == nil
+	if metricsAvailable && data.metrics != nil {
+		f.cpuHistory = appendSample(f.cpuHistory, float64(pod.PodUsageCpuQty.MilliValue()))
+		f.memHistory = appendSample(f.memHistory, float64(pod.PodUsageMemQty.Value()))
+	}
+	cpuLimit, memLimit := podLimits(data.pod)
+
+	if !metricsAvailable {
+		f.cpuView.SetText("[red]metrics unavailable")
+		f.memView.SetText("[red]metrics unavailable")
+	} else {
+		f.drawGraph(f.cpuView, f.cpuHistory, float64(pod.PodRequestedCpuQty.MilliValue()), float64(cpuLimit.MilliValue()), func(v float64) string {
+			return fmt.Sprintf("%1.0fm", v)
+		})
+		f.drawGraph(f.memView, f.memHistory, float64(pod.PodRequestedMemQty.Value()), float64(memLimit.Value()), func(v float64) string {
+			return fmt.Sprintf("%1.0fMi", v/(1000*1000))
+		})
+	}
+
+	f.drawContainers(data.pod, data.metrics, data.memory)
+	f.drawEvents(data.events)
+	f.drawTransitions(data.pod)
+}
+
+// drawGraph draws the usage history in view, scaled against the largest of
+// the usage peak, requested, and limit values
+func (f *podFocus) drawGraph(view *tview.TextView, samples []float64, requested, limit float64, format func(float64) string) {
+	var usage, peak float64
+	for _, sample := range samples {
+		if sample > peak {
+			peak = sample
+		}
+	}
+	if len(samples) > 0 {
+		usage = samples[len(samples)-1]
+	}
+	max := peak
+	if requested > max {
+		max = requested
+	}
+	if limit > max {
+		max = limit
+	}
+
+	_, _, width, _ := view.GetInnerRect()
+	if width <= 0 {
+		width = focusHistorySize / 4
+	}
+	info := fmt.Sprintf("[white]used [yellow]%s[white]  peak [yellow]%s[white]  requested [yellow]%s[white]", format(usage), format(peak), format(requested))
+	if limit > 0 {
+		info += fmt.Sprintf("  limit [yellow]%s[white]", format(limit))
+	}
+	colorKeys := ui.ColorKeys{0: "green", 50: "yellow", 90: "red"}
+	view.SetText(ui.HistoryGraph(samples, max, width, focusGraphHeight, colorKeys) + "\n" + info)
+}
+
+// drawContainers lists the init and app containers with their state, usage, and memory
+// breakdown: the working set, counted against the memory limit, is the RSS plus the
+// active part of the page cache. The SWAP column is shown when the kubelet reports swap.
+func (f *podFocus) drawContainers(pod *coreV1.Pod, metrics *metricsV1beta1.PodMetrics, memory []model.ContainerMemoryModel) {
+	f.containers.Clear()
+	breakdown := make(map[string]model.ContainerMemoryModel)
+	swap := false
+	for _, m := range memory {
+		breakdown[m.Name] = m
+		swap = swap || m.SwapQty != nil
+	}
+	cols := []string{"CONTAINER", "READY", "STATE", "RESTARTS", "CPU", "MEMORY", "WORKING SET", "RSS", "CACHE"}
+	if swap {
+		cols = append(cols, "SWAP")
+	}
+	cols = append(cols, "REQUESTS", "LIMITS")
+	for i, col := range cols {
+		f.containers.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	usage := make(map[string]coreV1.ResourceList)
+	if metrics != nil {
+		for _, c := range metrics.Containers {
+			usage[c.Name] = c.Usage
+		}
+	}
+	statuses := make(map[string]coreV1.ContainerStatus)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statuses[status.Name] = status
+	}
+
+	row := 1
+	addRow := func(container coreV1.Container, init bool) {
+		name := container.Name
+		if init {
+			name += " (init)"
+		}
+		status := statuses[container.Name]
+		cpu, mem := "-", "-"
+		if list, ok := usage[container.Name]; ok {
+			cpu = fmt.Sprintf("%dm", list.Cpu().MilliValue())
+			mem = fmt.Sprintf("%dMi", list.Memory().ScaledValue(resource.Mega))
+		}
+		values := []string{
+			name,
+			fmt.Sprintf("%t", status.Ready),
+			shortContainerState(status.State),
+			fmt.Sprintf("%d", status.RestartCount),
+			cpu,
+			mem,
+			formatMemQty(breakdown[container.Name].WorkingSetQty),
+			formatMemQty(breakdown[container.Name].RSSQty),
+			formatMemQty(breakdown[container.Name].PageCacheQty),
+		}
+		if swap {
+			values = append(values, formatMemQty(breakdown[container.Name].SwapQty))
+		}
+		values = append(values, formatCpuMem(container.Resources.Requests), formatCpuMem(container.Resources.Limits))
+		for i, value := range values {
+			f.containers.SetCell(row, i, &tview.TableCell{Text: value, Color: tcell.ColorYellow, Align: tview.AlignLeft})
+		}
+		row++
+	}
+	for _, container := range pod.Spec.InitContainers {
+		addRow(container, true)
+	}
+	for _, container := range pod.Spec.Containers {
+		addRow(container, false)
+	}
+}
+
+// drawEvents lists the most recent pod events, newest first
+func (f *podFocus) drawEvents(events []*coreV1.Event) {
+	if len(events) == 0 {
+		f.events.SetText("<none>")
+		return
+	}
+	var text strings.Builder
+	for i := len(events) - 1; i >= 0 && i >= len(events)-focusEventCount; i-- {
+		event := events[i]
+		color := "white"
+		if event.Type == coreV1.EventTypeWarning {
+			color = "orange"
+		}
+		age := duration.HumanDuration(time.Since(k8s.EventTime(event)))
+		if event.Count > 1 {
+			age = fmt.Sprintf("%s (x%d)", age, event.Count)
+		}
+		fmt.Fprintf(&text, "[%s]%-12s %-20s %s\n", color, age, tview.Escape(event.Reason), tview.Escape(strings.TrimSpace(event.Message)))
+	}
+	f.events.SetText(text.String())
+}
+
+// drawTransitions lists the observed status transitions, newest first, followed by the pod conditions
+func (f *podFocus) drawTransitions(pod *coreV1.Pod) {
+	var text strings.Builder
+	fmt.Fprintf(&text, "[yellow]%s[white] for %s\n", tview.Escape(f.status), duration.HumanDuration(time.Since(f.statusSince)))
+	for i := len(f.changes) - 1; i >= 0; i-- {
+		change := f.changes[i]
+		fmt.Fprintf(&text, "%s %s %c %s\n", change.time.Format("15:04:05"), tview.Escape(change.from), ui.Icons.ArrowRight, tview.Escape(change.to))
+	}
+
+	conds := make([]coreV1.PodCondition, len(pod.Status.Conditions))
+	copy(conds, pod.Status.Conditions)
+	sort.Slice(conds, func(i, j int) bool {
+		return conds[i].LastTransitionTime.After(conds[j].LastTransitionTime.Time)
+	})
+	text.WriteString("\n[green]Conditions:[white]\n")
+	for _, cond := range conds {
+		since := "..."
+		if !cond.LastTransitionTime.IsZero() {
+			since = duration.HumanDuration(time.Since(cond.LastTransitionTime.Time))
+		}
+		fmt.Fprintf(&text, "%-16s %-6s %s\n", cond.Type, cond.Status, since)
+	}
+	f.transitions.SetText(text.String())
+}
+
+// appendSample appends sample to samples, keeping the last focusHistorySize samples
+func appendSample(samples []float64, sample float64) []float64 {
+	samples = append(samples, sample)
+	if len(samples) > focusHistorySize {
+		samples = samples[len(samples)-focusHistorySize:]
+	}
+	return samples
+}
+
+// podLimits returns the sum of the CPU and memory limits of the pod containers
+func podLimits(pod *coreV1.Pod) (cpu, mem *resource.Quantity) {
+	cpu = resource.NewQuantity(0, resource.DecimalSI)
+	mem = resource.NewQuantity(0, resource.DecimalSI)
+	for _, container := range pod.Spec.Containers {
+		cpu.Add(*container.Resources.Limits.Cpu())
+		mem.Add(*container.Resources.Limits.Memory())
+	}
+	return cpu, mem
+}
+
+// shortContainerState returns the container state, with its reason, i.e. Waiting(CrashLoopBackOff)
+func shortContainerState(state coreV1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running"
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting(%s)", state.Waiting.Reason)
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated(%s)", state.Terminated.Reason)
+	default:
+		return "..."
+	}
+}
+
+// formatCpuMem returns the CPU and memory quantities of list, i.e. 100m/128Mi
+func formatCpuMem(list coreV1.ResourceList) string {
+	cpu, mem := "-", "-"
+	if qty, ok := list[coreV1.ResourceCPU]; ok {
+		cpu = qty.String()
+	}
+	if qty, ok := list[coreV1.ResourceMemory]; ok {
+		mem = qty.String()
+	}
+	return cpu + "/" + mem
+}
+
+// formatMemQty formats a memory quantity as the memory usage, or "-" when unknown
+func formatMemQty(qty *resource.Quantity) string {
+	if qty == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%dMi", qty.Value()/mebibyte)
+}
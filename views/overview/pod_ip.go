@@ -0,0 +1,60 @@
+package overview
+
+import (
+	"bytes"
+	"net"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// podIPs returns the text of the pod IP column: the pod IPs, IPv6 addresses first
+// when ipv6 is set, IPv4 addresses first otherwise
+func podIPs(pod model.PodModel, ipv6 bool) string {
+	ips := pod.IPs
+	if len(ips) == 0 && pod.IP != "" {
+		ips = []string{pod.IP}
+	}
+	var preferred, others []string
+	for _, ip := range ips {
+		if isIPv6(ip) == ipv6 {
+			preferred = append(preferred, ip)
+		} else {
+			others = append(others, ip)
+		}
+	}
+	return strings.Join(append(preferred, others...), ",")
+}
+
+// isIPv6 returns whether ip is an IPv6 address
+func isIPv6(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.To4() == nil
+}
+
+// compareIP compares the addresses a and b: empty addresses first, then IPv4 before
+// IPv6 addresses, in numeric order; unparsable addresses, last, are compared as text
+func compareIP(a, b string) int {
+	if a == "" || b == "" {
+		return strings.Compare(a, b)
+	}
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	switch {
+	case ipA == nil && ipB == nil:
+		return strings.Compare(a, b)
+	case ipA == nil:
+		return 1
+	case ipB == nil:
+		return -1
+	}
+	v4A, v4B := ipA.To4(), ipB.To4()
+	switch {
+	case v4A != nil && v4B != nil:
+		return bytes.Compare(v4A, v4B)
+	case v4A != nil:
+		return -1
+	case v4B != nil:
+		return 1
+	}
+	return bytes.Compare(ipA.To16(), ipB.To16())
+}
@@ -0,0 +1,62 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestPodIPs(t *testing.T) {
+	dualStack := model.PodModel{IP: "10.244.1.5", IPs: []string{"10.244.1.5", "fd00:10:244:1::5"}}
+	tests := []struct {
+		name     string
+		pod      model.PodModel
+		ipv6     bool
+		expected string
+	}{
+		{name: "no IP", pod: model.PodModel{}, expected: ""},
+		{name: "primary IP only", pod: model.PodModel{IP: "10.244.1.5"}, expected: "10.244.1.5"},
+		{name: "dual-stack IPv4 first", pod: dualStack, expected: "10.244.1.5,fd00:10:244:1::5"},
+		{name: "dual-stack IPv6 first", pod: dualStack, ipv6: true, expected: "fd00:10:244:1::5,10.244.1.5"},
+		{name: "single-stack IPv6", pod: model.PodModel{IP: "fd00::5", IPs: []string{"fd00::5"}}, expected: "fd00::5"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if text := podIPs(tc.pod, tc.ipv6); text != tc.expected {
+				t.Fatalf("expecting %q, got %q", tc.expected, text)
+			}
+		})
+	}
+}
+
+func TestCompareIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{name: "numeric IPv4 order", a: "10.0.0.9", b: "10.0.0.10", expected: -1},
+		{name: "equal", a: "10.0.0.9", b: "10.0.0.9", expected: 0},
+		{name: "IPv4 before IPv6", a: "192.168.0.1", b: "fd00::1", expected: -1},
+		{name: "numeric IPv6 order", a: "fd00::a", b: "fd00::9", expected: 1},
+		{name: "empty first", a: "", b: "10.0.0.1", expected: -1},
+		{name: "unparsable last", a: "pending", b: "fd00::1", expected: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			result := compareIP(tc.a, tc.b)
+			if result < 0 {
+				result = -1
+			} else if result > 0 {
+				result = 1
+			}
+			if result != tc.expected {
+				t.Fatalf("expecting %d, got %d", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package overview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// podJumpCompletions is the maximum number of completions listed by the jump to pod dialog
+const podJumpCompletions = 15
+
+// podCompletions returns the namespace/name of the pods whose namespace/name contains text,
+// case insensitively: the pods whose name starts with text first, then in list order
+func podCompletions(pods []model.PodModel, text string, limit int) []string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+	var prefixed, contained []string
+	for _, pod := range pods {
+		key := podKey(pod.Namespace, pod.Name)
+		switch {
+		case strings.HasPrefix(strings.ToLower(pod.Name), text), strings.HasPrefix(strings.ToLower(key), text):
+			prefixed = append(prefixed, key)
+		case strings.Contains(strings.ToLower(key), text):
+			contained = append(contained, key)
+		}
+	}
+	sort.Strings(prefixed)
+	completions := append(prefixed, contained...)
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+	return completions
+}
+
+// findPod returns the index, in pods, of the pod named text: an exact namespace/name or name
+// match, otherwise the first completion of text; -1 when no pod matches
+func findPod(pods []model.PodModel, text string) int {
+	text = strings.TrimSpace(text)
+	for i, pod := range pods {
+		if podKey(pod.Namespace, pod.Name) == text {
+			return i
+		}
+	}
+	for i, pod := range pods {
+		if pod.Name == text {
+			return i
+		}
+	}
+	completions := podCompletions(pods, text, 1)
+	if len(completions) == 0 {
+		return -1
+	}
+	for i, pod := range pods {
+		if podKey(pod.Namespace, pod.Name) == completions[0] {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpTo selects, and scrolls to, the row of the pod named text. It returns false when
+// no listed pod matches text.
+func (p *podPanel) jumpTo(text string) bool {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	i := findPod(p.pods, text)
+	if i < 0 {
+		return false
+	}
+	for row, r := range p.rows {
+		if r.pod == i && r.container == "" {
+			p.list.Select(row+1, 0)
+			return true
+		}
+	}
+	return false
+}
+
+// completions returns the completions of text among the listed pods
+func (p *podPanel) completions(text string) []string {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	return podCompletions(p.pods, text, podJumpCompletions)
+}
+
+// showPodJump prompts for a pod name, completed from the listed pods, then moves the
+// selection to the pod row
+func (p *MainPanel) showPodJump() {
+	p.app.ShowInputCompletion("Jump to pod: name or namespace/name", "pod: ", "", p.podPanel.completions, func(text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		if !p.podPanel.jumpTo(text) {
+			p.app.ShowMessage(fmt.Sprintf("No listed pod matches %s", text))
+			return
+		}
+		p.app.Focus(p.podPanel.list)
+	})
+}
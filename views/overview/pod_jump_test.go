@@ -0,0 +1,49 @@
+package overview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestPodJump(t *testing.T) {
+	pods := []model.PodModel{
+		{Namespace: "default", Name: "web-api-0"},
+		{Namespace: "payments", Name: "api-1"},
+		{Namespace: "payments", Name: "api-0"},
+		{Namespace: "kube-system", Name: "coredns-5d78c9869d-abcde"},
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		completions []string
+		found       int
+	}{
+		{name: "empty", text: " ", found: -1},
+		{
+			name:        "prefix first",
+			text:        "api",
+			completions: []string{"payments/api-0", "payments/api-1", "default/web-api-0"},
+			found:       2,
+		},
+		{name: "exact name", text: "api-1", completions: []string{"payments/api-1"}, found: 1},
+		{name: "namespace/name", text: "default/web-api-0", completions: []string{"default/web-api-0"}, found: 0},
+		{name: "case insensitive", text: "COREDNS", completions: []string{"kube-system/coredns-5d78c9869d-abcde"}, found: 3},
+		{name: "no match", text: "nginx", found: -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			completions := podCompletions(pods, tc.text, podJumpCompletions)
+			if strings.Join(completions, ",") != strings.Join(tc.completions, ",") {
+				t.Errorf("expected completions %v, got %v", tc.completions, completions)
+			}
+			if found := findPod(pods, tc.text); found != tc.found {
+				t.Errorf("expected pod %d, got %d", tc.found, found)
+			}
+		})
+	}
+}
@@ -2,15 +2,26 @@ package overview
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/config"
 	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/columns"
 	"github.com/vladimirvivien/ktop/views/model"
-	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// podRow is a pod table row: a pod or, when container is set, one of its containers
+type podRow struct {
+	pod       int // index of the pod in podPanel.pods
+	container string
+}
+
 type podPanel struct {
 	app      *application.Application
 	title    string
@@ -20,15 +31,89 @@ type podPanel struct {
 	list     *tview.Table
 	laidout  bool
 	colMap   map[string]int // Maps column name to position index
+	columns  *columns.Registry
+	pods     []model.PodModel // models displayed, in order
+	rows     []podRow         // pod, or container, displayed at each row after the header
+	markMu   sync.Mutex
+	marked   map[string]bool                // namespace/name keys of the marked pods
+	pinned   map[string]bool                // namespace/name keys of the pods pinned to the top
+	expanded map[string]bool                // namespace/name keys of the pods showing container rows
+	widths   map[string]config.ColumnLayout // column widths, keyed by column name
+	info     string                         // view information (i.e. sort, filter) added to the title
+	ipv6     bool                           // IPv6 pod IPs are listed first
+	width    int                            // table width the columns are laid out for
+	dropped  map[string]bool                // low-priority columns hidden for the table width
+
+	titleInfo string            // pod counts and view information of the title, guarded by markMu
+	countdown *refreshCountdown // time left until the next pods refresh
+	newWindow time.Duration     // window of the new and restarted pod badges, 0 to disable
+	flashes   *flashTracker     // CPU and MEMORY cells highlighted after a significant change
+	rules     []formatRule      // formatting rules of the configuration file
+	units     displayUnits      // units of the CPU and memory values
+	style     metricStyle       // display of the CPU and MEMORY cells
+	base      usageBase         // denominator of the CPU and MEMORY usage percentages
 }
 
-func NewPodPanel(app *application.Application, title string) ui.Panel {
-	p := &podPanel{app: app, title: title}
+func NewPodPanel(app *application.Application, title string, cols *columns.Registry) *podPanel {
+	p := &podPanel{app: app, title: title, columns: cols, marked: make(map[string]bool), pinned: make(map[string]bool), expanded: make(map[string]bool), countdown: newRefreshCountdown(k8s.PodsRefreshInterval), flashes: newFlashTracker()}
 	p.Layout(nil)
 
 	return p
 }
 
+// drawTitle sets the panel title: the pod counts, view information, and refresh
+// countdown; p.markMu must be held
+func (p *podPanel) drawTitle() {
+	info := p.titleInfo
+	if refresh := p.countdown.info(time.Now()); refresh != "" {
+		info += ", " + refresh
+	}
+	p.root.SetTitle(fmt.Sprintf("%s(%s) ", p.GetTitle(), info))
+}
+
+// drawCountdown updates the refresh countdown of the title
+func (p *podPanel) drawCountdown() {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	p.drawTitle()
+
+	// fade the flashing cells between refreshes
+	now := time.Now()
+	for i, row := range p.rows {
+		if row.container == "" && row.pod < len(p.pods) {
+			pod := p.pods[row.pod]
+			p.drawFlashes(i+1, pod, now)
+		}
+	}
+}
+
+// drawFlashes sets the background of the CPU and MEMORY cells, at rowIdx, of pod: highlighted,
+// then fading, after a significant change of the pod usage, or else the background of the
+// formatting rules matching pod
+func (p *podPanel) drawFlashes(rowIdx int, pod model.PodModel, now time.Time) {
+	key := podKey(pod.Namespace, pod.Name)
+	background := tcell.ColorDefault
+	backgroundSet := false
+	for _, col := range []string{"CPU", "MEMORY"} {
+		colIdx, ok := p.colMap[col]
+		if !ok {
+			continue
+		}
+		cell := p.list.GetCell(rowIdx, colIdx)
+		if cell == nil {
+			continue
+		}
+		if color := p.flashes.color(key, col, now); color != tcell.ColorDefault {
+			cell.SetBackgroundColor(color)
+			continue
+		}
+		if !backgroundSet {
+			background, backgroundSet = formatBackground(podFormatStyles(p.rules, pod)), true
+		}
+		cell.SetBackgroundColor(background)
+	}
+}
+
 func (p *podPanel) GetTitle() string {
 	return p.title
 }
@@ -42,9 +127,15 @@ func (p *podPanel) Layout(_ interface{}) {
 		p.list.SetFocusFunc(func() {
 			p.list.SetSelectable(true, false)
 			p.list.SetSelectedStyle(tcell.StyleDefault.Background(tcell.ColorYellow).Foreground(tcell.ColorBlue))
+			highlightPanel(p.root, true)
 		})
 		p.list.SetBlurFunc(func() {
 			p.list.SetSelectable(false, false)
+			highlightPanel(p.root, false)
+		})
+		p.list.SetDrawFunc(func(_ tcell.Screen, x, y, width, height int) (int, int, int, int) {
+			p.resize(width)
+			return x, y, width, height
 		})
 
 		p.root = tview.NewFlex().SetDirection(tview.FlexRow).
@@ -65,20 +156,33 @@ func (p *podPanel) DrawHeader(data interface{}) {
 	// Initialize the column map
 	p.colMap = make(map[string]int)
 	p.listCols = cols
+	p.dropped = droppedColumns(cols, podColumnDrops, p.width)
 	
 	// Set column headers and build column map
-	for i, col := range p.listCols {
+	i := 0
+	for _, col := range p.listCols {
+		if p.dropped[col] {
+			continue // hidden in narrow panel
+		}
+		header, align := col, tview.AlignLeft
+		if col == "CPU" || col == "MEMORY" {
+			header, align = p.base.header(col), p.style.align()
+			if p.style == styleNumeric {
+				header = numericHeader(col, "USE", "REQ", "LIM")
+			}
+		}
 		p.list.SetCell(0, i,
-			tview.NewTableCell(col).
+			tview.NewTableCell(header).
 				SetTextColor(tcell.ColorWhite).
 				SetBackgroundColor(tcell.ColorDarkGreen).
-				SetAlign(tview.AlignLeft).
+				SetAlign(align).
 				SetExpansion(100).
 				SetSelectable(false),
 		)
 		
 		// Map column name to position
 		p.colMap[col] = i
+		i++
 	}
 	p.list.SetFixed(1, 0)
 }
@@ -96,11 +200,30 @@ func (p *podPanel) DrawBody(data interface{}) {
 	var cpuGraph, memGraph string
 	var cpuMetrics, memMetrics string
 
-	p.root.SetTitle(fmt.Sprintf("%s(%d) ", p.GetTitle(), len(pods)))
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	selected := p.selectedKey()
+	p.pods = pods
+	p.rows = p.rows[:0]
+	titleInfo := []string{fmt.Sprintf("%d", len(pods))}
+	if len(p.marked) > 0 {
+		titleInfo = append(titleInfo, fmt.Sprintf("%d marked", len(p.marked)))
+	}
+	if len(p.pinned) > 0 {
+		titleInfo = append(titleInfo, fmt.Sprintf("%d pinned", len(p.pinned)))
+	}
+	if p.info != "" {
+		titleInfo = append(titleInfo, p.info)
+	}
+	p.titleInfo = strings.Join(titleInfo, ", ")
+	p.drawTitle()
 	p.root.SetTitleAlign(tview.AlignLeft)
 
-	for rowIdx, pod := range pods {
-		rowIdx++ // offset for header row
+	pinnedPods, pinnedRows := 0, 0
+	now := time.Now()
+	rowIdx := 0 // offset for header row
+	for i, pod := range pods {
+		rowIdx++
 		
 		// Render each column that is included in the filtered view
 		for _, colName := range p.listCols {
@@ -110,91 +233,118 @@ func (p *podPanel) DrawBody(data interface{}) {
 			}
 			
 			switch colName {
-			case "NAMESPACE":
+			case "NAMESPACE", "READY", "STATUS", "RESTARTS", "SCHEDULER", "AGE", "VOLS", "NODE":
+				text, _ := model.PodColumnText(colName, pod)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.Namespace,
+						Text:  text,
 						Color: tcell.ColorYellow,
 						Align: tview.AlignLeft,
 					},
 				)
 				
 			case "POD":
+				text, color := podNameBadge(pod, now, p.newWindow)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.Name,
-						Color: tcell.ColorYellow,
+						Text:  text,
+						Color: color,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "READY":
+			case "RESTART RATE":
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  fmt.Sprintf("%d/%d", pod.ReadyContainers, pod.TotalContainers),
-						Color: tcell.ColorYellow,
+						Text:  fmt.Sprintf("%d/%d", pod.RecentRestarts, pod.HourRestarts),
+						Color: restartRateColor(pod),
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "STATUS":
+			case "LAST RESTART":
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.Status,
-						Color: tcell.ColorYellow,
+						Text:  pod.TimeSinceLastRestart(),
+						Color: lastRestartColor(pod, time.Now()),
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "RESTARTS":
+			case "HUGEPAGES-2Mi", "HUGEPAGES-1Gi":
+				text := podHugePages(pod, hugePagesColumns[colName])
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  fmt.Sprintf("%d", pod.Restarts),
+						Text:  text,
 						Color: tcell.ColorYellow,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "AGE":
+			case "THROTTLE%":
+				text, color := podThrottle(pod)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.TimeSince,
-						Color: tcell.ColorYellow,
+						Text:  text,
+						Color: color,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "VOLS":
+			case "IMAGE":
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  fmt.Sprintf("%d", pod.Volumes),
+						Text:  podImage(pod),
 						Color: tcell.ColorYellow,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "IP":
+			case "EVICTION":
+				text, color := podEviction(pod)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.IP,
-						Color: tcell.ColorYellow,
+						Text:  text,
+						Color: color,
+						Align: tview.AlignLeft,
+					},
+				)
+				
+			case "HOST NET":
+				text, color := podHostNetwork(pod)
+				p.list.SetCell(
+					rowIdx, colIdx,
+					&tview.TableCell{
+						Text:  text,
+						Color: color,
 						Align: tview.AlignLeft,
 					},
 				)
 				
-			case "NODE":
+			case "SECURITY":
+				text, color := podSecurity(pod)
 				p.list.SetCell(
 					rowIdx, colIdx,
 					&tview.TableCell{
-						Text:  pod.Node,
+						Text:  text,
+						Color: color,
+						Align: tview.AlignLeft,
+					},
+				)
+				
+			case "IP":
+				p.list.SetCell(
+					rowIdx, colIdx,
+					&tview.TableCell{
+						Text:  podIPs(pod, p.ipv6),
 						Color: tcell.ColorYellow,
 						Align: tview.AlignLeft,
 					},
@@ -212,18 +362,32 @@ func (p *podPanel) DrawBody(data interface{}) {
 						},
 					)
 				} else {
-					cpuRatio = ui.GetRatio(float64(pod.PodUsageCpuQty.MilliValue()), float64(pod.PodRequestedCpuQty.MilliValue()))
-					cpuGraph = ui.BarGraph(10, cpuRatio, colorKeys)
+					cpuBase, _ := p.base.podQtys(pod)
+					cpuRatio = ui.GetRatio(float64(pod.PodUsageCpuQty.MilliValue()), float64(cpuBase.MilliValue()))
+					cpuGraph = ui.BarGraph(graphWidthFor(p.width, p.app.Compact()), cpuRatio, colorKeys)
 					cpuMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dm/%dm (%1.0f%%)",
-						cpuGraph, pod.PodUsageCpuQty.MilliValue(), pod.PodRequestedCpuQty.MilliValue(), cpuRatio*100,
+						"[white][%s[white]] %s (%1.0f%%)",
+						cpuGraph, p.units.cpuPair(pod.PodUsageCpuQty, cpuBase), cpuRatio*100,
 					)
+					if p.app.Compact() {
+						cpuMetrics = compactMetrics(cpuGraph, cpuRatio)
+					}
+					switch p.style {
+					case stylePercent:
+						cpuMetrics = ui.Percentage(cpuRatio, colorKeys)
+					case styleNumeric:
+						request, limit := qtyOrZero(pod.PodRequestedCpuQty), qtyOrZero(pod.PodLimitCpuQty)
+						cpuMetrics = numericMetrics(p.units.cpuValues(pod.PodUsageCpuQty, request, limit), request, limit)
+					}
+					if p.style != styleNumeric && p.base.unlimited(cpuBase) {
+						cpuMetrics = p.unlimitedMetrics(p.units.cpuValues(pod.PodUsageCpuQty)[0])
+					}
 					p.list.SetCell(
 						rowIdx, colIdx,
 						&tview.TableCell{
 							Text:  cpuMetrics,
 							Color: tcell.ColorYellow,
-							Align: tview.AlignLeft,
+							Align: p.style.align(),
 						},
 					)
 				}
@@ -240,27 +404,381 @@ func (p *podPanel) DrawBody(data interface{}) {
 						},
 					)
 				} else {
-					memRatio = ui.GetRatio(float64(pod.PodUsageMemQty.Value()), float64(pod.PodRequestedMemQty.Value()))
-					memGraph = ui.BarGraph(10, memRatio, colorKeys)
+					_, memBase := p.base.podQtys(pod)
+					memRatio = ui.GetRatio(float64(pod.PodUsageMemQty.Value()), float64(memBase.Value()))
+					memGraph = ui.BarGraph(graphWidthFor(p.width, p.app.Compact()), memRatio, colorKeys)
 					memMetrics = fmt.Sprintf(
-						"[white][%s[white]] %dMi/%dMi (%1.0f%%)",
-						memGraph, 
-						pod.PodUsageMemQty.ScaledValue(resource.Mega), 
-						pod.PodRequestedMemQty.ScaledValue(resource.Mega), 
+						"[white][%s[white]] %s (%1.0f%%)",
+						memGraph,
+						p.units.memPair(pod.PodUsageMemQty, memBase, config.MemoryUnitMi),
 						memRatio*100,
 					)
+					if p.app.Compact() {
+						memMetrics = compactMetrics(memGraph, memRatio)
+					}
+					switch p.style {
+					case stylePercent:
+						memMetrics = ui.Percentage(memRatio, colorKeys)
+					case styleNumeric:
+						request, limit := qtyOrZero(pod.PodRequestedMemQty), qtyOrZero(pod.PodLimitMemQty)
+						memMetrics = numericMetrics(p.units.memValues(config.MemoryUnitMi, pod.PodUsageMemQty, request, limit), request, limit)
+					}
+					if p.style != styleNumeric && p.base.unlimited(memBase) {
+						memMetrics = p.unlimitedMetrics(p.units.memValues(config.MemoryUnitMi, pod.PodUsageMemQty)[0])
+					}
 					p.list.SetCell(
 						rowIdx, colIdx,
 						&tview.TableCell{
 							Text:  memMetrics,
 							Color: tcell.ColorYellow,
+							Align: p.style.align(),
+						},
+					)
+				}
+
+			default:
+				// custom column computed by a column provider
+				if val, ok := p.columns.PodValue(colName, pod); ok {
+					p.list.SetCell(
+						rowIdx, colIdx,
+						&tview.TableCell{
+							Text:  val,
+							Color: tcell.ColorYellow,
 							Align: tview.AlignLeft,
 						},
 					)
 				}
 			}
 		}
+		applyFormatStyles(p.list, rowIdx, podFormatStyles(p.rules, pod))
+
+		key := podKey(pod.Namespace, pod.Name)
+		if p.pinned[key] {
+			if cell := p.list.GetCell(rowIdx, 0); cell != nil {
+				cell.SetText(fmt.Sprintf("%c %s", ui.Icons.Pin, cell.Text))
+			}
+		}
+		if p.marked[key] {
+			if cell := p.list.GetCell(rowIdx, 0); cell != nil {
+				cell.SetText(fmt.Sprintf("%c %s", ui.Icons.Check, cell.Text))
+				cell.SetTextColor(tcell.ColorAqua)
+			}
+		}
+		if !metricsDisabled {
+			p.drawFlashes(rowIdx, pod, now)
+		}
+		p.rows = append(p.rows, podRow{pod: i})
+
+		if p.expanded[key] {
+			for _, container := range pod.Containers {
+				rowIdx++
+				p.drawContainerRow(rowIdx, container, pod, metricsDisabled)
+				p.rows = append(p.rows, podRow{pod: i, container: container.Name})
+			}
+		}
+		if p.pinned[key] && i == pinnedPods {
+			pinnedPods++
+			pinnedRows = rowIdx
+		}
+	}
+	// pinned rows, at the top, stay in view when the table scrolls
+	p.list.SetFixed(1+pinnedRows, 0)
+	applyColumnWidths(p.list, p.colMap, p.widths)
+	p.reselect(selected)
+	p.columns.PrunePods()
+}
+
+// drawContainerRow draws, at rowIdx, the indented row of a container of pod
+func (p *podPanel) drawContainerRow(rowIdx int, container model.ContainerModel, pod model.PodModel, metricsDisabled bool) {
+	colorKeys := ui.ColorKeys{0: "green", 50: "yellow", 90: "red"}
+	nameIdx, ok := p.colMap["POD"]
+	if !ok {
+		nameIdx = 0
+	}
+	for _, colIdx := range p.colMap {
+		p.list.SetCell(rowIdx, colIdx, &tview.TableCell{Text: "", Align: tview.AlignLeft})
+	}
+	setCell := func(col, text string) {
+		if colIdx, ok := p.colMap[col]; ok {
+			p.list.SetCell(rowIdx, colIdx, &tview.TableCell{Text: text, Color: tcell.ColorSilver, Align: tview.AlignLeft})
+		}
+	}
+
+	p.list.SetCell(rowIdx, nameIdx, &tview.TableCell{Text: fmt.Sprintf("  %c %s", ui.Icons.TreeBranch, container.Name), Color: tcell.ColorSilver, Align: tview.AlignLeft})
+	ready := 0
+	if container.Ready {
+		ready = 1
 	}
+	setCell("READY", fmt.Sprintf("%d/1", ready))
+	setCell("STATUS", container.Status)
+	setCell("RESTARTS", fmt.Sprintf("%d", container.Restarts))
+	if metricsDisabled {
+		return
+	}
+
+	width := graphWidthFor(p.width, p.app.Compact())
+	cpuBase, memBase := p.base.containerQtys(container, pod)
+	cpuRatio := ui.GetRatio(float64(container.UsageCpuQty.MilliValue()), float64(cpuBase.MilliValue()))
+	cpuGraph := ui.BarGraph(width, cpuRatio, colorKeys)
+	cpuMetrics := fmt.Sprintf(
+		"[white][%s[white]] %s (%1.0f%%)",
+		cpuGraph, p.units.cpuPair(container.UsageCpuQty, cpuBase), cpuRatio*100,
+	)
+	memRatio := ui.GetRatio(float64(container.UsageMemQty.Value()), float64(memBase.Value()))
+	memGraph := ui.BarGraph(width, memRatio, colorKeys)
+	memMetrics := fmt.Sprintf(
+		"[white][%s[white]] %s (%1.0f%%)",
+		memGraph,
+		p.units.memPair(container.UsageMemQty, memBase, config.MemoryUnitMi),
+		memRatio*100,
+	)
+	if p.app.Compact() {
+		cpuMetrics = compactMetrics(cpuGraph, cpuRatio)
+		memMetrics = compactMetrics(memGraph, memRatio)
+	}
+	switch p.style {
+	case stylePercent:
+		cpuMetrics = ui.Percentage(cpuRatio, colorKeys)
+		memMetrics = ui.Percentage(memRatio, colorKeys)
+	case styleNumeric:
+		cpuRequest, cpuLimit := qtyOrZero(container.RequestedCpuQty), qtyOrZero(container.LimitCpuQty)
+		memRequest, memLimit := qtyOrZero(container.RequestedMemQty), qtyOrZero(container.LimitMemQty)
+		cpuMetrics = numericMetrics(p.units.cpuValues(container.UsageCpuQty, cpuRequest, cpuLimit), cpuRequest, cpuLimit)
+		memMetrics = numericMetrics(p.units.memValues(config.MemoryUnitMi, container.UsageMemQty, memRequest, memLimit), memRequest, memLimit)
+	}
+	if p.style != styleNumeric && p.base.unlimited(cpuBase) {
+		cpuMetrics = p.unlimitedMetrics(p.units.cpuValues(container.UsageCpuQty)[0])
+	}
+	if p.style != styleNumeric && p.base.unlimited(memBase) {
+		memMetrics = p.unlimitedMetrics(p.units.memValues(config.MemoryUnitMi, container.UsageMemQty)[0])
+	}
+	setCell("CPU", cpuMetrics)
+	setCell("MEMORY", memMetrics)
+	for _, col := range []string{"CPU", "MEMORY"} {
+		if colIdx, ok := p.colMap[col]; ok {
+			p.list.GetCell(rowIdx, colIdx).SetAlign(p.style.align())
+		}
+	}
+}
+
+// unlimitedMetrics returns the CPU, or memory, cell of a pod, or container, without limit when
+// the usage is compared to the limits: the usage and "-" for the limit, without graph nor
+// percentage, or only "-" in the percentage style
+func (p *podPanel) unlimitedMetrics(used string) string {
+	if p.style == stylePercent {
+		return "[white]-"
+	}
+	return fmt.Sprintf("[white]%s/-", used)
+}
+
+// rowKey returns the key of the pod, or pod container, displayed at row: namespace/name
+// for a pod and namespace/name/container for a container; p.markMu must be held.
+func (p *podPanel) rowKey(row int) string {
+	if row < 1 || row > len(p.rows) {
+		return ""
+	}
+	r := p.rows[row-1]
+	pod := p.pods[r.pod]
+	if r.container != "" {
+		return podKey(pod.Namespace, pod.Name) + "/" + r.container
+	}
+	return podKey(pod.Namespace, pod.Name)
+}
+
+// selectedKey returns the key (see rowKey) of the selected row, or an empty
+// string when no row is selected; p.markMu must be held
+func (p *podPanel) selectedKey() string {
+	row, _ := p.list.GetSelection()
+	return p.rowKey(row)
+}
+
+// reselect moves the selection to the row with key, so the selection follows the pod
+// when rows are re-sorted. The selected row is kept when the pod is gone; p.markMu must be held.
+func (p *podPanel) reselect(key string) {
+	if key == "" {
+		return
+	}
+	for row := 1; row <= len(p.rows); row++ {
+		if p.rowKey(row) == key {
+			if selected, _ := p.list.GetSelection(); selected != row {
+				p.list.Select(row, 0)
+			}
+			return
+		}
+	}
+}
+
+// selectedPod returns the pod displayed at the selected row, or the pod of the selected container row
+func (p *podPanel) selectedPod() (model.PodModel, bool) {
+	row, _ := p.list.GetSelection()
+	if row < 1 || row > len(p.rows) {
+		return model.PodModel{}, false
+	}
+	return p.pods[p.rows[row-1].pod], true
+}
+
+// expandSelected shows, or hides, the container rows of the selected pod.
+// Collapsing from a container row selects the pod row.
+func (p *podPanel) expandSelected(expand bool) {
+	p.markMu.Lock()
+	row, _ := p.list.GetSelection()
+	if row < 1 || row > len(p.rows) {
+		p.markMu.Unlock()
+		return
+	}
+	r := p.rows[row-1]
+	pod := p.pods[r.pod]
+	key := podKey(pod.Namespace, pod.Name)
+	if p.expanded[key] == expand {
+		p.markMu.Unlock()
+		return
+	}
+	if expand {
+		p.expanded[key] = true
+	} else {
+		delete(p.expanded, key)
+		for row > 1 && p.rows[row-1].container != "" {
+			row--
+		}
+		p.list.Select(row, 0)
+	}
+	pods := p.pods
+	p.markMu.Unlock()
+
+	p.Clear()
+	p.DrawBody(pods)
+}
+
+// resize lays out the columns, and bar graphs, again when the table width changes
+func (p *podPanel) resize(width int) {
+	if width == p.width {
+		return
+	}
+	graphChanged := graphWidthFor(width, p.app.Compact()) != graphWidthFor(p.width, p.app.Compact())
+	p.width = width
+	if !graphChanged && sameColumns(p.dropped, droppedColumns(p.listCols, podColumnDrops, width)) {
+		return
+	}
+
+	p.markMu.Lock()
+	pods := p.pods
+	p.markMu.Unlock()
+	p.Clear()
+	p.DrawBody(pods)
+}
+
+// toggleMark marks, or unmarks, the selected pod then moves the selection to the next row
+func (p *podPanel) toggleMark() {
+	pod, ok := p.selectedPod()
+	if !ok {
+		return
+	}
+	p.markMu.Lock()
+	key := podKey(pod.Namespace, pod.Name)
+	if p.marked[key] {
+		delete(p.marked, key)
+	} else {
+		p.marked[key] = true
+	}
+	pods := p.pods
+	p.markMu.Unlock()
+
+	p.Clear()
+	p.DrawBody(pods)
+	if row, _ := p.list.GetSelection(); row < p.list.GetRowCount()-1 {
+		p.list.Select(row+1, 0)
+	}
+}
+
+// clearMarks unmarks all pods
+func (p *podPanel) clearMarks() {
+	p.markMu.Lock()
+	p.marked = make(map[string]bool)
+	pods := p.pods
+	p.markMu.Unlock()
+
+	p.Clear()
+	p.DrawBody(pods)
+}
+
+// markedPods returns the marked pods that are currently displayed, in row order
+func (p *podPanel) markedPods() []model.PodModel {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	var result []model.PodModel
+	for _, pod := range p.pods {
+		if p.marked[podKey(pod.Namespace, pod.Name)] {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// togglePin pins, or unpins, the marked pods or, without marks, the selected pod
+func (p *podPanel) togglePin() {
+	pods := p.markedPods()
+	if len(pods) == 0 {
+		pod, ok := p.selectedPod()
+		if !ok {
+			return
+		}
+		pods = []model.PodModel{pod}
+	}
+
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	for _, pod := range pods {
+		key := podKey(pod.Namespace, pod.Name)
+		if p.pinned[key] {
+			delete(p.pinned, key)
+		} else {
+			p.pinned[key] = true
+		}
+	}
+}
+
+// pinnedKeys returns a copy of the namespace/name keys of the pinned pods
+func (p *podPanel) pinnedKeys() map[string]bool {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	keys := make(map[string]bool, len(p.pinned))
+	for key := range p.pinned {
+		keys[key] = true
+	}
+	return keys
+}
+
+// pruneMarks removes the marks, pins, and expansions of pods that no longer exist: pods is the
+// list of all the pods, including the pods hidden by the filter, whose marks are kept
+func (p *podPanel) pruneMarks(pods []model.PodModel) {
+	p.markMu.Lock()
+	defer p.markMu.Unlock()
+	if len(p.marked) == 0 && len(p.pinned) == 0 && len(p.expanded) == 0 {
+		return
+	}
+	current := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		current[podKey(pod.Namespace, pod.Name)] = true
+	}
+	for key := range p.marked {
+		if !current[key] {
+			delete(p.marked, key)
+		}
+	}
+	for key := range p.pinned {
+		if !current[key] {
+			delete(p.pinned, key)
+		}
+	}
+	for key := range p.expanded {
+		if !current[key] {
+			delete(p.expanded, key)
+		}
+	}
+}
+
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
 }
 
 func (p *podPanel) DrawFooter(_ interface{}) {}
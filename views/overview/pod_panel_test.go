@@ -0,0 +1,30 @@
+package overview
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestPruneMarks(t *testing.T) {
+	p := &podPanel{
+		marked:   map[string]bool{"default/web": true, "default/db": true, "default/gone": true},
+		pinned:   map[string]bool{"default/db": true, "default/gone": true},
+		expanded: map[string]bool{"default/web": true, "default/gone": true},
+	}
+	// default/db is listed, but hidden by the filter, and default/gone no longer exists
+	all := []model.PodModel{{Namespace: "default", Name: "web"}, {Namespace: "default", Name: "db"}}
+	p.pruneMarks(all)
+
+	expected := []map[string]bool{
+		{"default/web": true, "default/db": true},
+		{"default/db": true},
+		{"default/web": true},
+	}
+	for i, actual := range []map[string]bool{p.marked, p.pinned, p.expanded} {
+		if fmt.Sprint(actual) != fmt.Sprint(expected[i]) {
+			t.Errorf("expecting %v, got %v", expected[i], actual)
+		}
+	}
+}
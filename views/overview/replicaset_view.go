@@ -0,0 +1,58 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// showReplicaSetIssues displays the orphaned replica sets and the old deployment revisions
+// still holding pods, or retained beyond the deployment revision history limit.
+func (p *MainPanel) showReplicaSetIssues() {
+	replicaSets, err := p.app.GetK8sClient().Controller().GetReplicaSetIssues(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list replica sets: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "OWNER", "REVISION", "DESIRED", "PODS", "AGE", "ISSUE"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	holding := 0
+	for i, rs := range replicaSets {
+		color := tcell.ColorOrange
+		if rs.Issue != model.ReplicaSetExcessRevision {
+			color = tcell.ColorRed
+			holding++
+		}
+		values := []string{
+			rs.Namespace,
+			rs.Name,
+			valueOrDash(rs.Owner),
+			valueOrDash(rs.Revision),
+			fmt.Sprintf("%d", rs.Desired),
+			fmt.Sprintf("%d", rs.Pods),
+			rs.Age,
+			rs.Issue,
+		}
+		for j, val := range values {
+			table.SetCell(i+1, j, tview.NewTableCell(val).SetTextColor(color).SetExpansion(100))
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Replica set issues (%d, %d holding pods) (Esc: close) ", len(replicaSets), holding))
+	p.app.ShowNamedModal("replica sets", table)
+}
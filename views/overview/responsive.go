@@ -0,0 +1,92 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/ui"
+)
+
+const (
+	// graphWidth is the default width of the CPU/memory bar graphs
+	graphWidth = 10
+	// narrowGraphWidth is the width of the bar graphs in narrow panels
+	narrowGraphWidth = 5
+	// narrowWidth is the panel width below which bar graphs are shrunk
+	narrowWidth = 130
+)
+
+// columnDrop is a low-priority column that is hidden when the panel is narrower than minWidth
+type columnDrop struct {
+	column   string
+	minWidth int
+}
+
+// podColumnDrops lists, in drop order, the pod columns hidden in narrow panels
+var podColumnDrops = []columnDrop{
+	{column: "SCHEDULER", minWidth: 250},
+	{column: "SECURITY", minWidth: 240},
+	{column: "IMAGE", minWidth: 220},
+	{column: "LAST RESTART", minWidth: 200},
+	{column: "RESTART RATE", minWidth: 190},
+	{column: "VOLS", minWidth: 170},
+	{column: "IP", minWidth: 150},
+	{column: "THROTTLE%", minWidth: 140},
+	{column: "NODE", minWidth: 130},
+}
+
+// nodeColumnDrops lists, in drop order, the node columns hidden in narrow panels
+var nodeColumnDrops = []columnDrop{
+	{column: "OS", minWidth: 190},
+	{column: "DISK", minWidth: 170},
+	{column: "OS/ARC", minWidth: 150},
+	{column: "EXTERNAL-IP", minWidth: 140},
+	{column: "INT/EXT IPs", minWidth: 130},
+	{column: "INTERNAL-IP", minWidth: 120},
+}
+
+// droppedColumns returns the columns of cols hidden for the panel width, keeping
+// at least one column. A width of 0 (panel not drawn yet) hides no column.
+func droppedColumns(cols []string, drops []columnDrop, width int) map[string]bool {
+	dropped := make(map[string]bool)
+	if width <= 0 {
+		return dropped
+	}
+	for _, drop := range drops {
+		if width < drop.minWidth && containsColumn(cols, drop.column) && len(dropped) < len(cols)-1 {
+			dropped[drop.column] = true
+		}
+	}
+	return dropped
+}
+
+// graphWidthFor returns the bar graph width for the panel width and display mode
+func graphWidthFor(width int, compact bool) int {
+	if compact || (width > 0 && width < narrowWidth) {
+		return narrowGraphWidth
+	}
+	return graphWidth
+}
+
+// compactMetrics returns a compact metrics cell: the bar graph followed by the
+// ratios as percentages, i.e. "[|||  ] 45%/62%"
+func compactMetrics(graph string, ratios ...ui.Ratio) string {
+	percents := make([]string, len(ratios))
+	for i, ratio := range ratios {
+		percents[i] = fmt.Sprintf("%1.0f%%", ratio*100)
+	}
+	return fmt.Sprintf("[white][%s[white]] %s", graph, strings.Join(percents, "/"))
+}
+
+// sameColumns returns true if a and b contain the same columns
+func sameColumns(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for col := range a {
+		if !b[col] {
+			return false
+		}
+	}
+	return true
+}
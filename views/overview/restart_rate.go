@@ -0,0 +1,95 @@
+package overview
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// restartRecentWindow and restartHourWindow are the windows of the RESTART RATE column
+	restartRecentWindow = 10 * time.Minute
+	restartHourWindow   = time.Hour
+)
+
+// restartSample is the restart count of a pod at a point in time
+type restartSample struct {
+	time  time.Time
+	count int
+}
+
+// restartTracker keeps, for each pod, the restart counts observed over the last hour
+// in order to compute the restarts in a window instead of the lifetime total.
+type restartTracker struct {
+	samples map[string][]restartSample // keyed by namespace/name, oldest first
+}
+
+func newRestartTracker() *restartTracker {
+	return &restartTracker{samples: make(map[string][]restartSample)}
+}
+
+// record adds the restart counts of pods, observed at now, and sets their recent
+// and hourly restarts. The history of removed pods is dropped and the history of a
+// pod whose count decreased (i.e. a recreated pod) starts again.
+func (t *restartTracker) record(pods []model.PodModel, now time.Time) {
+	samples := make(map[string][]restartSample, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		key := pod.Namespace + "/" + pod.Name
+		history := t.samples[key]
+		if len(history) > 0 && history[len(history)-1].count > pod.Restarts {
+			history = nil
+		}
+		history = append(history, restartSample{time: now, count: pod.Restarts})
+		for len(history) > 1 && now.Sub(history[1].time) >= restartHourWindow {
+			history = history[1:]
+		}
+		samples[key] = history
+
+		pod.RecentRestarts = restartsSince(history, now.Add(-restartRecentWindow))
+		pod.HourRestarts = restartsSince(history, now.Add(-restartHourWindow))
+	}
+	t.samples = samples
+}
+
+// restartsSince returns the restarts of history since the time, counted from the
+// last sample not after time or, when observed later, from the oldest sample
+func restartsSince(history []restartSample, since time.Time) int {
+	base := history[0]
+	for _, sample := range history {
+		if sample.time.After(since) {
+			break
+		}
+		base = sample
+	}
+	return history[len(history)-1].count - base.count
+}
+
+// restartRateColor returns the color of the RESTART RATE column: red with recent
+// restarts, orange with restarts in the last hour, yellow otherwise
+func restartRateColor(pod model.PodModel) tcell.Color {
+	switch {
+	case pod.RecentRestarts > 0:
+		return tcell.ColorRed
+	case pod.HourRestarts > 0:
+		return tcell.ColorOrange
+	default:
+		return tcell.ColorYellow
+	}
+}
+
+// lastRestartColor returns the color of the LAST RESTART column, using the windows
+// of the RESTART RATE column
+func lastRestartColor(pod model.PodModel, now time.Time) tcell.Color {
+	switch {
+	case pod.LastRestart.IsZero():
+		return tcell.ColorYellow
+	case now.Sub(pod.LastRestart.Time) < restartRecentWindow:
+		return tcell.ColorRed
+	case now.Sub(pod.LastRestart.Time) < restartHourWindow:
+		return tcell.ColorOrange
+	default:
+		return tcell.ColorYellow
+	}
+}
@@ -0,0 +1,50 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestRestartTracker(t *testing.T) {
+	start := time.Now()
+
+	// restart counts observed, one per minute
+	tests := []struct {
+		name   string
+		counts []int
+		recent int
+		hour   int
+	}{
+		{name: "first sample", counts: []int{300}, recent: 0, hour: 0},
+		{name: "stable", counts: []int{300, 300, 300}, recent: 0, hour: 0},
+		{name: "recent restarts", counts: []int{300, 302, 305}, recent: 5, hour: 5},
+		{name: "older restarts", counts: append([]int{1, 4}, repeat(4, 20)...), recent: 0, hour: 3},
+		{name: "expired restarts", counts: append([]int{1, 4}, repeat(4, 70)...), recent: 0, hour: 0},
+		{name: "recreated pod", counts: []int{7, 0, 1}, recent: 1, hour: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			tracker := newRestartTracker()
+			var pods []model.PodModel
+			for i, count := range tc.counts {
+				pods = []model.PodModel{{Namespace: "default", Name: "pod", Restarts: count}}
+				tracker.record(pods, start.Add(time.Duration(i)*time.Minute))
+			}
+			if pods[0].RecentRestarts != tc.recent || pods[0].HourRestarts != tc.hour {
+				t.Fatalf("expecting restarts %d/%d, got %d/%d", tc.recent, tc.hour, pods[0].RecentRestarts, pods[0].HourRestarts)
+			}
+		})
+	}
+}
+
+func repeat(val, count int) []int {
+	result := make([]int, count)
+	for i := range result {
+		result[i] = val
+	}
+	return result
+}
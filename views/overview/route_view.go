@@ -0,0 +1,92 @@
+package overview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// showRoutes displays the ingresses, and Gateway API HTTPRoutes, with one row per backend
+// path along with the endpoint health of the backend service.
+func (p *MainPanel) showRoutes() {
+	routes, err := p.app.GetK8sClient().Controller().GetRouteModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list ingresses: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "HOSTS", "TLS", "PATH", "BACKEND", "ENDPOINTS"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	row, unhealthy := 1, 0
+	for _, route := range routes {
+		backends := route.Backends
+		if len(backends) == 0 {
+			backends = []model.RouteBackend{{}}
+		}
+		for i, backend := range backends {
+			var values []string
+			if i == 0 {
+				values = []string{
+					route.Namespace,
+					fmt.Sprintf("%s/%s", strings.ToLower(route.Kind), route.Name),
+					valueOrDash(strings.Join(route.Hosts, ",")),
+					valueOrDash(route.TLS),
+				}
+			} else {
+				values = []string{"", "", "", ""}
+			}
+
+			endpoints, endpointsColor := "-", tcell.ColorYellow
+			target := "-"
+			if backend.Service != "" {
+				target = backend.Service
+				if backend.Port != "" {
+					target += ":" + backend.Port
+				}
+				switch {
+				case !backend.ServiceFound:
+					endpoints, endpointsColor = fmt.Sprintf("%c service not found", ui.Icons.Warning), tcell.ColorRed
+				case !backend.Healthy():
+					endpoints, endpointsColor = fmt.Sprintf("%c 0/%d ready", ui.Icons.Warning, backend.EndpointsNotReady), tcell.ColorRed
+				default:
+					endpoints = fmt.Sprintf("%d/%d ready", backend.EndpointsReady, backend.EndpointsReady+backend.EndpointsNotReady)
+					if backend.EndpointsNotReady > 0 {
+						endpointsColor = tcell.ColorOrange
+					}
+				}
+				if !backend.Healthy() {
+					unhealthy++
+				}
+			}
+			values = append(values, valueOrDash(backend.Path), target, endpoints)
+
+			for j, val := range values {
+				cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+				if j == 6 {
+					cell.SetTextColor(endpointsColor)
+				}
+				table.SetCell(row, j, cell)
+			}
+			row++
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Ingresses and HTTPRoutes (%d, %d unhealthy backends) (Esc: close) ", len(routes), unhealthy))
+	p.app.ShowNamedModal("routes", table)
+}
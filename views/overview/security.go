@@ -0,0 +1,23 @@
+package overview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// podSecurity returns the SECURITY column text and color: the security posture flags
+// of the pod, in red when the pod runs privileged, as root, or in a host namespace
+func podSecurity(pod model.PodModel) (string, tcell.Color) {
+	if len(pod.SecurityFlags) == 0 {
+		return "-", tcell.ColorYellow
+	}
+	color := tcell.ColorOrange
+	for _, flag := range pod.SecurityFlags {
+		if flag != model.SecurityNoSecurityContext {
+			color = tcell.ColorRed
+		}
+	}
+	return strings.Join(pod.SecurityFlags, ","), color
+}
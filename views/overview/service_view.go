@@ -0,0 +1,69 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/ui"
+)
+
+// showServices displays the services with their ready and not ready endpoint counts,
+// flagging the services without ready endpoints.
+func (p *MainPanel) showServices() {
+	services, err := p.app.GetK8sClient().Controller().GetServiceModels(p.ctx)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Failed to list services: %s", err))
+		return
+	}
+
+	table := tview.NewTable().SetSelectable(true, false)
+	table.SetFixed(1, 0)
+	table.SetBorder(true)
+	table.SetTitleAlign(tview.AlignLeft)
+	for i, col := range []string{"NAMESPACE", "NAME", "TYPE", "CLUSTER-IP", "PORTS", "READY", "NOT READY"} {
+		table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+
+	unavailable := 0
+	for i, svc := range services {
+		name := svc.Name
+		readyColor, notReadyColor := tcell.ColorYellow, tcell.ColorYellow
+		if svc.NoReadyEndpoints() {
+			name = fmt.Sprintf("%c %s", ui.Icons.Warning, svc.Name)
+			readyColor = tcell.ColorRed
+			unavailable++
+		}
+		if svc.EndpointsNotReady > 0 {
+			notReadyColor = tcell.ColorOrange
+		}
+		values := []string{
+			svc.Namespace,
+			name,
+			svc.Type,
+			svc.ClusterIP,
+			svc.Ports,
+			fmt.Sprintf("%d", svc.EndpointsReady),
+			fmt.Sprintf("%d", svc.EndpointsNotReady),
+		}
+		for j, val := range values {
+			cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+			switch j {
+			case 5:
+				cell.SetTextColor(readyColor)
+			case 6:
+				cell.SetTextColor(notReadyColor)
+			}
+			table.SetCell(i+1, j, cell)
+		}
+	}
+	table.SetTitle(fmt.Sprintf(" Services (%d, %d without ready endpoints) (Esc: close) ", len(services), unavailable))
+	p.app.ShowNamedModal("services", table)
+}
@@ -0,0 +1,44 @@
+package overview
+
+// restoreSession selects, once the pods, or nodes, are listed, the rows selected at the end
+// of the previous session, and scrolls back to their position
+func (p *MainPanel) restoreSession(pods, nodes bool) {
+	p.mu.Lock()
+	restore := p.restore
+	if pods {
+		p.restore.SelectedPod = ""
+	}
+	if nodes {
+		p.restore.SelectedNode = ""
+	}
+	p.mu.Unlock()
+
+	restored := false
+	if pods && restore.SelectedPod != "" && p.podPanel.jumpTo(restore.SelectedPod) {
+		p.podPanel.list.SetOffset(restore.PodOffset, 0)
+		restored = true
+	}
+	if nodes && restore.SelectedNode != "" && p.nodePanel.selectNode(restore.SelectedNode) {
+		p.nodePanel.list.SetOffset(restore.NodeOffset, 0)
+		restored = true
+	}
+	if restored {
+		p.recordSession()
+	}
+}
+
+// recordSession saves the selected pod and node, and the scroll position of the panels, to the
+// session state of the cluster context
+func (p *MainPanel) recordSession() {
+	session := p.app.Session()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pod, ok := p.podPanel.selectedPod(); ok {
+		session.SelectedPod = podKey(pod.Namespace, pod.Name)
+	}
+	session.PodOffset, _ = p.podPanel.list.GetOffset()
+	if node, ok := p.nodePanel.selectedNode(); ok {
+		session.SelectedNode = node.Name
+	}
+	session.NodeOffset, _ = p.nodePanel.list.GetOffset()
+}
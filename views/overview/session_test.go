@@ -0,0 +1,120 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/k8s"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// testSessionPanel returns a main panel listing pods and nodes, with the session state of
+// the cluster context, restored from the previous session
+func testSessionPanel(session *config.ContextState) *MainPanel {
+	app := application.New(&k8s.Client{})
+	app.SetSession(session)
+	pods := []model.PodModel{
+		{Namespace: "default", Name: "web-0"},
+		{Namespace: "payments", Name: "api-0"},
+		{Namespace: "payments", Name: "api-1"},
+	}
+	return &MainPanel{
+		app:     app,
+		restore: *session,
+		podPanel: &podPanel{
+			list: tview.NewTable(),
+			pods: pods,
+			rows: []podRow{{pod: 0}, {pod: 1}, {pod: 1, container: "app"}, {pod: 2}},
+		},
+		nodePanel: &nodePanel{
+			list:  tview.NewTable(),
+			nodes: []model.NodeModel{{Name: "node-a"}, {Name: "node-b"}},
+		},
+	}
+}
+
+func TestRestoreSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  config.ContextState
+		pods     bool
+		nodes    bool
+		podRow   int
+		nodeRow  int
+		expected config.ContextState
+		pending  string // node selection restored once the nodes are listed
+	}{
+		{
+			name:     "pods and nodes",
+			session:  config.ContextState{SelectedPod: "payments/api-0", PodOffset: 1, SelectedNode: "node-b", NodeOffset: 1},
+			pods:     true,
+			nodes:    true,
+			podRow:   2,
+			nodeRow:  2,
+			expected: config.ContextState{SelectedPod: "payments/api-0", PodOffset: 1, SelectedNode: "node-b", NodeOffset: 1},
+		},
+		{
+			name:    "pods listed first",
+			session: config.ContextState{SelectedPod: "payments/api-1", PodOffset: 2, SelectedNode: "node-b", NodeOffset: 1},
+			pods:    true,
+			podRow:  4,
+			// the node scroll position is recorded again once the nodes are restored
+			expected: config.ContextState{SelectedPod: "payments/api-1", PodOffset: 2, SelectedNode: "node-b"},
+			pending:  "node-b",
+		},
+		{
+			name:     "pod gone",
+			session:  config.ContextState{Namespace: "payments", SelectedPod: "payments/api-2", PodOffset: 2},
+			pods:     true,
+			nodes:    true,
+			expected: config.ContextState{Namespace: "payments", SelectedPod: "payments/api-2", PodOffset: 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			session := tc.session
+			p := testSessionPanel(&session)
+			p.restoreSession(tc.pods, tc.nodes)
+
+			if row, _ := p.podPanel.list.GetSelection(); row != tc.podRow {
+				t.Errorf("expecting pod row %d selected, got %d", tc.podRow, row)
+			}
+			if row, _ := p.nodePanel.list.GetSelection(); row != tc.nodeRow {
+				t.Errorf("expecting node row %d selected, got %d", tc.nodeRow, row)
+			}
+			if session != tc.expected {
+				t.Errorf("expecting session %+v, got %+v", tc.expected, session)
+			}
+			// the selections of the listed panels are restored once
+			if p.restore.SelectedPod != "" || p.restore.SelectedNode != tc.pending {
+				t.Errorf("expecting pending node %q, got %+v", tc.pending, p.restore)
+			}
+		})
+	}
+}
+
+func TestRecordSession(t *testing.T) {
+	session := &config.ContextState{Namespace: "payments", Page: "Overview"}
+	p := testSessionPanel(session)
+
+	// a container row records its pod
+	p.podPanel.list.Select(3, 0).SetOffset(2, 0)
+	p.nodePanel.list.Select(1, 0)
+	p.recordSession()
+
+	expected := config.ContextState{Namespace: "payments", Page: "Overview", SelectedPod: "payments/api-0", PodOffset: 2, SelectedNode: "node-a"}
+	if *session != expected {
+		t.Errorf("expecting session %+v, got %+v", expected, *session)
+	}
+
+	// without a selection, the previous selection is kept
+	p.podPanel.list.Select(0, 0)
+	p.recordSession()
+	if session.SelectedPod != "payments/api-0" {
+		t.Errorf("expecting selected pod payments/api-0, got %s", session.SelectedPod)
+	}
+}
@@ -0,0 +1,313 @@
+package overview
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/ui"
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podSortKeys maps the pod panel sort keys to the sorted column
+var podSortKeys = map[rune]string{
+	'N': "POD",
+	'T': "STATUS",
+	'R': "RESTARTS",
+	'A': "AGE",
+	'O': "NODE",
+	'U': "CPU",
+	'M': "MEMORY",
+	'I': "IMAGE",
+}
+
+// nodeSortKeys maps the node panel sort keys to the sorted column
+var nodeSortKeys = map[rune]string{
+	'N': "NAME",
+	'T': "STATUS",
+	'A': "AGE",
+	'P': "PODS/IMGs",
+	'U': "CPU",
+	'M': "MEM",
+}
+
+// podCompare returns the comparison function, by column, used to sort pods
+var podCompare = map[string]func(a, b model.PodModel) int{
+	"NAMESPACE": func(a, b model.PodModel) int { return strings.Compare(a.Namespace, b.Namespace) },
+	"POD":       func(a, b model.PodModel) int { return strings.Compare(a.Name, b.Name) },
+	"STATUS":    func(a, b model.PodModel) int { return strings.Compare(a.Status, b.Status) },
+	"RESTARTS":  func(a, b model.PodModel) int { return a.Restarts - b.Restarts },
+	"AGE":       func(a, b model.PodModel) int { return compareAge(a.CreationTime, b.CreationTime) },
+	"NODE":      func(a, b model.PodModel) int { return strings.Compare(a.Node, b.Node) },
+	"CPU":       func(a, b model.PodModel) int { return compareQty(a.PodUsageCpuQty, b.PodUsageCpuQty) },
+	"MEMORY":    func(a, b model.PodModel) int { return compareQty(a.PodUsageMemQty, b.PodUsageMemQty) },
+	"SCHEDULER": func(a, b model.PodModel) int { return strings.Compare(a.Scheduler, b.Scheduler) },
+	"IP":        func(a, b model.PodModel) int { return compareIP(a.IP, b.IP) },
+	"RESTART RATE": func(a, b model.PodModel) int {
+		if a.RecentRestarts != b.RecentRestarts {
+			return a.RecentRestarts - b.RecentRestarts
+		}
+		return a.HourRestarts - b.HourRestarts
+	},
+	"IMAGE": func(a, b model.PodModel) int {
+		return strings.Compare(strings.Join(a.Images, ","), strings.Join(b.Images, ","))
+	},
+	"SECURITY":     func(a, b model.PodModel) int { return len(a.SecurityFlags) - len(b.SecurityFlags) },
+	"LAST RESTART": func(a, b model.PodModel) int { return compareAge(b.LastRestart, a.LastRestart) }, // most recent first
+	"THROTTLE%":    compareThrottle,
+	"EVICTION": func(a, b model.PodModel) int {
+		riskA, _ := podEvictionRisk(a)
+		riskB, _ := podEvictionRisk(b)
+		return riskA - riskB
+	},
+	"HOST NET": func(a, b model.PodModel) int {
+		if a.HostNetwork != b.HostNetwork {
+			if a.HostNetwork {
+				return 1
+			}
+			return -1
+		}
+		return len(a.HostPorts) - len(b.HostPorts)
+	},
+	"HUGEPAGES-2Mi": func(a, b model.PodModel) int {
+		return compareQty(model.HugePagesQty(a.HugePagesRequests, "hugepages-2Mi"), model.HugePagesQty(b.HugePagesRequests, "hugepages-2Mi"))
+	},
+	"HUGEPAGES-1Gi": func(a, b model.PodModel) int {
+		return compareQty(model.HugePagesQty(a.HugePagesRequests, "hugepages-1Gi"), model.HugePagesQty(b.HugePagesRequests, "hugepages-1Gi"))
+	},
+}
+
+// nodeCompare returns the comparison function, by column, used to sort nodes
+var nodeCompare = map[string]func(a, b model.NodeModel) int{
+	"NAME":        func(a, b model.NodeModel) int { return strings.Compare(a.Name, b.Name) },
+	"STATUS":      func(a, b model.NodeModel) int { return strings.Compare(a.Status, b.Status) },
+	"AGE":         func(a, b model.NodeModel) int { return compareAge(a.CreationTime, b.CreationTime) },
+	"PODS/IMGs":   func(a, b model.NodeModel) int { return a.PodsCount - b.PodsCount },
+	"OS":          func(a, b model.NodeModel) int { return strings.Compare(a.OS, b.OS) },
+	"CPU":         func(a, b model.NodeModel) int { return compareQty(a.UsageCpuQty, b.UsageCpuQty) },
+	"MEM":         func(a, b model.NodeModel) int { return compareQty(a.UsageMemQty, b.UsageMemQty) },
+	"INTERNAL-IP": func(a, b model.NodeModel) int { return compareIP(a.InternalIP, b.InternalIP) },
+	"EXTERNAL-IP": func(a, b model.NodeModel) int { return compareIP(a.ExternalIP, b.ExternalIP) },
+	"HUGEPAGES-2Mi": func(a, b model.NodeModel) int {
+		return compareQty(model.HugePagesQty(a.RequestedPodHugePages, "hugepages-2Mi"), model.HugePagesQty(b.RequestedPodHugePages, "hugepages-2Mi"))
+	},
+	"HUGEPAGES-1Gi": func(a, b model.NodeModel) int {
+		return compareQty(model.HugePagesQty(a.RequestedPodHugePages, "hugepages-1Gi"), model.HugePagesQty(b.RequestedPodHugePages, "hugepages-1Gi"))
+	},
+}
+
+// sortPods sorts pods by the column, then secondary column, of spec; pods with equal
+// values keep the default namespace/name order. An unknown column keeps the default order.
+func sortPods(pods []model.PodModel, spec config.SortState) {
+	model.SortPodModels(pods)
+	compare, ok := podCompare[spec.Column]
+	if !ok {
+		return
+	}
+	secondary := podCompare[spec.Secondary]
+	sort.SliceStable(pods, func(i, j int) bool {
+		if c := directed(compare(pods[i], pods[j]), spec.Desc); c != 0 || secondary == nil {
+			return c < 0
+		}
+		return directed(secondary(pods[i], pods[j]), spec.SecondaryDesc) < 0
+	})
+}
+
+// sortNodes sorts nodes by the column, then secondary column, of spec; nodes with equal
+// values keep the default name order. An unknown column keeps the default order.
+func sortNodes(nodes []model.NodeModel, spec config.SortState) {
+	model.SortNodeModels(nodes)
+	compare, ok := nodeCompare[spec.Column]
+	if !ok {
+		return
+	}
+	secondary := nodeCompare[spec.Secondary]
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if c := directed(compare(nodes[i], nodes[j]), spec.Desc); c != 0 || secondary == nil {
+			return c < 0
+		}
+		return directed(secondary(nodes[i], nodes[j]), spec.SecondaryDesc) < 0
+	})
+}
+
+// directed returns the comparison result c reversed for a descending sort
+func directed(c int, desc bool) int {
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// toggleSort returns the sort state after selecting column: the same column
+// reverses the direction, another column sorts ascending.
+func toggleSort(spec config.SortState, column string) config.SortState {
+	if spec.Column == column {
+		spec.Desc = !spec.Desc
+		return spec
+	}
+	spec.Column, spec.Desc = column, false
+	if spec.Secondary == column {
+		spec.Secondary, spec.SecondaryDesc = "", false
+	}
+	return spec
+}
+
+// toggleSecondarySort returns the sort state after selecting column as the secondary
+// sort column: the same column reverses its direction. Without a sort column, column
+// becomes the sort column.
+func toggleSecondarySort(spec config.SortState, column string) config.SortState {
+	switch {
+	case spec.Column == "" || spec.Column == column:
+		return toggleSort(spec, column)
+	case spec.Secondary == column:
+		spec.SecondaryDesc = !spec.SecondaryDesc
+	default:
+		spec.Secondary, spec.SecondaryDesc = column, false
+	}
+	return spec
+}
+
+// sortInfo returns a short description of spec, i.e. "NODE↑, CPU↓", or an empty string
+func sortInfo(spec config.SortState) string {
+	if spec.Column == "" {
+		return ""
+	}
+	info := columnSortInfo(spec.Column, spec.Desc)
+	if spec.Secondary != "" {
+		info += ", " + columnSortInfo(spec.Secondary, spec.SecondaryDesc)
+	}
+	return info
+}
+
+func columnSortInfo(column string, desc bool) string {
+	return fmt.Sprintf("%s%c", column, sortArrow(desc))
+}
+
+// sortArrow returns the icon of the sort direction
+func sortArrow(desc bool) rune {
+	if desc {
+		return ui.Icons.ArrowDown
+	}
+	return ui.Icons.ArrowUp
+}
+
+// compareAge compares the ages of objects created at a and b: the object created
+// first is the oldest, and sorts first in ascending order.
+func compareAge(a, b metav1.Time) int {
+	switch {
+	case a.Time.Before(b.Time):
+		return -1
+	case b.Time.Before(a.Time):
+		return 1
+	}
+	return 0
+}
+
+func compareQty(a, b *resource.Quantity) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Cmp(*b)
+}
+
+// regexFilterPrefix marks a pod filter as a regular expression matched against pod names
+const regexFilterPrefix = "~"
+
+// podMatcher returns a function that matches pods against filter: a case-insensitive
+// namespace/name substring or, when prefixed with ~, a regular expression matched
+// against pod names.
+func podMatcher(filter string) (func(model.PodModel) bool, error) {
+	if strings.HasPrefix(filter, regexFilterPrefix) {
+		expr, err := regexp.Compile(strings.TrimPrefix(filter, regexFilterPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return func(pod model.PodModel) bool { return expr.MatchString(pod.Name) }, nil
+	}
+	filter = strings.ToLower(filter)
+	return func(pod model.PodModel) bool {
+		return strings.Contains(strings.ToLower(podKey(pod.Namespace, pod.Name)), filter)
+	}, nil
+}
+
+// filterPods returns the pods matching filter (see podMatcher); an invalid filter matches no pod
+func filterPods(pods []model.PodModel, filter string) []model.PodModel {
+	if filter == "" {
+		return pods
+	}
+	match, err := podMatcher(filter)
+	if err != nil {
+		return nil
+	}
+	var result []model.PodModel
+	for _, pod := range pods {
+		if match(pod) {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// filterNodesByOS returns the nodes running os, or all the nodes when os is empty
+func filterNodesByOS(nodes []model.NodeModel, os string) []model.NodeModel {
+	if os == "" {
+		return nodes
+	}
+	var result []model.NodeModel
+	for _, node := range nodes {
+		if strings.EqualFold(node.OS, os) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// nextNodeOS returns the operating system, of nodes, following current in name
+// order; it returns an empty string, for all nodes, after the last one.
+func nextNodeOS(nodes []model.NodeModel, current string) string {
+	var systems []string
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if node.OS != "" && !seen[node.OS] {
+			seen[node.OS] = true
+			systems = append(systems, node.OS)
+		}
+	}
+	sort.Strings(systems)
+	for _, os := range systems {
+		if current == "" || os > current {
+			return os
+		}
+	}
+	return ""
+}
+
+// pinPods returns the pinned pods of all, in namespace/name order, followed by the
+// other pods of pods: pinned pods stay at the top whatever the sort and filter.
+func pinPods(all, pods []model.PodModel, pinned map[string]bool) []model.PodModel {
+	if len(pinned) == 0 {
+		return pods
+	}
+	var result []model.PodModel
+	for _, pod := range all {
+		if pinned[podKey(pod.Namespace, pod.Name)] {
+			result = append(result, pod)
+		}
+	}
+	model.SortPodModels(result)
+	for _, pod := range pods {
+		if !pinned[podKey(pod.Namespace, pod.Name)] {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package overview
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/ui"
+)
+
+// sortField is a sortable column, with its sort key in the panel (0 without key)
+type sortField struct {
+	column string
+	key    rune
+}
+
+// sortFields returns the sortable columns, the columns with a sort key first in key order,
+// followed by the other columns in name order
+func sortFields(columns []string, keys map[rune]string) []sortField {
+	byColumn := make(map[string]rune)
+	for key, column := range keys {
+		byColumn[column] = key
+	}
+	var fields []sortField
+	for _, column := range columns {
+		fields = append(fields, sortField{column: column, key: byColumn[column]})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i], fields[j]
+		if (a.key == 0) != (b.key == 0) {
+			return a.key != 0
+		}
+		if a.key != b.key {
+			return a.key < b.key
+		}
+		return a.column < b.column
+	})
+	return fields
+}
+
+// sortFieldText returns the list item text of field: its key, column, and direction in spec,
+// i.e. "Pods   [U]  CPU ↓ (primary)"
+func sortFieldText(kind string, field sortField, spec config.SortState) string {
+	key := "   "
+	if field.key != 0 {
+		key = fmt.Sprintf("[%c[]", field.key)
+	}
+	text := fmt.Sprintf("%-6s %s  %s", kind, key, field.column)
+	switch field.column {
+	case spec.Column:
+		text += fmt.Sprintf(" %c (primary)", sortArrow(spec.Desc))
+	case spec.Secondary:
+		text += fmt.Sprintf(" %c (secondary)", sortArrow(spec.SecondaryDesc))
+	}
+	return text
+}
+
+// showSortChooser displays the sortable node and pod columns, with their sort keys and
+// current direction; selecting a column sorts by it (again to reverse), and key '+' sets it
+// as the secondary sort column.
+func (p *MainPanel) showSortChooser() {
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true)
+	list.SetTitle(" Sort (Enter: sort, +: secondary sort, Esc: close) ")
+	list.SetTitleAlign(tview.AlignLeft)
+
+	type sortSet struct {
+		kind   string
+		fields []sortField
+		spec   func() config.SortState
+		sortBy func(column string, secondary bool)
+	}
+	var nodeColumns, podColumns []string
+	for column := range nodeCompare {
+		nodeColumns = append(nodeColumns, column)
+	}
+	for column := range podCompare {
+		podColumns = append(podColumns, column)
+	}
+	sets := []sortSet{
+		{kind: "Nodes", fields: sortFields(nodeColumns, nodeSortKeys), spec: func() config.SortState {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			return p.nodeSort
+		}, sortBy: p.sortNodesBy},
+		{kind: "Pods", fields: sortFields(podColumns, podSortKeys), spec: func() config.SortState {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			return p.podSort
+		}, sortBy: p.sortPodsBy},
+	}
+
+	// item returns the sort set and field of list item index
+	item := func(index int) (sortSet, sortField, bool) {
+		for _, set := range sets {
+			if index < len(set.fields) {
+				return set, set.fields[index], true
+			}
+			index -= len(set.fields)
+		}
+		return sortSet{}, sortField{}, false
+	}
+
+	var fill func()
+	fill = func() {
+		current := list.GetCurrentItem()
+		list.Clear()
+		for _, set := range sets {
+			set := set
+			spec := set.spec()
+			for _, field := range set.fields {
+				field := field
+				list.AddItem(sortFieldText(set.kind, field, spec), "", 0, func() {
+					set.sortBy(field.column, false)
+					fill()
+				})
+			}
+		}
+		list.SetCurrentItem(current)
+	}
+	fill()
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() != '+' {
+			return event
+		}
+		if set, field, ok := item(list.GetCurrentItem()); ok {
+			set.sortBy(field.column, true)
+			fill()
+		}
+		return nil
+	})
+
+	p.app.ShowNamedModal("sort", list, ui.KeyHint{Key: "Enter", Name: "sort"}, ui.KeyHint{Key: "+", Name: "secondary sort"})
+}
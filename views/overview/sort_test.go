@@ -0,0 +1,168 @@
+package overview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/views/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortPods(t *testing.T) {
+	now := time.Now()
+	pods := []model.PodModel{
+		{Namespace: "default", Name: "old", Restarts: 1, CreationTime: metav1.NewTime(now.Add(-72 * time.Hour))},
+		{Namespace: "default", Name: "new", Restarts: 5, CreationTime: metav1.NewTime(now.Add(-time.Minute))},
+		{Namespace: "default", Name: "mid", Restarts: 1, CreationTime: metav1.NewTime(now.Add(-3 * time.Hour))},
+	}
+
+	tests := []struct {
+		name     string
+		spec     config.SortState
+		expected []string
+	}{
+		{name: "default", spec: config.SortState{}, expected: []string{"mid", "new", "old"}},
+		{name: "age, oldest first", spec: config.SortState{Column: "AGE"}, expected: []string{"old", "mid", "new"}},
+		{name: "age desc", spec: config.SortState{Column: "AGE", Desc: true}, expected: []string{"new", "mid", "old"}},
+		{name: "restarts desc, name ties", spec: config.SortState{Column: "RESTARTS", Desc: true}, expected: []string{"new", "mid", "old"}},
+		{name: "restarts then age desc", spec: config.SortState{Column: "RESTARTS", Secondary: "AGE", SecondaryDesc: true}, expected: []string{"mid", "old", "new"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			sorted := make([]model.PodModel, len(pods))
+			copy(sorted, pods)
+			sortPods(sorted, tc.spec)
+			for i, name := range tc.expected {
+				if sorted[i].Name != name {
+					t.Fatalf("expecting pod %s at position %d, got %s", name, i, sorted[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterPods(t *testing.T) {
+	pods := []model.PodModel{
+		{Namespace: "shop", Name: "payments-api-1"},
+		{Namespace: "shop", Name: "payments-worker-1"},
+		{Namespace: "shop", Name: "payments-db-0"},
+		{Namespace: "payments", Name: "gateway-1"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected int
+	}{
+		{name: "no filter", filter: "", expected: 4},
+		{name: "substring matches namespace/name", filter: "PAYMENTS", expected: 4},
+		{name: "regex matches name", filter: "~^payments-(api|worker)-", expected: 2},
+		{name: "invalid regex", filter: "~payments-(", expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if got := len(filterPods(pods, tc.filter)); got != tc.expected {
+				t.Fatalf("expecting %d pods, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPinPods(t *testing.T) {
+	all := []model.PodModel{
+		{Namespace: "default", Name: "api"},
+		{Namespace: "default", Name: "db"},
+		{Namespace: "default", Name: "web"},
+	}
+
+	tests := []struct {
+		name     string
+		pods     []model.PodModel
+		pinned   map[string]bool
+		expected []string
+	}{
+		{name: "no pins", pods: []model.PodModel{all[2], all[0]}, expected: []string{"web", "api"}},
+		{name: "pinned first", pods: []model.PodModel{all[2], all[0]}, pinned: map[string]bool{"default/api": true}, expected: []string{"api", "web"}},
+		{name: "pinned but filtered out", pods: []model.PodModel{all[2]}, pinned: map[string]bool{"default/db": true}, expected: []string{"db", "web"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			pods := pinPods(all, tc.pods, tc.pinned)
+			if len(pods) != len(tc.expected) {
+				t.Fatalf("expecting %d pods, got %d", len(tc.expected), len(pods))
+			}
+			for i, name := range tc.expected {
+				if pods[i].Name != name {
+					t.Fatalf("expecting pod %s at position %d, got %s", name, i, pods[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestNextNodeOS(t *testing.T) {
+	nodes := []model.NodeModel{{Name: "a", OS: "windows"}, {Name: "b", OS: "linux"}, {Name: "c", OS: "linux"}}
+
+	tests := []struct {
+		name     string
+		nodes    []model.NodeModel
+		current  string
+		expected string
+	}{
+		{name: "first", nodes: nodes, current: "", expected: "linux"},
+		{name: "next", nodes: nodes, current: "linux", expected: "windows"},
+		{name: "all after last", nodes: nodes, current: "windows", expected: ""},
+		{name: "no nodes", current: "linux", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if os := nextNodeOS(tc.nodes, tc.current); os != tc.expected {
+				t.Fatalf("expecting os %q, got %q", tc.expected, os)
+			}
+			if filtered := filterNodesByOS(tc.nodes, tc.expected); tc.expected == "" && len(filtered) != len(tc.nodes) {
+				t.Fatalf("expecting all %d nodes, got %d", len(tc.nodes), len(filtered))
+			}
+		})
+	}
+}
+
+func TestSortFields(t *testing.T) {
+	fields := sortFields([]string{"OS", "NAME", "CPU", "AGE"}, map[rune]string{'N': "NAME", 'A': "AGE", 'U': "CPU"})
+	expected := []sortField{{column: "AGE", key: 'A'}, {column: "NAME", key: 'N'}, {column: "CPU", key: 'U'}, {column: "OS"}}
+	if len(fields) != len(expected) {
+		t.Fatalf("expecting fields %v, got %v", expected, fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Fatalf("expecting field %v at position %d, got %v", expected[i], i, fields[i])
+		}
+	}
+
+	spec := config.SortState{Column: "CPU", Desc: true, Secondary: "NAME"}
+	tests := []struct {
+		name     string
+		field    sortField
+		expected string
+	}{
+		{name: "primary", field: sortField{column: "CPU", key: 'U'}, expected: "Nodes  [U[]  CPU ↓ (primary)"},
+		{name: "secondary", field: sortField{column: "NAME", key: 'N'}, expected: "Nodes  [N[]  NAME ↑ (secondary)"},
+		{name: "without key", field: sortField{column: "OS"}, expected: "Nodes       OS"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if text := sortFieldText("Nodes", tc.field, spec); text != tc.expected {
+				t.Fatalf("expecting %q, got %q", tc.expected, text)
+			}
+		})
+	}
+}
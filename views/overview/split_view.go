@@ -0,0 +1,77 @@
+package overview
+
+import (
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// toggleSplit shows, or hides, the detail pane of the selected pod on the right half of
+// the pods panel. While shown, the pane follows the pod selection and is updated on each
+// pods refresh.
+func (p *MainPanel) toggleSplit() {
+	p.mu.Lock()
+	shown := p.split != nil
+	p.split = nil
+	p.mu.Unlock()
+	if shown {
+		p.layoutPods(nil)
+		return
+	}
+	if pod, ok := p.podPanel.selectedPod(); ok {
+		p.showSplit(pod)
+	}
+}
+
+// followSelection replaces the detail pane, when shown, with the pane of the selected pod
+func (p *MainPanel) followSelection() {
+	pod, ok := p.podPanel.selectedPod()
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	split := p.split
+	p.mu.Unlock()
+	if split == nil || (split.namespace == pod.Namespace && split.name == pod.Name) {
+		return
+	}
+	p.showSplit(pod)
+}
+
+// showSplit shows the detail pane of pod. The pod data is collected in the
+// background as it may query the metrics server.
+func (p *MainPanel) showSplit(pod model.PodModel) {
+	split := newPodFocus(p.app, pod.Namespace, pod.Name)
+	split.hint = "|: close"
+	p.mu.Lock()
+	p.split = split
+	models := p.lastPods
+	p.mu.Unlock()
+
+	go p.drawSplit(split, models, true)
+}
+
+// drawSplit collects then draws the data of the detail pane, laying the pane out
+// when layout is set. Panes replaced, or closed, in the meantime are not drawn.
+func (p *MainPanel) drawSplit(split *podFocus, models []model.PodModel, layout bool) {
+	data := split.collect(p.ctx, p.app.GetK8sClient().Controller(), models)
+	p.app.QueueUpdateDraw(func() {
+		p.mu.Lock()
+		current := p.split == split
+		p.mu.Unlock()
+		if !current {
+			return
+		}
+		split.draw(data)
+		if layout {
+			p.layoutPods(split)
+		}
+	})
+}
+
+// layoutPods lays out the pods panel alone, or next to the detail pane of split
+func (p *MainPanel) layoutPods(split *podFocus) {
+	p.podsPane.Clear()
+	p.podsPane.AddItem(p.podPanel.GetRootView(), 0, 1, true)
+	if split != nil {
+		p.podsPane.AddItem(split.root, 0, 1, false)
+	}
+}
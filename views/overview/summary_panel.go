@@ -2,11 +2,15 @@ package overview
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/config"
+	"github.com/vladimirvivien/ktop/k8s"
 	"github.com/vladimirvivien/ktop/ui"
 	"github.com/vladimirvivien/ktop/views/model"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -21,15 +25,35 @@ type clusterSummaryPanel struct {
 	listCols     []string
 	graphTable   *tview.Table
 	summaryTable *tview.Table
+	detailTable  *tview.Table // additional stats shown when expanded
+	expanded     bool
+	countdown    *refreshCountdown // time left until the next summary refresh
+	units        displayUnits      // units of the CPU and memory values
+	capacity     bool              // usage compared to the node capacity rather than allocatable
+	graphRows    int               // rows of the graphs: used, and requested with metrics
 }
 
-func NewClusterSummaryPanel(app *application.Application, title string) ui.Panel {
-	p := &clusterSummaryPanel{app: app, title: title}
+const (
+	// summaryHeight is the height of the summary panel, including borders, with one row of graphs
+	summaryHeight = 4
+	// summaryDetailRows is the number of additional rows of the expanded summary panel
+	summaryDetailRows = 4
+)
+
+func NewClusterSummaryPanel(app *application.Application, title string) *clusterSummaryPanel {
+	p := &clusterSummaryPanel{app: app, title: title, countdown: newRefreshCountdown(k8s.SummaryRefreshInterval), graphRows: 1}
 	p.Layout(nil)
 	p.children = append(p.children, p.graphTable)
 	return p
 }
 
+// drawCountdown sets the panel title with the refresh countdown
+func (p *clusterSummaryPanel) drawCountdown() {
+	if refresh := p.countdown.info(time.Now()); refresh != "" {
+		p.root.SetTitle(fmt.Sprintf("%s(%s) ", p.GetTitle(), refresh))
+	}
+}
+
 func (p *clusterSummaryPanel) GetTitle() string {
 	return p.title
 }
@@ -46,9 +70,33 @@ func (p *clusterSummaryPanel) Layout(data interface{}) {
 	p.graphTable.SetTitleAlign(tview.AlignLeft)
 	p.graphTable.SetBorderColor(tcell.ColorWhite)
 
+	p.detailTable = tview.NewTable()
+	p.detailTable.SetBorder(false)
+	p.detailTable.SetBorders(false)
+
+	// the summary is static: the focus goes to the additional rows, to scroll them, when expanded
+	p.summaryTable.SetFocusFunc(func() {
+		highlightPanel(p.root, true)
+		if p.expanded {
+			p.app.Focus(p.detailTable)
+		}
+	})
+	p.summaryTable.SetBlurFunc(func() {
+		highlightPanel(p.root, false)
+	})
+	p.detailTable.SetFocusFunc(func() {
+		p.detailTable.SetSelectable(true, false)
+		highlightPanel(p.root, true)
+	})
+	p.detailTable.SetBlurFunc(func() {
+		p.detailTable.SetSelectable(false, false)
+		highlightPanel(p.root, false)
+	})
+
 	root := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(p.summaryTable, 1, 1, true).
-		AddItem(p.graphTable, 1, 1, true)
+		AddItem(p.graphTable, 1, 1, true).
+		AddItem(p.detailTable, 0, 0, false)
 	root.SetBorder(true)
 	root.SetTitle(p.GetTitle())
 	root.SetTitleAlign(tview.AlignLeft)
@@ -62,41 +110,59 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 	colorKeys := ui.ColorKeys{0: "green", 40: "yellow", 80: "red"}
 	client := p.app.GetK8sClient()
 	graphSize := 40
+	if p.app.Compact() {
+		graphSize = graphWidth
+	}
 	switch summary := data.(type) {
 	case model.ClusterSummary:
 		var cpuRatio, memRatio ui.Ratio
 		var cpuGraph, memGraph string
 		var cpuMetrics, memMetrics string
-		if err := client.AssertMetricsAvailable(); err != nil { // metrics not available
-			cpuRatio = ui.GetRatio(float64(summary.RequestedPodCpuTotal.MilliValue()), float64(summary.AllocatableNodeCpuTotal.MilliValue()))
-			cpuGraph = ui.BarGraph(graphSize, cpuRatio, colorKeys)
-			cpuMetrics = fmt.Sprintf(
-				"CPU: [white][%s[white]] %dm/%dm (%02.1f%% requested)",
-				cpuGraph, summary.RequestedPodCpuTotal.MilliValue(), summary.AllocatableNodeCpuTotal.MilliValue(), cpuRatio*100,
-			)
+		cpuBase, memBase := summary.AllocatableNodeCpuTotal, summary.AllocatableNodeMemTotal
+		var cpuReserved, memReserved string
+		if p.capacity {
+			cpuBase, memBase = qtyOrZero(summary.CapacityNodeCpuTotal), qtyOrZero(summary.CapacityNodeMemTotal)
+			cpuReserved = fmt.Sprintf(", %s reserved", p.units.cpuValues(reservedQty(cpuBase, summary.AllocatableNodeCpuTotal))[0])
+			memReserved = fmt.Sprintf(", %s reserved", p.units.memValues(config.MemoryUnitGi, reservedQty(memBase, summary.AllocatableNodeMemTotal))[0])
+		}
+		// requested resources, shown below the used resources when metrics are available
+		cpuRatio = ui.GetRatio(float64(summary.RequestedPodCpuTotal.MilliValue()), float64(cpuBase.MilliValue()))
+		cpuGraph = ui.BarGraph(graphSize, cpuRatio, colorKeys)
+		cpuMetrics = fmt.Sprintf(
+			"CPU: [white][%s[white]] %s (%02.1f%% requested%s)",
+			cpuGraph, p.units.cpuPair(summary.RequestedPodCpuTotal, cpuBase), cpuRatio*100, cpuReserved,
+		)
 
-			memRatio = ui.GetRatio(float64(summary.RequestedPodMemTotal.MilliValue()), float64(summary.AllocatableNodeMemTotal.MilliValue()))
-			memGraph = ui.BarGraph(graphSize, memRatio, colorKeys)
-			memMetrics = fmt.Sprintf(
-				"Memory: [white][%s[white]] %dGi/%dGi (%02.1f%% requested)",
-				memGraph, summary.RequestedPodMemTotal.ScaledValue(resource.Giga), summary.AllocatableNodeMemTotal.ScaledValue(resource.Giga), memRatio*100,
-			)
-		} else {
-			cpuRatio = ui.GetRatio(float64(summary.UsageNodeCpuTotal.MilliValue()), float64(summary.AllocatableNodeCpuTotal.MilliValue()))
+		memRatio = ui.GetRatio(float64(summary.RequestedPodMemTotal.MilliValue()), float64(memBase.MilliValue()))
+		memGraph = ui.BarGraph(graphSize, memRatio, colorKeys)
+		memMetrics = fmt.Sprintf(
+			"Memory: [white][%s[white]] %s (%02.1f%% requested%s)",
+			memGraph, p.units.memPair(summary.RequestedPodMemTotal, memBase, config.MemoryUnitGi), memRatio*100, memReserved,
+		)
+		cpuRequested, memRequested := cpuMetrics, memMetrics
+
+		metricsAvailable := client.AssertMetricsAvailable() == nil
+		if metricsAvailable {
+			cpuRatio = ui.GetRatio(float64(summary.UsageNodeCpuTotal.MilliValue()), float64(cpuBase.MilliValue()))
 			cpuGraph = ui.BarGraph(graphSize, cpuRatio, colorKeys)
 			cpuMetrics = fmt.Sprintf(
-				"CPU: [white][%s[white]] %dm/%dm (%02.1f%% used)",
-				cpuGraph, summary.UsageNodeCpuTotal.MilliValue(), summary.AllocatableNodeCpuTotal.MilliValue(), cpuRatio*100,
+				"CPU: [white][%s[white]] %s (%02.1f%% used%s)",
+				cpuGraph, p.units.cpuPair(summary.UsageNodeCpuTotal, cpuBase), cpuRatio*100, cpuReserved,
 			)
 
-			memRatio = ui.GetRatio(float64(summary.UsageNodeMemTotal.MilliValue()), float64(summary.AllocatableNodeMemTotal.MilliValue()))
+			memRatio = ui.GetRatio(float64(summary.UsageNodeMemTotal.MilliValue()), float64(memBase.MilliValue()))
 			memGraph = ui.BarGraph(graphSize, memRatio, colorKeys)
 			memMetrics = fmt.Sprintf(
-				"Memory: [white][%s[white]] %dGi/%dGi (%02.1f%% used)",
-				memGraph, summary.UsageNodeMemTotal.ScaledValue(resource.Giga), summary.AllocatableNodeMemTotal.ScaledValue(resource.Giga), memRatio*100,
+				"Memory: [white][%s[white]] %s (%02.1f%% used%s)",
+				memGraph, p.units.memPair(summary.UsageNodeMemTotal, memBase, config.MemoryUnitGi), memRatio*100, memReserved,
 			)
 		}
 
+		if p.app.Compact() {
+			cpuMetrics = "CPU: " + compactMetrics(cpuGraph, cpuRatio)
+			memMetrics = "Memory: " + compactMetrics(memGraph, memRatio)
+		}
+
 		p.graphTable.SetCell(
 			0, 0,
 			tview.NewTableCell(cpuMetrics).
@@ -113,6 +179,20 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 				SetExpansion(100),
 		)
 
+		// the requested bars, under the used bars, show the gap between requests and usage
+		graphRows := 1
+		if metricsAvailable && !p.app.Compact() {
+			graphRows = 2
+			p.graphTable.SetCell(1, 0, tview.NewTableCell(cpuRequested).SetTextColor(tcell.ColorYellow).SetAlign(tview.AlignLeft).SetExpansion(100))
+			p.graphTable.SetCell(1, 1, tview.NewTableCell(memRequested).SetTextColor(tcell.ColorYellow).SetAlign(tview.AlignLeft).SetExpansion(100))
+		} else if p.graphTable.GetRowCount() > 1 {
+			p.graphTable.RemoveRow(1)
+		}
+		if graphRows != p.graphRows {
+			p.graphRows = graphRows
+			p.root.ResizeItem(p.graphTable, graphRows, 1)
+		}
+
 		// -=-=-=-=-=-=-=-=-=-=-=-=- cluster summary table -=-=-=-=-=-=-=-=-=-=-=-=-=-=-=-=-
 		p.summaryTable.SetCell(
 			0, 0,
@@ -130,7 +210,7 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 		)
 		p.summaryTable.SetCell(
 			0, 2,
-			tview.NewTableCell(fmt.Sprintf("Namespaces: [white]%d[white]", summary.Namespaces)).
+			tview.NewTableCell(namespacesText(summary)).
 				SetTextColor(tcell.ColorYellow).
 				SetAlign(tview.AlignLeft).
 				SetExpansion(100),
@@ -144,6 +224,17 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 				SetExpansion(100),
 		)
 
+		p.summaryTable.SetCell(
+			0, 4,
+			tview.NewTableCell(fmt.Sprintf(
+				"Pending: %s Failed: %s Unschedulable: %s",
+				countText(summary.PodsPending, "orange"), countText(summary.PodsFailed, "red"), countText(summary.PodsUnschedulable, "red"),
+			)).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100),
+		)
+
 		p.summaryTable.SetCell(
 			0, 5,
 			tview.NewTableCell(fmt.Sprintf("Deployments: [white]%d/%d", summary.DeploymentsReady, summary.DeploymentsTotal)).
@@ -160,6 +251,22 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 				SetExpansion(100),
 		)
 
+		p.summaryTable.SetCell(
+			0, 7,
+			tview.NewTableCell(quotaText(summary.Quota)).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100),
+		)
+
+		p.summaryTable.SetCell(
+			0, 8,
+			tview.NewTableCell(versionsText(summary)).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100),
+		)
+
 		p.summaryTable.SetCell(
 			0, 9,
 			tview.NewTableCell(fmt.Sprintf("Jobs: [white]%d (cron: %d)", summary.JobsCount, summary.CronJobsCount)).
@@ -179,11 +286,142 @@ func (p *clusterSummaryPanel) DrawBody(data interface{}) {
 				SetAlign(tview.AlignLeft).
 				SetExpansion(100),
 		)
+
+		p.drawDetails(summary)
 	default:
 		panic(fmt.Sprintf("SummaryPanel.DrawBody: unexpected type %T", data))
 	}
 }
 
+// drawDetails draws the additional stats of the expanded panel
+func (p *clusterSummaryPanel) drawDetails(summary model.ClusterSummary) {
+	rows := [][]string{
+		{
+			fmt.Sprintf(
+				"Pods by phase: [white]running %d, pending %d, succeeded %d, failed %d, unknown %d",
+				summary.PodsRunning, summary.PodsPending, summary.PodsSucceeded, summary.PodsFailed, summary.PodsUnknown,
+			),
+			fmt.Sprintf("Nodes: [white]%d/%d ready, %d pressures", summary.NodesReady, summary.NodesCount, summary.Pressures),
+		},
+		{
+			"Nodes by role: [white]" + countsText(summary.NodesByRole),
+			fmt.Sprintf("Volumes: [white]%d in use", summary.VolumesInUse),
+		},
+		{
+			fmt.Sprintf("Services: [white]%d (%s)", summary.ServicesCount, countsText(summary.ServicesByType)),
+			fmt.Sprintf("Endpoints: [white]%d ready, %s not ready", summary.EndpointsReady, countText(summary.EndpointsNotReady, "orange")),
+			fmt.Sprintf("Ingresses: [white]%d", summary.IngressesCount),
+		},
+		{
+			"Kubelet versions: [white]" + countsText(summary.KubeletVersions),
+			fmt.Sprintf("API server: [white]%s", p.app.GetK8sClient().GetServerVersion()),
+		},
+	}
+	for row, cells := range rows {
+		for col, text := range cells {
+			p.detailTable.SetCell(
+				row, col,
+				tview.NewTableCell(text).
+					SetTextColor(tcell.ColorYellow).
+					SetAlign(tview.AlignLeft).
+					SetExpansion(100),
+			)
+		}
+	}
+}
+
+// setExpanded shows, or hides, the additional stats rows and returns the panel height
+func (p *clusterSummaryPanel) setExpanded(expanded bool) int {
+	p.expanded = expanded
+	if !expanded {
+		p.root.ResizeItem(p.detailTable, 0, 0)
+	} else {
+		p.root.ResizeItem(p.detailTable, summaryDetailRows, 0)
+	}
+	return p.height()
+}
+
+// height returns the height of the panel, with the graph rows and the additional stats rows
+func (p *clusterSummaryPanel) height() int {
+	height := summaryHeight + p.graphRows - 1
+	if p.expanded {
+		height += summaryDetailRows
+	}
+	return height
+}
+
+// quotaText returns the used/hard values of the quota resource, colored by usage, or an empty string without quota
+func quotaText(quota *model.QuotaUsage) string {
+	if quota == nil {
+		return ""
+	}
+	ratio := ui.Ratio(quota.Ratio())
+	color := "green"
+	switch {
+	case ratio >= 0.9:
+		color = "red"
+	case ratio >= 0.7:
+		color = "orange"
+	}
+	return fmt.Sprintf(
+		"Quota: [%s]%s %s/%s (%1.0f%%) [white]%s/%s",
+		color, quota.Resource, quota.Used.String(), quota.Hard.String(), ratio*100, quota.Namespace, quota.Name,
+	)
+}
+
+// versionsText returns the number of kubelet versions with a warning badge when nodes
+// are outside of the supported version skew
+func versionsText(summary model.ClusterSummary) string {
+	text := fmt.Sprintf("Kubelets: [white]%d versions", len(summary.KubeletVersions))
+	if len(summary.KubeletVersions) == 1 {
+		for v := range summary.KubeletVersions {
+			text = fmt.Sprintf("Kubelets: [white]%s", v)
+		}
+	}
+	if summary.SkewedNodes > 0 {
+		text += fmt.Sprintf(" [red]%c %d skewed", ui.Icons.Warning, summary.SkewedNodes)
+	}
+	return text
+}
+
+// countsText returns the counts, sorted by name, i.e. "control-plane 1, worker 3"
+func countsText(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, fmt.Sprintf("%s %d", name, counts[name]))
+	}
+	return strings.Join(result, ", ")
+}
+
+// namespacesText returns the namespace count, with the recently created, Terminating,
+// and stuck namespaces when any (i.e. "Namespaces: 12 (new 1, terminating 2, stuck 1)")
+func namespacesText(summary model.ClusterSummary) string {
+	text := fmt.Sprintf("Namespaces: [white]%d", summary.Namespaces)
+	if summary.NamespacesRecent == 0 && summary.NamespacesTerminating == 0 {
+		return text
+	}
+	return fmt.Sprintf(
+		"%s[yellow] (new %s, terminating %s, stuck %s)",
+		text, countText(summary.NamespacesRecent, "green"), countText(summary.NamespacesTerminating, "orange"), countText(summary.NamespacesStuck, "red"),
+	)
+}
+
+// countText returns count in white, or in color when not zero
+func countText(count int, color string) string {
+	if count == 0 {
+		return fmt.Sprintf("[white]%d[yellow]", count)
+	}
+	return fmt.Sprintf("[%s]%d[yellow]", color, count)
+}
+
 func (p *clusterSummaryPanel) DrawFooter(data interface{}) {}
 
 func (p *clusterSummaryPanel) Clear() {}
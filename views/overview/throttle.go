@@ -0,0 +1,49 @@
+package overview
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// throttleWarnPercent and throttleAlertPercent are the THROTTLE% column thresholds,
+	// colored orange and red, of the throttled CPU periods
+	throttleWarnPercent  = 5
+	throttleAlertPercent = 25
+)
+
+// podThrottle returns the text and color of the THROTTLE% column: the percentage of the
+// CPU periods of the pod throttled by its CPU limit, or "-" until sampled
+func podThrottle(pod model.PodModel) (string, tcell.Color) {
+	if !pod.ThrottleSampled {
+		return "-", tcell.ColorYellow
+	}
+	text := fmt.Sprintf("%.0f%%", pod.ThrottlePercent)
+	switch {
+	case pod.ThrottlePercent >= throttleAlertPercent:
+		return text, tcell.ColorRed
+	case pod.ThrottlePercent >= throttleWarnPercent:
+		return text, tcell.ColorOrange
+	default:
+		return text, tcell.ColorYellow
+	}
+}
+
+// compareThrottle compares the throttling of pods, pods not sampled first
+func compareThrottle(a, b model.PodModel) int {
+	switch {
+	case a.ThrottleSampled != b.ThrottleSampled:
+		if a.ThrottleSampled {
+			return 1
+		}
+		return -1
+	case a.ThrottlePercent < b.ThrottlePercent:
+		return -1
+	case a.ThrottlePercent > b.ThrottlePercent:
+		return 1
+	default:
+		return 0
+	}
+}
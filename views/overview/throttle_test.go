@@ -0,0 +1,32 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+func TestPodThrottle(t *testing.T) {
+	tests := []struct {
+		name  string
+		pod   model.PodModel
+		text  string
+		color tcell.Color
+	}{
+		{name: "not sampled", pod: model.PodModel{}, text: "-", color: tcell.ColorYellow},
+		{name: "not throttled", pod: model.PodModel{ThrottleSampled: true}, text: "0%", color: tcell.ColorYellow},
+		{name: "throttled", pod: model.PodModel{ThrottleSampled: true, ThrottlePercent: 12.4}, text: "12%", color: tcell.ColorOrange},
+		{name: "heavily throttled", pod: model.PodModel{ThrottleSampled: true, ThrottlePercent: 60}, text: "60%", color: tcell.ColorRed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			text, color := podThrottle(tc.pod)
+			if text != tc.text || color != tc.color {
+				t.Fatalf("expecting %q (%v), got %q (%v)", tc.text, tc.color, text, color)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package overview
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/config"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	mebibyte = 1 << 20
+	gibibyte = 1 << 30
+)
+
+// displayUnits formats the CPU and memory values of the pod, node, and summary panels in the
+// units of the configuration file
+type displayUnits struct {
+	memory   string // Mi, Gi, or auto; empty for the panel default unit
+	decimals int    // decimals of the memory values, with memory
+	cores    bool   // CPU values in cores rather than millicores
+}
+
+func newDisplayUnits(cfg *config.Config) displayUnits {
+	return displayUnits{memory: cfg.MemoryUnit, decimals: cfg.MemoryDecimals, cores: cfg.CPUUnit == config.CPUUnitCores}
+}
+
+// cpuPair returns the used and total CPU, i.e. 250m/1000m, or 0.25/1 in cores
+func (u displayUnits) cpuPair(used, total *resource.Quantity) string {
+	return strings.Join(u.cpuValues(used, total), "/")
+}
+
+// memPair returns the used and total memory in the memory unit, i.e. 512Mi/2048Mi
+func (u displayUnits) memPair(used, total *resource.Quantity, defaultUnit string) string {
+	return strings.Join(u.memValues(defaultUnit, used, total), "/")
+}
+
+// cpuValues returns the CPU quantities in millicores, i.e. 250m, or in cores (i.e. 0.25)
+func (u displayUnits) cpuValues(qtys ...*resource.Quantity) []string {
+	values := make([]string, len(qtys))
+	for i, qty := range qtys {
+		if u.cores {
+			values[i] = formatCores(qty)
+		} else {
+			values[i] = fmt.Sprintf("%dm", qty.MilliValue())
+		}
+	}
+	return values
+}
+
+// memValues returns the memory quantities in the memory unit, i.e. 512Mi. Without memory unit,
+// the values are truncated to whole units of the panel default unit, Mi or Gi (i.e. 2Gi). The
+// auto unit uses Gi when the largest quantity is at least 1Gi.
+func (u displayUnits) memValues(defaultUnit string, qtys ...*resource.Quantity) []string {
+	values := make([]string, len(qtys))
+	unit := u.memory
+	switch unit {
+	case "":
+		divisor := int64(mebibyte)
+		if defaultUnit == config.MemoryUnitGi {
+			divisor = gibibyte
+		}
+		for i, qty := range qtys {
+			values[i] = fmt.Sprintf("%d%s", qty.Value()/divisor, defaultUnit)
+		}
+		return values
+	case config.MemoryUnitAuto:
+		unit = config.MemoryUnitMi
+		for _, qty := range qtys {
+			if qty.Value() >= gibibyte {
+				unit = config.MemoryUnitGi
+			}
+		}
+	}
+	for i, qty := range qtys {
+		values[i] = u.formatMem(qty, unit)
+	}
+	return values
+}
+
+// formatMem returns qty in unit, Mi or Gi, with the configured decimals
+func (u displayUnits) formatMem(qty *resource.Quantity, unit string) string {
+	divisor := float64(mebibyte)
+	if unit == config.MemoryUnitGi {
+		divisor = gibibyte
+	}
+	return strconv.FormatFloat(float64(qty.Value())/divisor, 'f', u.decimals, 64) + unit
+}
+
+// formatCores returns qty in cores, with up to 2 decimals, i.e. 0.25 or 4
+func formatCores(qty *resource.Quantity) string {
+	return strconv.FormatFloat(math.Round(float64(qty.MilliValue())/10)/100, 'f', -1, 64)
+}
@@ -0,0 +1,46 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/config"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDisplayUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		units    displayUnits
+		cpu      [2]string
+		mem      [2]string
+		defUnit  string
+		expected string
+	}{
+		{name: "millicores", cpu: [2]string{"250m", "1"}, expected: "250m/1000m"},
+		{name: "cores", units: displayUnits{cores: true}, cpu: [2]string{"250m", "1500m"}, expected: "0.25/1.5"},
+		{name: "default Mi", mem: [2]string{"512Mi", "2Gi"}, defUnit: config.MemoryUnitMi, expected: "512Mi/2048Mi"},
+		{name: "default Mi from decimal", mem: [2]string{"500M", "2G"}, defUnit: config.MemoryUnitMi, expected: "476Mi/1907Mi"},
+		{name: "default Gi", mem: [2]string{"1Gi", "4Gi"}, defUnit: config.MemoryUnitGi, expected: "1Gi/4Gi"},
+		{name: "default Gi from decimal", mem: [2]string{"4G", "8G"}, defUnit: config.MemoryUnitGi, expected: "3Gi/7Gi"},
+		{name: "Gi with decimals", units: displayUnits{memory: config.MemoryUnitGi, decimals: 1}, mem: [2]string{"512Mi", "2Gi"}, expected: "0.5Gi/2.0Gi"},
+		{name: "auto below 1Gi", units: displayUnits{memory: config.MemoryUnitAuto}, mem: [2]string{"256Mi", "512Mi"}, expected: "256Mi/512Mi"},
+		{name: "auto from 1Gi", units: displayUnits{memory: config.MemoryUnitAuto, decimals: 1}, mem: [2]string{"1536Mi", "4Gi"}, expected: "1.5Gi/4.0Gi"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			var result string
+			if tc.cpu[0] != "" {
+				used, total := resource.MustParse(tc.cpu[0]), resource.MustParse(tc.cpu[1])
+				result = tc.units.cpuPair(&used, &total)
+			} else {
+				used, total := resource.MustParse(tc.mem[0]), resource.MustParse(tc.mem[1])
+				result = tc.units.memPair(&used, &total, tc.defUnit)
+			}
+			if result != tc.expected {
+				t.Fatalf("expecting %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package overview
+
+import (
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// usageBase is the denominator of the CPU and MEMORY usage percentages of the pods panel
+type usageBase int
+
+const (
+	baseRequest usageBase = iota // the pod requests (default)
+	baseLimit                    // the pod limits
+	baseNode                     // the allocatable resources of the pod node
+)
+
+// usageBaseNames are the names of the usage bases, saved in the session state
+var usageBaseNames = []string{"request", "limit", "node"}
+
+// usageBaseDescriptions describe the usage bases, in the notification of a usage base change
+var usageBaseDescriptions = []string{"pod requests", "pod limits", "node allocatable resources"}
+
+// parseUsageBase returns the usage base named name, the pod requests for an unknown name
+func parseUsageBase(name string) usageBase {
+	for i, baseName := range usageBaseNames {
+		if baseName == name {
+			return usageBase(i)
+		}
+	}
+	return baseRequest
+}
+
+func (b usageBase) String() string {
+	return usageBaseNames[b]
+}
+
+// description returns the description of the usage base, i.e. pod limits
+func (b usageBase) description() string {
+	return usageBaseDescriptions[b]
+}
+
+// next returns the usage base following b: request, limit, node, then request again
+func (b usageBase) next() usageBase {
+	return (b + 1) % usageBase(len(usageBaseNames))
+}
+
+// header returns the header of the CPU, or MEMORY, column col for the usage base: col for
+// the requests, or else the base appended to col, i.e. CPU/LIMIT
+func (b usageBase) header(col string) string {
+	switch b {
+	case baseLimit:
+		return col + "/LIMIT"
+	case baseNode:
+		return col + "/NODE"
+	}
+	return col
+}
+
+// unlimited returns true when the usage is compared to the limits and base, the limit of a
+// pod or container, is not set: the usage has no percentage
+func (b usageBase) unlimited(base *resource.Quantity) bool {
+	return b == baseLimit && base.IsZero()
+}
+
+// podQtys returns the CPU and memory the usage of pod is compared to
+func (b usageBase) podQtys(pod model.PodModel) (cpu, mem *resource.Quantity) {
+	switch b {
+	case baseLimit:
+		return qtyOrZero(pod.PodLimitCpuQty), qtyOrZero(pod.PodLimitMemQty)
+	case baseNode:
+		return qtyOrZero(pod.NodeAllocatableCpuQty), qtyOrZero(pod.NodeAllocatableMemQty)
+	}
+	return qtyOrZero(pod.PodRequestedCpuQty), qtyOrZero(pod.PodRequestedMemQty)
+}
+
+// containerQtys returns the CPU and memory the usage of container, of pod, is compared to
+func (b usageBase) containerQtys(container model.ContainerModel, pod model.PodModel) (cpu, mem *resource.Quantity) {
+	switch b {
+	case baseLimit:
+		return qtyOrZero(container.LimitCpuQty), qtyOrZero(container.LimitMemQty)
+	case baseNode:
+		return qtyOrZero(pod.NodeAllocatableCpuQty), qtyOrZero(pod.NodeAllocatableMemQty)
+	}
+	return qtyOrZero(container.RequestedCpuQty), qtyOrZero(container.RequestedMemQty)
+}
+
+// qtyOrZero returns qty, or a zero quantity when qty is nil
+func qtyOrZero(qty *resource.Quantity) *resource.Quantity {
+	if qty == nil {
+		return resource.NewQuantity(0, resource.DecimalSI)
+	}
+	return qty
+}
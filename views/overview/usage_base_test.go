@@ -0,0 +1,61 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestUsageBase(t *testing.T) {
+	qty := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+	pod := model.PodModel{
+		PodRequestedCpuQty:    qty("100m"),
+		PodRequestedMemQty:    qty("128Mi"),
+		PodLimitCpuQty:        qty("500m"),
+		NodeAllocatableCpuQty: qty("4"),
+		NodeAllocatableMemQty: qty("16Gi"),
+	}
+
+	tests := []struct {
+		name         string
+		base         usageBase
+		header       string
+		cpu          string
+		mem          string
+		memUnlimited bool
+	}{
+		{name: "request", base: baseRequest, header: "CPU", cpu: "100m", mem: "128Mi"},
+		{name: "limit", base: baseLimit, header: "CPU/LIMIT", cpu: "500m", mem: "0", memUnlimited: true},
+		{name: "node", base: baseNode, header: "CPU/NODE", cpu: "4", mem: "16Gi"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if base := parseUsageBase(tc.base.String()); base != tc.base {
+				t.Fatalf("expecting base %s, got %s", tc.base, base)
+			}
+			if header := tc.base.header("CPU"); header != tc.header {
+				t.Fatalf("expecting header %s, got %s", tc.header, header)
+			}
+			cpu, mem := tc.base.podQtys(pod)
+			if cpu.Cmp(resource.MustParse(tc.cpu)) != 0 || mem.Cmp(resource.MustParse(tc.mem)) != 0 {
+				t.Fatalf("expecting %s and %s, got %s and %s", tc.cpu, tc.mem, cpu, mem)
+			}
+			if tc.base.unlimited(cpu) {
+				t.Errorf("expecting the CPU %s to be limited", cpu)
+			}
+			if unlimited := tc.base.unlimited(mem); unlimited != tc.memUnlimited {
+				t.Errorf("expecting the memory unlimited %t, got %t", tc.memUnlimited, unlimited)
+			}
+		})
+	}
+
+	if base := baseNode.next(); base != baseRequest {
+		t.Fatalf("expecting the node base followed by the request base, got %s", base)
+	}
+}
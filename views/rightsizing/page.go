@@ -0,0 +1,163 @@
+package rightsizing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/vladimirvivien/ktop/application"
+	"github.com/vladimirvivien/ktop/k8s"
+)
+
+// Page is the right-sizing report page: the workloads ranked by how far their pod requests
+// are from the usage observed during the session, with suggested requests
+type Page struct {
+	app     *application.Application
+	title   string
+	table   *tview.Table
+	tracker *Tracker
+}
+
+func New(app *application.Application, title string) *Page {
+	p := &Page{app: app, title: title, tracker: NewTracker()}
+	p.Layout(nil)
+	return p
+}
+
+func (p *Page) Layout(_ interface{}) {
+	p.table = tview.NewTable().SetSelectable(true, false)
+	p.table.SetFixed(1, 0)
+	p.table.SetBorder(true)
+	p.table.SetTitleAlign(tview.AlignLeft)
+	p.DrawHeader(nil)
+}
+
+func (p *Page) DrawHeader(_ interface{}) {
+	cols := []string{"VERDICT", "NAMESPACE", "WORKLOAD", "SAMPLES", "CPU REQ", "CPU AVG", "CPU PEAK", "CPU SUGGESTED", "MEM REQ", "MEM AVG", "MEM PEAK", "MEM SUGGESTED"}
+	for i, col := range cols {
+		p.table.SetCell(0, i,
+			tview.NewTableCell(col).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(tcell.ColorDarkGreen).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(100).
+				SetSelectable(false),
+		)
+	}
+}
+
+// DrawBody draws the over, then under, provisioned workloads of the report
+func (p *Page) DrawBody(_ interface{}) {
+	over, under := p.tracker.Report()
+	p.Clear()
+	row := 1
+	for _, section := range []struct {
+		verdict string
+		color   tcell.Color
+		rows    []Row
+	}{{"over", tcell.ColorOrange, over}, {"under", tcell.ColorRed, under}} {
+		for _, r := range section.rows {
+			values := []string{
+				section.verdict, r.Namespace, r.Workload, fmt.Sprintf("%d", r.Samples),
+				FormatCPU(r.CPURequest), FormatCPU(r.CPUAverage), FormatCPU(r.CPUPeak), FormatCPU(r.CPUSuggested),
+				FormatMem(r.MemRequest), FormatMem(r.MemAverage), FormatMem(r.MemPeak), FormatMem(r.MemSuggested),
+			}
+			for i, val := range values {
+				cell := tview.NewTableCell(val).SetTextColor(tcell.ColorYellow).SetExpansion(100)
+				if i == 0 || i == 7 || i == 11 {
+					cell.SetTextColor(section.color)
+				}
+				p.table.SetCell(row, i, cell)
+			}
+			row++
+		}
+	}
+
+	info := fmt.Sprintf("%d over-provisioned, %d under-provisioned", len(over), len(under))
+	if p.app.GetK8sClient().AssertMetricsAvailable() != nil {
+		info = "metrics not available"
+	}
+	p.table.SetTitle(fmt.Sprintf(" Right-sizing (%s) (e: export CSV) ", info))
+}
+
+func (p *Page) DrawFooter(_ interface{}) {}
+
+func (p *Page) Clear() {
+	p.table.Clear()
+	p.DrawHeader(nil)
+}
+
+func (p *Page) GetTitle() string {
+	return p.title
+}
+
+func (p *Page) GetRootView() tview.Primitive {
+	return p.table
+}
+
+func (p *Page) GetChildrenViews() []tview.Primitive {
+	return []tview.Primitive{p.table}
+}
+
+// Run samples, once the controller caches are synced and until ctx is done, the usage
+// of the pods at each pods refresh interval
+func (p *Page) Run(ctx context.Context) error {
+	go func() {
+		ctrl := p.app.GetK8sClient().Controller()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ctrl.Ready():
+		}
+
+		ticker := time.NewTicker(k8s.PodsRefreshInterval)
+		defer ticker.Stop()
+		for {
+			if p.app.GetK8sClient().AssertMetricsAvailable() == nil {
+				if pods, err := ctrl.GetPodModels(ctx); err == nil {
+					p.tracker.Record(pods)
+				}
+			}
+			p.app.QueueUpdateDraw(func() {
+				p.DrawBody(nil)
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// HandleInput handles the right-sizing page key bindings
+func (p *Page) HandleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event.Rune() == 'e' {
+		p.exportCSV()
+		return nil
+	}
+	return event
+}
+
+// exportCSV writes the report to a CSV file in the working directory
+func (p *Page) exportCSV() {
+	over, under := p.tracker.Report()
+	fileName := fmt.Sprintf("ktop-rightsizing-%s.csv", time.Now().Format("20060102-150405"))
+	file, err := os.Create(fileName)
+	if err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+		return
+	}
+	defer file.Close()
+
+	if err := WriteCSV(file, over, under); err != nil {
+		p.app.ShowMessage(fmt.Sprintf("Export failed: %s", err))
+		return
+	}
+	p.app.Notify(fmt.Sprintf("Right-sizing report exported to %s", fileName))
+}
@@ -0,0 +1,219 @@
+package rightsizing
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+const (
+	// minSamples is the number of pod usage samples of a workload before it is reported
+	minSamples = 3
+	// cpuHeadroom and memHeadroom are the margins added to the average CPU, and peak memory,
+	// usage of the workload pods to suggest their requests
+	cpuHeadroom = 1.25
+	memHeadroom = 1.2
+	// minCPUSuggestion and minMemSuggestion are the smallest suggested requests
+	minCPUSuggestion = 10               // millicores
+	minMemSuggestion = 16 * 1024 * 1024 // bytes
+	// overProvisionedRatio is the ratio of the suggested to the current requests, of both CPU
+	// and memory, below which a workload is over-provisioned
+	overProvisionedRatio = 0.5
+	// reportSize is the number of over, and under, provisioned workloads reported
+	reportSize = 20
+)
+
+// workloadUsage is the usage, per pod, of the pods of a workload observed during the session
+type workloadUsage struct {
+	samples         int
+	cpuSum, cpuPeak int64 // millicores
+	memSum, memPeak int64 // bytes
+	cpuReq, memReq  int64 // latest requests, per pod
+}
+
+// Tracker aggregates, per workload, the usage of the workload pods against their requests
+type Tracker struct {
+	mu        sync.Mutex
+	workloads map[string]*workloadUsage // keyed by namespace/kind/name
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{workloads: make(map[string]*workloadUsage)}
+}
+
+// Record adds the usage of the running pods managed by a workload
+func (t *Tracker) Record(pods []model.PodModel) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, pod := range pods {
+		if pod.Workload == "" || pod.Status != "Running" || pod.PodUsageCpuQty == nil || pod.PodUsageMemQty == nil {
+			continue
+		}
+		key := pod.Namespace + "/" + pod.Workload
+		usage, ok := t.workloads[key]
+		if !ok {
+			usage = &workloadUsage{}
+			t.workloads[key] = usage
+		}
+		cpu, mem := pod.PodUsageCpuQty.MilliValue(), pod.PodUsageMemQty.Value()
+		usage.samples++
+		usage.cpuSum += cpu
+		usage.memSum += mem
+		if cpu > usage.cpuPeak {
+			usage.cpuPeak = cpu
+		}
+		if mem > usage.memPeak {
+			usage.memPeak = mem
+		}
+		if pod.PodRequestedCpuQty != nil {
+			usage.cpuReq = pod.PodRequestedCpuQty.MilliValue()
+		}
+		if pod.PodRequestedMemQty != nil {
+			usage.memReq = pod.PodRequestedMemQty.Value()
+		}
+	}
+}
+
+// Row is the usage, per pod, of a workload with the suggested requests
+type Row struct {
+	Namespace string
+	Workload  string
+	Samples   int
+
+	CPURequest, CPUAverage, CPUPeak, CPUSuggested int64 // millicores
+	MemRequest, MemAverage, MemPeak, MemSuggested int64 // bytes
+
+	// Ratio is the largest ratio of the suggested to the current requests, +Inf without requests
+	Ratio float64
+}
+
+// Report returns the most over-provisioned workloads, the lowest ratio first, and the most
+// under-provisioned workloads (suggested requests above the current ones, or no requests),
+// the highest ratio first
+func (t *Tracker) Report() (over, under []Row) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, usage := range t.workloads {
+		if usage.samples < minSamples {
+			continue
+		}
+		row := newRow(key, usage)
+		switch {
+		case row.Ratio > 1:
+			under = append(under, row)
+		case row.Ratio < overProvisionedRatio:
+			over = append(over, row)
+		}
+	}
+	sort.Slice(over, func(i, j int) bool { return lessRow(over[i], over[j], false) })
+	sort.Slice(under, func(i, j int) bool { return lessRow(under[i], under[j], true) })
+	if len(over) > reportSize {
+		over = over[:reportSize]
+	}
+	if len(under) > reportSize {
+		under = under[:reportSize]
+	}
+	return over, under
+}
+
+// newRow returns the report row of the workload usage keyed by namespace/kind/name
+func newRow(key string, usage *workloadUsage) Row {
+	row := Row{
+		Samples:    usage.samples,
+		CPURequest: usage.cpuReq,
+		CPUAverage: usage.cpuSum / int64(usage.samples),
+		CPUPeak:    usage.cpuPeak,
+		MemRequest: usage.memReq,
+		MemAverage: usage.memSum / int64(usage.samples),
+		MemPeak:    usage.memPeak,
+	}
+	for i := range key {
+		if key[i] == '/' {
+			row.Namespace, row.Workload = key[:i], key[i+1:]
+			break
+		}
+	}
+	row.CPUSuggested = roundUp(int64(math.Ceil(float64(row.CPUAverage)*cpuHeadroom)), 5, minCPUSuggestion)
+	row.MemSuggested = roundUp(int64(math.Ceil(float64(row.MemPeak)*memHeadroom)), 1024*1024, minMemSuggestion)
+	row.Ratio = math.Max(ratio(row.CPUSuggested, row.CPURequest), ratio(row.MemSuggested, row.MemRequest))
+	return row
+}
+
+// lessRow orders rows by ratio, descending when desc is set, then by namespace and workload
+func lessRow(a, b Row, desc bool) bool {
+	if a.Ratio != b.Ratio {
+		return (a.Ratio < b.Ratio) != desc
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Workload < b.Workload
+}
+
+// ratio returns suggested/request, +Inf without request
+func ratio(suggested, request int64) float64 {
+	if request <= 0 {
+		return math.Inf(1)
+	}
+	return float64(suggested) / float64(request)
+}
+
+// roundUp rounds val up to a multiple of unit, at least min
+func roundUp(val, unit, min int64) int64 {
+	val = (val + unit - 1) / unit * unit
+	if val < min {
+		return min
+	}
+	return val
+}
+
+// FormatCPU returns the millicores as a CPU quantity, i.e. 250m, "-" when 0
+func FormatCPU(millis int64) string {
+	if millis == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dm", millis)
+}
+
+// FormatMem returns the bytes as a memory quantity in Mi, i.e. 256Mi, "-" when 0
+func FormatMem(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dMi", (bytes+1024*1024-1)/(1024*1024))
+}
+
+// WriteCSV writes the over, and under, provisioned workloads of the report as CSV to w
+func WriteCSV(w io.Writer, over, under []Row) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"verdict", "namespace", "workload", "samples",
+		"cpu request", "cpu average", "cpu peak", "cpu suggested",
+		"memory request", "memory average", "memory peak", "memory suggested",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, section := range []struct {
+		verdict string
+		rows    []Row
+	}{{"over-provisioned", over}, {"under-provisioned", under}} {
+		for _, row := range section.rows {
+			record := []string{
+				section.verdict, row.Namespace, row.Workload, fmt.Sprintf("%d", row.Samples),
+				FormatCPU(row.CPURequest), FormatCPU(row.CPUAverage), FormatCPU(row.CPUPeak), FormatCPU(row.CPUSuggested),
+				FormatMem(row.MemRequest), FormatMem(row.MemAverage), FormatMem(row.MemPeak), FormatMem(row.MemSuggested),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,127 @@
+package rightsizing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func usagePod(workload, cpuReq, memReq, cpu, mem string) model.PodModel {
+	cpuQty, memQty := resource.MustParse(cpu), resource.MustParse(mem)
+	pod := model.PodModel{
+		Namespace:      "default",
+		Workload:       workload,
+		Status:         "Running",
+		PodUsageCpuQty: &cpuQty,
+		PodUsageMemQty: &memQty,
+	}
+	if cpuReq != "" {
+		qty := resource.MustParse(cpuReq)
+		pod.PodRequestedCpuQty = &qty
+	}
+	if memReq != "" {
+		qty := resource.MustParse(memReq)
+		pod.PodRequestedMemQty = &qty
+	}
+	return pod
+}
+
+func TestTrackerReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		samples   int
+		pod       model.PodModel
+		over      []string
+		under     []string
+		suggested [2]string // cpu, memory
+	}{
+		{
+			name:    "not enough samples",
+			samples: minSamples - 1,
+			pod:     usagePod("Deployment/web", "1", "1Gi", "10m", "64Mi"),
+		},
+		{
+			name:      "over-provisioned",
+			samples:   minSamples,
+			pod:       usagePod("Deployment/web", "1", "1Gi", "100m", "100Mi"),
+			over:      []string{"Deployment/web"},
+			suggested: [2]string{"125m", "120Mi"},
+		},
+		{
+			name:      "under-provisioned",
+			samples:   minSamples,
+			pod:       usagePod("StatefulSet/db", "100m", "128Mi", "200m", "256Mi"),
+			under:     []string{"StatefulSet/db"},
+			suggested: [2]string{"250m", "308Mi"},
+		},
+		{
+			name:      "without requests",
+			samples:   minSamples,
+			pod:       usagePod("DaemonSet/agent", "", "", "1m", "1Mi"),
+			under:     []string{"DaemonSet/agent"},
+			suggested: [2]string{"10m", "16Mi"},
+		},
+		{
+			name:    "right-sized",
+			samples: minSamples,
+			pod:     usagePod("Deployment/api", "250m", "256Mi", "180m", "200Mi"),
+		},
+		{
+			name:    "standalone pod",
+			samples: minSamples,
+			pod:     usagePod("", "1", "1Gi", "1m", "1Mi"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			tracker := NewTracker()
+			for i := 0; i < tc.samples; i++ {
+				tracker.Record([]model.PodModel{tc.pod})
+			}
+			over, under := tracker.Report()
+			if got := workloads(over); strings.Join(got, ",") != strings.Join(tc.over, ",") {
+				t.Errorf("over-provisioned: expected %v, got %v", tc.over, got)
+			}
+			if got := workloads(under); strings.Join(got, ",") != strings.Join(tc.under, ",") {
+				t.Errorf("under-provisioned: expected %v, got %v", tc.under, got)
+			}
+			for _, row := range append(over, under...) {
+				if got := FormatCPU(row.CPUSuggested); got != tc.suggested[0] {
+					t.Errorf("suggested CPU: expected %s, got %s", tc.suggested[0], got)
+				}
+				if got := FormatMem(row.MemSuggested); got != tc.suggested[1] {
+					t.Errorf("suggested memory: expected %s, got %s", tc.suggested[1], got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	over := []Row{{Namespace: "default", Workload: "Deployment/web", Samples: 3, CPURequest: 1000, CPUSuggested: 125}}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, over, nil); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	expected := "over-provisioned,default,Deployment/web,3,1000m,-,-,125m,-,-,-,-"
+	if lines[1] != expected {
+		t.Errorf("expected %s, got %s", expected, lines[1])
+	}
+}
+
+func workloads(rows []Row) []string {
+	var names []string
+	for _, row := range rows {
+		names = append(names, row.Workload)
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package snapshot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Export formats
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvHeader is the header of the CSV export, one row per node then per pod: CPU quantities
+// are in millicores, memory quantities in bytes, and allocatable quantities are set for nodes only
+var csvHeader = []string{
+	"time", "kind", "namespace", "name", "status", "node", "restarts",
+	"cpu_usage_m", "cpu_requested_m", "cpu_allocatable_m",
+	"memory_usage_bytes", "memory_requested_bytes", "memory_allocatable_bytes",
+}
+
+// Write writes the snapshot to w in the export format, json or csv
+func Write(w io.Writer, snap Snapshot, format string) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, snap)
+	case FormatCSV:
+		return WriteCSV(w, snap)
+	}
+	return fmt.Errorf("unsupported snapshot format %q (want json or csv)", format)
+}
+
+// WriteJSON writes the snapshot, summary, nodes, and pods models, as indented JSON to w
+func WriteJSON(w io.Writer, snap Snapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snap)
+}
+
+// WriteCSV writes the nodes, then the pods, of the snapshot as CSV rows to w
+func WriteCSV(w io.Writer, snap Snapshot) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	now := snap.Time.UTC().Format(time.RFC3339)
+	for _, node := range snap.Nodes {
+		record := []string{
+			now, "node", "", node.Name, node.Status, "", "",
+			csvMilli(node.UsageCpuQty), csvMilli(node.RequestedPodCpuQty), csvMilli(node.AllocatableCpuQty),
+			csvValue(node.UsageMemQty), csvValue(node.RequestedPodMemQty), csvValue(node.AllocatableMemQty),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	for _, pod := range snap.Pods {
+		record := []string{
+			now, "pod", pod.Namespace, pod.Name, pod.Status, pod.Node, strconv.Itoa(pod.Restarts),
+			csvMilli(pod.PodUsageCpuQty), csvMilli(pod.PodRequestedCpuQty), "",
+			csvValue(pod.PodUsageMemQty), csvValue(pod.PodRequestedMemQty), "",
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvMilli returns the quantity in milli units, empty when not known
+func csvMilli(q *resource.Quantity) string {
+	if q == nil {
+		return ""
+	}
+	return strconv.FormatInt(q.MilliValue(), 10)
+}
+
+// csvValue returns the quantity in units, empty when not known
+func csvValue(q *resource.Quantity) string {
+	if q == nil {
+		return ""
+	}
+	return strconv.FormatInt(q.Value(), 10)
+}
@@ -0,0 +1,88 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestWriteExport(t *testing.T) {
+	snap := Snapshot{
+		Time:    time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Context: "prod",
+		Nodes: []model.NodeModel{{
+			Name:              "worker-1",
+			Status:            "Ready",
+			UsageCpuQty:       resource.NewMilliQuantity(500, resource.DecimalSI),
+			AllocatableCpuQty: resource.NewMilliQuantity(4000, resource.DecimalSI),
+			AllocatableMemQty: resource.NewQuantity(8*1024*1024*1024, resource.BinarySI),
+		}},
+		Pods: []model.PodModel{{
+			Namespace:          "default",
+			Name:               "web-0",
+			Status:             "Running",
+			Node:               "worker-1",
+			Restarts:           2,
+			PodRequestedCpuQty: resource.NewMilliQuantity(250, resource.DecimalSI),
+			PodUsageMemQty:     resource.NewQuantity(64*1024*1024, resource.BinarySI),
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected []string
+		err      bool
+	}{
+		{
+			name:   "csv",
+			format: FormatCSV,
+			expected: []string{
+				strings.Join(csvHeader, ","),
+				"2024-01-02T15:04:05Z,node,,worker-1,Ready,,,500,,4000,,,8589934592",
+				"2024-01-02T15:04:05Z,pod,default,web-0,Running,worker-1,2,,250,,67108864,,",
+			},
+		},
+		{name: "json", format: FormatJSON},
+		{name: "unsupported format", format: "yaml", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			var buf bytes.Buffer
+			err := Write(&buf, snap, tc.format)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expecting an error for format %q", tc.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.format == FormatJSON {
+				var decoded struct {
+					Context string
+					Pods    []struct{ Name string }
+				}
+				if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+					t.Fatal(err)
+				}
+				if decoded.Context != "prod" || len(decoded.Pods) != 1 || decoded.Pods[0].Name != "web-0" {
+					t.Errorf("unexpected JSON snapshot: %s", buf.String())
+				}
+				return
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if strings.Join(lines, "\n") != strings.Join(tc.expected, "\n") {
+				t.Errorf("expected:\n%s\ngot:\n%s", strings.Join(tc.expected, "\n"), buf.String())
+			}
+		})
+	}
+}
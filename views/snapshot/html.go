@@ -0,0 +1,191 @@
+package snapshot
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type htmlBar struct {
+	Text    string
+	Percent float64
+	Color   string
+}
+
+type htmlData struct {
+	Snapshot
+	Title      string
+	Uptime     string
+	UsageLabel string
+	ClusterCPU htmlBar
+	ClusterMem htmlBar
+	NodeRows   []htmlNodeRow
+	PodRows    []htmlPodRow
+}
+
+type htmlNodeRow struct {
+	model.NodeModel
+	CPU htmlBar
+	Mem htmlBar
+}
+
+type htmlPodRow struct {
+	model.PodModel
+	CPU *htmlBar
+	Mem *htmlBar
+}
+
+// WriteHTML renders the snapshot as a standalone HTML document, including
+// the usage bar graphs, to w.
+func WriteHTML(w io.Writer, snap Snapshot) error {
+	data := htmlData{
+		Snapshot:   snap,
+		Title:      fmt.Sprintf("ktop snapshot: %s (%s)", snap.Context, snap.Time.Format(time.RFC1123)),
+		Uptime:     duration.HumanDuration(snap.Time.Sub(snap.Summary.Uptime.Time)),
+		UsageLabel: "requested",
+	}
+
+	sum := snap.Summary
+	if snap.MetricsAvailable {
+		data.UsageLabel = "used"
+		data.ClusterCPU = milliBar(sum.UsageNodeCpuTotal, sum.AllocatableNodeCpuTotal, 40, 80)
+		data.ClusterMem = gigaBar(sum.UsageNodeMemTotal, sum.AllocatableNodeMemTotal, 40, 80)
+	} else {
+		data.ClusterCPU = milliBar(sum.RequestedPodCpuTotal, sum.AllocatableNodeCpuTotal, 40, 80)
+		data.ClusterMem = gigaBar(sum.RequestedPodMemTotal, sum.AllocatableNodeMemTotal, 40, 80)
+	}
+
+	for _, node := range snap.Nodes {
+		row := htmlNodeRow{NodeModel: node}
+		if snap.MetricsAvailable {
+			row.CPU = milliBar(node.UsageCpuQty, node.AllocatableCpuQty, 50, 90)
+			row.Mem = gigaBar(node.UsageMemQty, node.AllocatableMemQty, 50, 90)
+		} else {
+			row.CPU = milliBar(node.RequestedPodCpuQty, node.AllocatableCpuQty, 50, 90)
+			row.Mem = gigaBar(node.RequestedPodMemQty, node.AllocatableMemQty, 50, 90)
+		}
+		data.NodeRows = append(data.NodeRows, row)
+	}
+
+	for _, pod := range snap.Pods {
+		row := htmlPodRow{PodModel: pod}
+		if snap.MetricsAvailable {
+			cpu := milliBar(pod.PodUsageCpuQty, pod.PodRequestedCpuQty, 50, 90)
+			mem := megaBar(pod.PodUsageMemQty, pod.PodRequestedMemQty, 50, 90)
+			row.CPU, row.Mem = &cpu, &mem
+		}
+		data.PodRows = append(data.PodRows, row)
+	}
+
+	return htmlTemplate.Execute(w, data)
+}
+
+func milliBar(val, total *resource.Quantity, warn, crit float64) htmlBar {
+	v, t := milliValue(val), milliValue(total)
+	bar := newBar(float64(v), float64(t), warn, crit)
+	bar.Text = fmt.Sprintf("%dm/%dm (%1.0f%%)", v, t, bar.Percent)
+	return bar
+}
+
+func gigaBar(val, total *resource.Quantity, warn, crit float64) htmlBar {
+	bar := newBar(float64(milliValue(val)), float64(milliValue(total)), warn, crit)
+	bar.Text = fmt.Sprintf("%dGi/%dGi (%1.0f%%)", scaledValue(val, resource.Giga), scaledValue(total, resource.Giga), bar.Percent)
+	return bar
+}
+
+func megaBar(val, total *resource.Quantity, warn, crit float64) htmlBar {
+	bar := newBar(float64(milliValue(val)), float64(milliValue(total)), warn, crit)
+	bar.Text = fmt.Sprintf("%dMi/%dMi (%1.0f%%)", scaledValue(val, resource.Mega), scaledValue(total, resource.Mega), bar.Percent)
+	return bar
+}
+
+func newBar(val, total, warn, crit float64) htmlBar {
+	var pct float64
+	if total > 0 {
+		pct = val / total * 100
+	}
+	color := "#2e7d32"
+	switch {
+	case pct >= crit:
+		color = "#c62828"
+	case pct >= warn:
+		color = "#f9a825"
+	}
+	return htmlBar{Percent: pct, Color: color}
+}
+
+func milliValue(q *resource.Quantity) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.MilliValue()
+}
+
+func scaledValue(q *resource.Quantity, scale resource.Scale) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.ScaledValue(scale)
+}
+
+var htmlTemplate = template.Must(template.New("snapshot").Funcs(template.FuncMap{
+	"width": func(pct float64) string {
+		if pct > 100 {
+			pct = 100
+		}
+		return fmt.Sprintf("%1.0f%%", pct)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: monospace; background: #1e1e1e; color: #e0e0e0; margin: 1em; }
+h1 { font-size: 1.2em; }
+h2 { font-size: 1.1em; color: #ffd54f; margin-top: 1.5em; }
+table { border-collapse: collapse; width: 100%; }
+th { background: #1b5e20; color: #fff; text-align: left; padding: 2px 8px; }
+td { padding: 2px 8px; color: #ffd54f; white-space: nowrap; }
+tr:nth-child(even) td { background: #262626; }
+.summary td { color: #e0e0e0; }
+.bar { display: inline-block; width: 100px; height: 0.8em; background: #424242; vertical-align: middle; margin-right: 6px; }
+.bar.wide { width: 300px; }
+.bar span { display: block; height: 100%; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>API server: {{.Server}} | namespace: {{if .Namespace}}{{.Namespace}}{{else}}(all){{end}} | metrics: {{if .MetricsAvailable}}connected{{else}}not connected{{end}}</p>
+
+<h2>Cluster Summary</h2>
+<table class="summary">
+<tr><td>Uptime: {{.Uptime}}</td><td>Nodes: {{.Summary.NodesReady}}/{{.Summary.NodesCount}}</td><td>Namespaces: {{.Summary.Namespaces}}</td><td>Pods: {{.Summary.PodsRunning}}/{{.Summary.PodsAvailable}} ({{.Summary.ImagesCount}} imgs)</td><td>Pending: {{.Summary.PodsPending}} Failed: {{.Summary.PodsFailed}} Unschedulable: {{.Summary.PodsUnschedulable}}</td></tr>
+<tr><td>Deployments: {{.Summary.DeploymentsReady}}/{{.Summary.DeploymentsTotal}}</td><td>Sets: replicas {{.Summary.ReplicaSetsReady}}, daemons {{.Summary.DaemonSetsReady}}, stateful {{.Summary.StatefulSetsReady}}</td><td>Jobs: {{.Summary.JobsCount}} (cron: {{.Summary.CronJobsCount}})</td><td>PVs: {{.Summary.PVCount}} PVCs: {{.Summary.PVCCount}}</td></tr>
+<tr><td colspan="2">CPU: <span class="bar wide"><span style="width: {{width .ClusterCPU.Percent}}; background: {{.ClusterCPU.Color}}"></span></span>{{.ClusterCPU.Text}} {{.UsageLabel}}</td>
+<td colspan="2">Memory: <span class="bar wide"><span style="width: {{width .ClusterMem.Percent}}; background: {{.ClusterMem.Color}}"></span></span>{{.ClusterMem.Text}} {{.UsageLabel}}</td></tr>
+</table>
+
+<h2>Nodes ({{len .NodeRows}})</h2>
+<table>
+<tr><th>NAME</th><th>STATUS</th><th>AGE</th><th>VERSION</th><th>INT/EXT IPs</th><th>OS/ARC</th><th>PODS/IMGs</th><th>CPU</th><th>MEM</th></tr>
+{{range .NodeRows}}<tr><td>{{.Name}}</td><td>{{.Status}}</td><td>{{.TimeSinceStart}}</td><td>{{.KubeletVersion}}</td><td>{{.InternalIP}}/{{.ExternalIP}}</td><td>{{.OSImage}}/{{.Architecture}}</td><td>{{.PodsCount}}/{{.ContainerImagesCount}}</td>
+<td><span class="bar"><span style="width: {{width .CPU.Percent}}; background: {{.CPU.Color}}"></span></span>{{.CPU.Text}}</td>
+<td><span class="bar"><span style="width: {{width .Mem.Percent}}; background: {{.Mem.Color}}"></span></span>{{.Mem.Text}}</td></tr>
+{{end}}</table>
+
+<h2>Pods ({{len .PodRows}})</h2>
+<table>
+<tr><th>NAMESPACE</th><th>POD</th><th>READY</th><th>STATUS</th><th>RESTARTS</th><th>AGE</th><th>VOLS</th><th>IP</th><th>NODE</th><th>CPU</th><th>MEMORY</th></tr>
+{{range .PodRows}}<tr><td>{{.Namespace}}</td><td>{{.Name}}</td><td>{{.ReadyContainers}}/{{.TotalContainers}}</td><td>{{.Status}}</td><td>{{.Restarts}}</td><td>{{.TimeSince}}</td><td>{{.Volumes}}</td><td>{{.IP}}</td><td>{{.Node}}</td>
+{{if .CPU}}<td><span class="bar"><span style="width: {{width .CPU.Percent}}; background: {{.CPU.Color}}"></span></span>{{.CPU.Text}}</td>
+<td><span class="bar"><span style="width: {{width .Mem.Percent}}; background: {{.Mem.Color}}"></span></span>{{.Mem.Text}}</td>{{else}}<td>unavailable</td><td>unavailable</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
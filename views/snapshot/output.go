@@ -0,0 +1,99 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// noneValue is the value of missing fields, as printed by kubectl
+	noneValue = "<none>"
+
+	customColumnsPrefix = "custom-columns="
+)
+
+// OutputOptions are the text output options of a snapshot: the wide columns or custom columns
+type OutputOptions struct {
+	Wide    bool
+	Columns []CustomColumn
+}
+
+// CustomColumn is a column, as with kubectl -o custom-columns, with values
+// resolved against the fields of the pod, or node, models
+type CustomColumn struct {
+	Header string
+	Path   string
+	parser *jsonpath.JSONPath
+}
+
+// ParseOutput parses an output format: empty for the default columns, wide, or
+// custom-columns=<HEADER>:<field path>[,<HEADER>:<field path>...], where a field path
+// is a JSONPath expression on the model fields, i.e. custom-columns=POD:.Name,TEAM:.Labels.team
+func ParseOutput(output string) (OutputOptions, error) {
+	switch {
+	case output == "":
+		return OutputOptions{}, nil
+	case output == "wide":
+		return OutputOptions{Wide: true}, nil
+	case strings.HasPrefix(output, customColumnsPrefix):
+		columns, err := parseCustomColumns(strings.TrimPrefix(output, customColumnsPrefix))
+		if err != nil {
+			return OutputOptions{}, err
+		}
+		return OutputOptions{Columns: columns}, nil
+	default:
+		return OutputOptions{}, fmt.Errorf("unsupported output format %q (wide or custom-columns=...)", output)
+	}
+}
+
+func parseCustomColumns(spec string) ([]CustomColumn, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format requires at least one column")
+	}
+	var columns []CustomColumn
+	for _, col := range strings.Split(spec, ",") {
+		parts := strings.SplitN(col, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expecting <HEADER>:<field path>", col)
+		}
+		path := parts[1]
+		if !strings.HasPrefix(path, "{") {
+			path = "{" + path + "}"
+		}
+		parser := jsonpath.New(parts[0]).AllowMissingKeys(true)
+		if err := parser.Parse(path); err != nil {
+			return nil, fmt.Errorf("invalid custom column %q: %s", col, err)
+		}
+		columns = append(columns, CustomColumn{Header: parts[0], Path: parts[1], parser: parser})
+	}
+	return columns, nil
+}
+
+// value returns the column value for obj, a pod or node model: the values found
+// at the field path joined with commas, or <none>
+func (c CustomColumn) value(obj interface{}) string {
+	results, err := c.parser.FindResults(obj)
+	if err != nil {
+		return noneValue
+	}
+	var values []string
+	for _, result := range results {
+		for _, val := range result {
+			var buf bytes.Buffer
+			if err := c.parser.PrintResults(&buf, []reflect.Value{val}); err != nil {
+				return noneValue
+			}
+			if buf.Len() > 0 {
+				values = append(values, buf.String())
+			}
+		}
+	}
+	if len(values) == 0 {
+		return noneValue
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestCustomColumns(t *testing.T) {
+	pod := model.PodModel{
+		Name:           "api-0",
+		Labels:         map[string]string{"team": "payments"},
+		Images:         []string{"nginx:1.25", "envoy:1.28"},
+		PodUsageCpuQty: resource.NewMilliQuantity(250, resource.DecimalSI),
+	}
+
+	tests := []struct {
+		name     string
+		output   string
+		expected []string
+		err      bool
+	}{
+		{name: "fields", output: "custom-columns=NAME:.Name,TEAM:.Labels.team", expected: []string{"api-0", "payments"}},
+		{name: "braced path", output: "custom-columns=NAME:{.Name}", expected: []string{"api-0"}},
+		{name: "slice", output: "custom-columns=IMAGES:.Images[*]", expected: []string{"nginx:1.25,envoy:1.28"}},
+		{name: "quantity", output: "custom-columns=CPU:.PodUsageCpuQty", expected: []string{"250m"}},
+		{name: "missing", output: "custom-columns=OWNER:.Labels.owner,NOPE:.Nope", expected: []string{"<none>", "<none>"}},
+		{name: "missing path", output: "custom-columns=NAME", err: true},
+		{name: "unsupported format", output: "json", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			opts, err := ParseOutput(tc.output)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expecting an error for %q", tc.output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(opts.Columns) != len(tc.expected) {
+				t.Fatalf("expecting %d columns, got %d", len(tc.expected), len(opts.Columns))
+			}
+			for i, col := range opts.Columns {
+				if value := col.value(pod); value != tc.expected[i] {
+					t.Errorf("expecting column %s value %q, got %q", col.Header, tc.expected[i], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// Snapshot captures the overview data (summary, nodes, and pods) at a point in time
+type Snapshot struct {
+	Time             time.Time
+	Server           string
+	Context          string
+	Namespace        string
+	MetricsAvailable bool
+	Summary          model.ClusterSummary
+	Nodes            []model.NodeModel
+	Pods             []model.PodModel
+}
+
+// FileName returns a file name for the snapshot using the cluster context,
+// snapshot time, and the specified extension (i.e. html)
+func (s Snapshot) FileName(ext string) string {
+	return "ktop-" + safeName(s.Context) + "-" + s.Time.Format("20060102-150405") + "." + ext
+}
+
+func safeName(name string) string {
+	if name == "" {
+		return "cluster"
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			runes[i] = '_'
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vladimirvivien/ktop/views/model"
+)
+
+// nodeColumn is a column of the text nodes table
+type nodeColumn struct {
+	header string
+	value  func(snap Snapshot, node model.NodeModel) string
+}
+
+// podColumn is a column of the text pods table
+type podColumn struct {
+	header string
+	value  func(snap Snapshot, pod model.PodModel) string
+}
+
+// nodeText returns the value of the node column of the nodes panel, the same text in the
+// panel and in the snapshot
+func nodeText(column string) func(Snapshot, model.NodeModel) string {
+	return func(_ Snapshot, node model.NodeModel) string {
+		text, _ := model.NodeColumnText(column, node)
+		return valueOrNone(text)
+	}
+}
+
+// podText returns the value of the pod column of the pods panel, the same text in the
+// panel and in the snapshot
+func podText(column string) func(Snapshot, model.PodModel) string {
+	return func(_ Snapshot, pod model.PodModel) string {
+		text, _ := model.PodColumnText(column, pod)
+		return valueOrNone(text)
+	}
+}
+
+var nodeColumns = []nodeColumn{
+	{header: "NODE", value: nodeText("NAME")},
+	{header: "STATUS", value: nodeText("STATUS")},
+	{header: "AGE", value: nodeText("AGE")},
+	{header: "PODS", value: func(_ Snapshot, node model.NodeModel) string { return fmt.Sprint(node.PodsCount) }},
+	{header: "CPU", value: func(snap Snapshot, node model.NodeModel) string {
+		if snap.MetricsAvailable {
+			return milliBar(node.UsageCpuQty, node.AllocatableCpuQty, 50, 90).Text
+		}
+		return milliBar(node.RequestedPodCpuQty, node.AllocatableCpuQty, 50, 90).Text
+	}},
+	{header: "MEMORY", value: func(snap Snapshot, node model.NodeModel) string {
+		if snap.MetricsAvailable {
+			return gigaBar(node.UsageMemQty, node.MemoryBaseQty(), 50, 90).Text
+		}
+		return gigaBar(node.RequestedPodMemQty, node.AllocatableMemQty, 50, 90).Text
+	}},
+}
+
+// wideNodeColumns are the node columns added by the wide output
+var wideNodeColumns = []nodeColumn{
+	{header: "VERSION", value: nodeText("VERSION")},
+	{header: "INTERNAL-IP", value: nodeText("INTERNAL-IP")},
+	{header: "EXTERNAL-IP", value: nodeText("EXTERNAL-IP")},
+	{header: "OS-IMAGE", value: func(_ Snapshot, node model.NodeModel) string { return node.OSImage }},
+	{header: "KERNEL-VERSION", value: func(_ Snapshot, node model.NodeModel) string { return node.OSKernel }},
+	{header: "CONTAINER-RUNTIME", value: func(_ Snapshot, node model.NodeModel) string { return node.ContainerRuntimeVersion }},
+}
+
+var podColumns = []podColumn{
+	{header: "NAMESPACE", value: podText("NAMESPACE")},
+	{header: "POD", value: podText("POD")},
+	{header: "READY", value: podText("READY")},
+	{header: "STATUS", value: podText("STATUS")},
+	{header: "RESTARTS", value: podText("RESTARTS")},
+	{header: "AGE", value: podText("AGE")},
+	{header: "NODE", value: podText("NODE")},
+	{header: "CPU", value: func(snap Snapshot, pod model.PodModel) string {
+		if !snap.MetricsAvailable {
+			return "-"
+		}
+		return milliBar(pod.PodUsageCpuQty, pod.PodRequestedCpuQty, 50, 90).Text
+	}},
+	{header: "MEMORY", value: func(snap Snapshot, pod model.PodModel) string {
+		if !snap.MetricsAvailable {
+			return "-"
+		}
+		return megaBar(pod.PodUsageMemQty, pod.PodRequestedMemQty, 50, 90).Text
+	}},
+}
+
+// widePodColumns are the pod columns added by the wide output
+var widePodColumns = []podColumn{
+	{header: "IP", value: func(_ Snapshot, pod model.PodModel) string { return valueOrNone(strings.Join(pod.IPs, ",")) }},
+	{header: "VOLS", value: podText("VOLS")},
+	{header: "IMAGES", value: func(_ Snapshot, pod model.PodModel) string { return valueOrNone(strings.Join(pod.Images, ",")) }},
+}
+
+// WriteText renders the snapshot as plain text tables, nodes then pods, to w with the
+// default, wide, or custom columns of the output options
+func WriteText(w io.Writer, snap Snapshot, opts OutputOptions) error {
+	usage := "requested"
+	if snap.MetricsAvailable {
+		usage = "used"
+	}
+	namespace := snap.Namespace
+	if namespace == "" {
+		namespace = "(all)"
+	}
+	fmt.Fprintf(w, "ktop snapshot: %s, namespace: %s, %s (CPU and memory %s)\n\n", snap.Context, namespace, snap.Time.Format(time.RFC1123), usage)
+
+	nodeCols, podCols := nodeColumns, podColumns
+	if opts.Wide {
+		nodeCols = append(append([]nodeColumn{}, nodeColumns...), wideNodeColumns...)
+		podCols = append(append([]podColumn{}, podColumns...), widePodColumns...)
+	}
+	if len(opts.Columns) > 0 {
+		nodeCols, podCols = nil, nil
+		for _, col := range opts.Columns {
+			col := col
+			nodeCols = append(nodeCols, nodeColumn{header: col.Header, value: func(_ Snapshot, node model.NodeModel) string { return col.value(node) }})
+			podCols = append(podCols, podColumn{header: col.Header, value: func(_ Snapshot, pod model.PodModel) string { return col.value(pod) }})
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	var row []string
+	for _, col := range nodeCols {
+		row = append(row, col.header)
+	}
+	fmt.Fprintln(tw, strings.Join(row, "\t"))
+	for _, node := range snap.Nodes {
+		row = row[:0]
+		for _, col := range nodeCols {
+			row = append(row, col.value(snap, node))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	fmt.Fprintln(tw)
+
+	row = row[:0]
+	for _, col := range podCols {
+		row = append(row, col.header)
+	}
+	fmt.Fprintln(tw, strings.Join(row, "\t"))
+	for _, pod := range snap.Pods {
+		row = row[:0]
+		for _, col := range podCols {
+			row = append(row, col.value(snap, pod))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// valueOrNone returns value, or <none> when empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return noneValue
+	}
+	return value
+}
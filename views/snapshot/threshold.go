@@ -0,0 +1,147 @@
+package snapshot
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// thresholdExpr matches threshold expressions: <object>.<metric><operator><value>
+var thresholdExpr = regexp.MustCompile(`^\s*(node|pod)\.([a-z]+)\s*(>=|<=|==|!=|>|<)\s*([0-9]+(?:\.[0-9]+)?)\s*$`)
+
+// thresholdMetrics are the metrics, by object, a threshold can be set on:
+// cpu and mem are percentages of the node allocatable resources.
+var thresholdMetrics = map[string][]string{
+	"node": {"cpu", "mem", "pods"},
+	"pod":  {"cpu", "mem", "restarts"},
+}
+
+// Threshold is a limit on a node, or pod, metric, i.e. node.cpu>90
+type Threshold struct {
+	Expr     string
+	Object   string // node or pod
+	Metric   string
+	Operator string
+	Value    float64
+}
+
+// ParseThreshold parses a threshold expression: node.cpu, node.mem, node.pods,
+// pod.cpu, pod.mem, or pod.restarts, followed by an operator (>, >=, <, <=, ==,
+// or !=) and a number. The cpu and mem metrics are percentages of the node
+// allocatable resources, used (or requested without metrics).
+func ParseThreshold(expr string) (Threshold, error) {
+	match := thresholdExpr.FindStringSubmatch(expr)
+	if match == nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q, expecting <node|pod>.<metric><operator><value> (i.e. node.cpu>90)", expr)
+	}
+	object, metric := match[1], match[2]
+	supported := false
+	for _, m := range thresholdMetrics[object] {
+		supported = supported || m == metric
+	}
+	if !supported {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: unsupported %s metric %s (%s)", expr, object, metric, strings.Join(thresholdMetrics[object], ", "))
+	}
+	value, err := strconv.ParseFloat(match[4], 64)
+	if err != nil {
+		return Threshold{}, fmt.Errorf("invalid threshold %q: %s", expr, err)
+	}
+	return Threshold{Expr: strings.TrimSpace(expr), Object: object, Metric: metric, Operator: match[3], Value: value}, nil
+}
+
+// Breaches returns the nodes, or pods, of the snapshot breaching the threshold,
+// described with their metric value (i.e. node/worker-1 cpu=93.5%)
+func (t Threshold) Breaches(snap Snapshot) []string {
+	var breaches []string
+	switch t.Object {
+	case "node":
+		for _, node := range snap.Nodes {
+			if value := t.nodeValue(node, snap.MetricsAvailable); t.breached(value) {
+				breaches = append(breaches, fmt.Sprintf("node/%s %s=%s", node.Name, t.Metric, t.format(value)))
+			}
+		}
+	case "pod":
+		for _, pod := range snap.Pods {
+			if value := t.podValue(pod, snap.MetricsAvailable); t.breached(value) {
+				breaches = append(breaches, fmt.Sprintf("pod/%s/%s %s=%s", pod.Namespace, pod.Name, t.Metric, t.format(value)))
+			}
+		}
+	}
+	sort.Strings(breaches)
+	return breaches
+}
+
+func (t Threshold) nodeValue(node model.NodeModel, metrics bool) float64 {
+	switch t.Metric {
+	case "cpu":
+		if metrics {
+			return percent(node.UsageCpuQty, node.AllocatableCpuQty, true)
+		}
+		return percent(node.RequestedPodCpuQty, node.AllocatableCpuQty, true)
+	case "mem":
+		if metrics {
+			return percent(node.UsageMemQty, node.MemoryBaseQty(), false)
+		}
+		return percent(node.RequestedPodMemQty, node.AllocatableMemQty, false)
+	default:
+		return float64(node.PodsCount)
+	}
+}
+
+func (t Threshold) podValue(pod model.PodModel, metrics bool) float64 {
+	switch t.Metric {
+	case "cpu":
+		if metrics {
+			return percent(pod.PodUsageCpuQty, pod.NodeAllocatableCpuQty, true)
+		}
+		return percent(pod.PodRequestedCpuQty, pod.NodeAllocatableCpuQty, true)
+	case "mem":
+		if metrics {
+			return percent(pod.PodUsageMemQty, pod.NodeAllocatableMemQty, false)
+		}
+		return percent(pod.PodRequestedMemQty, pod.NodeAllocatableMemQty, false)
+	default:
+		return float64(pod.Restarts)
+	}
+}
+
+func (t Threshold) breached(value float64) bool {
+	switch t.Operator {
+	case ">":
+		return value > t.Value
+	case ">=":
+		return value >= t.Value
+	case "<":
+		return value < t.Value
+	case "<=":
+		return value <= t.Value
+	case "==":
+		return value == t.Value
+	default:
+		return value != t.Value
+	}
+}
+
+// percent returns used as a percentage of total, comparing milli values when milli is set
+func percent(used, total *resource.Quantity, milli bool) float64 {
+	if used == nil || total == nil || total.IsZero() {
+		return 0
+	}
+	if milli {
+		return float64(used.MilliValue()) * 100 / float64(total.MilliValue())
+	}
+	return float64(used.Value()) * 100 / float64(total.Value())
+}
+
+// format formats a metric value: a percentage for cpu and mem, a count otherwise
+func (t Threshold) format(value float64) string {
+	if t.Metric == "cpu" || t.Metric == "mem" {
+		return fmt.Sprintf("%.1f%%", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestThresholdBreaches(t *testing.T) {
+	snap := Snapshot{
+		MetricsAvailable: true,
+		Nodes: []model.NodeModel{
+			{Name: "busy", PodsCount: 50, UsageCpuQty: resource.NewMilliQuantity(1900, resource.DecimalSI), AllocatableCpuQty: resource.NewMilliQuantity(2000, resource.DecimalSI)},
+			{Name: "idle", PodsCount: 3, UsageCpuQty: resource.NewMilliQuantity(100, resource.DecimalSI), AllocatableCpuQty: resource.NewMilliQuantity(2000, resource.DecimalSI)},
+		},
+		Pods: []model.PodModel{
+			{Namespace: "default", Name: "crashing", Restarts: 7},
+			{Namespace: "default", Name: "stable"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected []string
+		err      bool
+	}{
+		{name: "node cpu breached", expr: "node.cpu>90", expected: []string{"node/busy cpu=95.0%"}},
+		{name: "node cpu not breached", expr: "node.cpu > 96", expected: nil},
+		{name: "node pods", expr: "node.pods<=3", expected: []string{"node/idle pods=3"}},
+		{name: "pod restarts", expr: "pod.restarts>=5", expected: []string{"pod/default/crashing restarts=7"}},
+		{name: "unsupported metric", expr: "pod.pods>1", err: true},
+		{name: "invalid expression", expr: "node.cpu=>90", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			threshold, err := ParseThreshold(tc.expr)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expecting an error for %q", tc.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if breaches := threshold.Breaches(snap); !reflect.DeepEqual(breaches, tc.expected) {
+				t.Fatalf("expecting breaches %v, got %v", tc.expected, breaches)
+			}
+		})
+	}
+}
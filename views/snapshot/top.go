@@ -0,0 +1,205 @@
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// TopSortCPU and TopSortMemory sort the top tables by usage, like kubectl top --sort-by
+	TopSortCPU    = "cpu"
+	TopSortMemory = "memory"
+
+	mebibyte = 1024 * 1024
+)
+
+// TopOptions are the options of the top tables
+type TopOptions struct {
+	// AllNamespaces adds the NAMESPACE column to the pods table
+	AllNamespaces bool
+	// SortBy sorts the rows by usage, decreasing: cpu or memory (default name order)
+	SortBy string
+	// NoHeaders omits the header row
+	NoHeaders bool
+}
+
+// topPeak is the peak CPU (millicores) and memory (bytes) usage of a pod or node over the samples
+type topPeak struct {
+	cpu int64
+	mem int64
+}
+
+// ValidateTopSort returns an error when sortBy is not a supported top sort
+func ValidateTopSort(sortBy string) error {
+	switch sortBy {
+	case "", TopSortCPU, TopSortMemory:
+		return nil
+	}
+	return fmt.Errorf("unsupported sort %q (cpu or memory)", sortBy)
+}
+
+// WriteTopPods writes the pods of the last sample of samples to w as kubectl top pods does:
+// NAME, CPU(cores), and MEMORY(bytes), with NAMESPACE first for all the namespaces. The usage
+// percentages of the requests (CPU%, MEMORY%), the requests, the limits, and the peak usage over
+// the samples follow.
+func WriteTopPods(w io.Writer, samples []Snapshot, opts TopOptions) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	peaks := make(map[string]topPeak)
+	for _, sample := range samples {
+		for _, pod := range sample.Pods {
+			key := pod.Namespace + "/" + pod.Name
+			peaks[key] = maxPeak(peaks[key], pod.PodUsageCpuQty, pod.PodUsageMemQty)
+		}
+	}
+
+	pods := append([]model.PodModel{}, samples[len(samples)-1].Pods...)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return lessUsage(opts.SortBy, pods[i].PodUsageCpuQty, pods[i].PodUsageMemQty, pods[j].PodUsageCpuQty, pods[j].PodUsageMemQty)
+	})
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	header := []string{"NAME", "CPU(cores)", "MEMORY(bytes)", "CPU%", "MEMORY%", "CPU-REQ", "CPU-LIM", "MEMORY-REQ", "MEMORY-LIM", "CPU-PEAK", "MEMORY-PEAK"}
+	if opts.AllNamespaces {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	if !opts.NoHeaders {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, pod := range pods {
+		peak := peaks[pod.Namespace+"/"+pod.Name]
+		row := []string{
+			pod.Name,
+			topCPU(milliValue(pod.PodUsageCpuQty)),
+			topMem(bytesValue(pod.PodUsageMemQty)),
+			topPercent(milliValue(pod.PodUsageCpuQty), milliValue(pod.PodRequestedCpuQty)),
+			topPercent(bytesValue(pod.PodUsageMemQty), bytesValue(pod.PodRequestedMemQty)),
+			topCPUOrNone(pod.PodRequestedCpuQty),
+			topCPUOrNone(pod.PodLimitCpuQty),
+			topMemOrNone(pod.PodRequestedMemQty),
+			topMemOrNone(pod.PodLimitMemQty),
+			topCPU(peak.cpu),
+			topMem(peak.mem),
+		}
+		if opts.AllNamespaces {
+			row = append([]string{pod.Namespace}, row...)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteTopNodes writes the nodes of the last sample of samples to w as kubectl top nodes does:
+// NAME, CPU(cores), CPU%, MEMORY(bytes), and MEMORY% (of the allocatable resources). The
+// requests and limits of the node pods (<none> when a node pod is not limited), and the peak
+// usage over the samples, follow.
+func WriteTopNodes(w io.Writer, samples []Snapshot, opts TopOptions) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	peaks := make(map[string]topPeak)
+	for _, sample := range samples {
+		for _, node := range sample.Nodes {
+			peaks[node.Name] = maxPeak(peaks[node.Name], node.UsageCpuQty, node.UsageMemQty)
+		}
+	}
+
+	nodes := append([]model.NodeModel{}, samples[len(samples)-1].Nodes...)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return lessUsage(opts.SortBy, nodes[i].UsageCpuQty, nodes[i].UsageMemQty, nodes[j].UsageCpuQty, nodes[j].UsageMemQty)
+	})
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	if !opts.NoHeaders {
+		fmt.Fprintln(tw, "NAME\tCPU(cores)\tCPU%\tMEMORY(bytes)\tMEMORY%\tCPU-REQ\tCPU-LIM\tMEMORY-REQ\tMEMORY-LIM\tCPU-PEAK\tMEMORY-PEAK")
+	}
+	for _, node := range nodes {
+		peak := peaks[node.Name]
+		row := []string{
+			node.Name,
+			topCPU(milliValue(node.UsageCpuQty)),
+			topPercent(milliValue(node.UsageCpuQty), milliValue(node.AllocatableCpuQty)),
+			topMem(bytesValue(node.UsageMemQty)),
+			topPercent(bytesValue(node.UsageMemQty), bytesValue(node.AllocatableMemQty)),
+			topCPU(milliValue(node.RequestedPodCpuQty)),
+			topCPUOrNone(node.LimitPodCpuQty),
+			topMem(bytesValue(node.RequestedPodMemQty)),
+			topMemOrNone(node.LimitPodMemQty),
+			topCPU(peak.cpu),
+			topMem(peak.mem),
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// maxPeak returns peak raised to the cpu and mem usage
+func maxPeak(peak topPeak, cpu, mem *resource.Quantity) topPeak {
+	if v := milliValue(cpu); v > peak.cpu {
+		peak.cpu = v
+	}
+	if v := bytesValue(mem); v > peak.mem {
+		peak.mem = v
+	}
+	return peak
+}
+
+// lessUsage returns true when the usage a sorts before the usage b, decreasing by sortBy;
+// without sortBy, the rows keep their (name) order
+func lessUsage(sortBy string, cpuA, memA, cpuB, memB *resource.Quantity) bool {
+	switch sortBy {
+	case TopSortCPU:
+		return milliValue(cpuA) > milliValue(cpuB)
+	case TopSortMemory:
+		return bytesValue(memA) > bytesValue(memB)
+	}
+	return false
+}
+
+func bytesValue(q *resource.Quantity) int64 {
+	if q == nil {
+		return 0
+	}
+	return q.Value()
+}
+
+// topCPU returns millicores as kubectl top does, i.e. 250m
+func topCPU(milli int64) string {
+	return fmt.Sprintf("%dm", milli)
+}
+
+// topMem returns bytes in mebibytes as kubectl top does, i.e. 512Mi
+func topMem(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/mebibyte)
+}
+
+// topPercent returns used as a percentage of total, i.e. 45%, or <none> without total
+func topPercent(used, total int64) string {
+	if total <= 0 {
+		return noneValue
+	}
+	return fmt.Sprintf("%d%%", used*100/total)
+}
+
+// topCPUOrNone returns the CPU quantity, or <none> when unset
+func topCPUOrNone(q *resource.Quantity) string {
+	if q == nil || q.IsZero() {
+		return noneValue
+	}
+	return topCPU(q.MilliValue())
+}
+
+// topMemOrNone returns the memory quantity, or <none> when unset
+func topMemOrNone(q *resource.Quantity) string {
+	if q == nil || q.IsZero() {
+		return noneValue
+	}
+	return topMem(q.Value())
+}
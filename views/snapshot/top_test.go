@@ -0,0 +1,174 @@
+package snapshot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vladimirvivien/ktop/views/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func topPod(name string, cpu, mem int64) model.PodModel {
+	return model.PodModel{
+		Namespace:          "default",
+		Name:               name,
+		PodUsageCpuQty:     resource.NewMilliQuantity(cpu, resource.DecimalSI),
+		PodUsageMemQty:     resource.NewQuantity(mem*mebibyte, resource.BinarySI),
+		PodRequestedCpuQty: resource.NewMilliQuantity(500, resource.DecimalSI),
+		PodRequestedMemQty: resource.NewQuantity(256*mebibyte, resource.BinarySI),
+	}
+}
+
+func TestWriteTopPods(t *testing.T) {
+	samples := []Snapshot{
+		{Pods: []model.PodModel{topPod("api-0", 400, 128), topPod("web-0", 100, 200)}},
+		{Pods: []model.PodModel{topPod("api-0", 250, 64), topPod("web-0", 300, 32)}},
+	}
+
+	tests := []struct {
+		name     string
+		opts     TopOptions
+		expected [][]string
+	}{
+		{
+			name: "name order",
+			expected: [][]string{
+				{"NAME", "CPU(cores)", "MEMORY(bytes)", "CPU%", "MEMORY%", "CPU-REQ", "CPU-LIM", "MEMORY-REQ", "MEMORY-LIM", "CPU-PEAK", "MEMORY-PEAK"},
+				{"api-0", "250m", "64Mi", "50%", "25%", "500m", "<none>", "256Mi", "<none>", "400m", "128Mi"},
+				{"web-0", "300m", "32Mi", "60%", "12%", "500m", "<none>", "256Mi", "<none>", "300m", "200Mi"},
+			},
+		},
+		{
+			name: "sort by cpu without headers",
+			opts: TopOptions{SortBy: TopSortCPU, NoHeaders: true},
+			expected: [][]string{
+				{"web-0", "300m", "32Mi", "60%", "12%", "500m", "<none>", "256Mi", "<none>", "300m", "200Mi"},
+				{"api-0", "250m", "64Mi", "50%", "25%", "500m", "<none>", "256Mi", "<none>", "400m", "128Mi"},
+			},
+		},
+		{
+			name: "all namespaces",
+			opts: TopOptions{AllNamespaces: true, SortBy: TopSortMemory, NoHeaders: true},
+			expected: [][]string{
+				{"default", "api-0", "250m", "64Mi", "50%", "25%", "500m", "<none>", "256Mi", "<none>", "400m", "128Mi"},
+				{"default", "web-0", "300m", "32Mi", "60%", "12%", "500m", "<none>", "256Mi", "<none>", "300m", "200Mi"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			var buf bytes.Buffer
+			if err := WriteTopPods(&buf, samples, tc.opts); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tc.expected) {
+				t.Fatalf("expecting %d lines, got %d:\n%s", len(tc.expected), len(lines), buf.String())
+			}
+			for i, line := range lines {
+				if fields := strings.Fields(line); strings.Join(fields, " ") != strings.Join(tc.expected[i], " ") {
+					t.Errorf("expecting line %d %v, got %v", i, tc.expected[i], fields)
+				}
+			}
+		})
+	}
+}
+
+func topNode(name string, cpu, mem int64, limited bool) model.NodeModel {
+	node := model.NodeModel{
+		Name:               name,
+		UsageCpuQty:        resource.NewMilliQuantity(cpu, resource.DecimalSI),
+		UsageMemQty:        resource.NewQuantity(mem*mebibyte, resource.BinarySI),
+		AllocatableCpuQty:  resource.NewMilliQuantity(4000, resource.DecimalSI),
+		AllocatableMemQty:  resource.NewQuantity(8192*mebibyte, resource.BinarySI),
+		RequestedPodCpuQty: resource.NewMilliQuantity(1500, resource.DecimalSI),
+		RequestedPodMemQty: resource.NewQuantity(2048*mebibyte, resource.BinarySI),
+	}
+	if limited {
+		node.LimitPodCpuQty = resource.NewMilliQuantity(3000, resource.DecimalSI)
+		node.LimitPodMemQty = resource.NewQuantity(4096*mebibyte, resource.BinarySI)
+	}
+	return node
+}
+
+func TestWriteTopNodes(t *testing.T) {
+	samples := []Snapshot{
+		{Nodes: []model.NodeModel{topNode("node-a", 2000, 1024, true), topNode("node-b", 500, 4096, false)}},
+		{Nodes: []model.NodeModel{topNode("node-a", 1000, 2048, true), topNode("node-b", 800, 3072, false)}},
+	}
+
+	tests := []struct {
+		name     string
+		opts     TopOptions
+		expected [][]string
+	}{
+		{
+			name: "name order",
+			expected: [][]string{
+				{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%", "CPU-REQ", "CPU-LIM", "MEMORY-REQ", "MEMORY-LIM", "CPU-PEAK", "MEMORY-PEAK"},
+				{"node-a", "1000m", "25%", "2048Mi", "25%", "1500m", "3000m", "2048Mi", "4096Mi", "2000m", "2048Mi"},
+				{"node-b", "800m", "20%", "3072Mi", "37%", "1500m", "<none>", "2048Mi", "<none>", "800m", "4096Mi"},
+			},
+		},
+		{
+			name: "sort by memory without headers",
+			opts: TopOptions{SortBy: TopSortMemory, NoHeaders: true},
+			expected: [][]string{
+				{"node-b", "800m", "20%", "3072Mi", "37%", "1500m", "<none>", "2048Mi", "<none>", "800m", "4096Mi"},
+				{"node-a", "1000m", "25%", "2048Mi", "25%", "1500m", "3000m", "2048Mi", "4096Mi", "2000m", "2048Mi"},
+			},
+		},
+		{
+			name: "sort by cpu",
+			opts: TopOptions{SortBy: TopSortCPU, NoHeaders: true},
+			expected: [][]string{
+				{"node-a", "1000m", "25%", "2048Mi", "25%", "1500m", "3000m", "2048Mi", "4096Mi", "2000m", "2048Mi"},
+				{"node-b", "800m", "20%", "3072Mi", "37%", "1500m", "<none>", "2048Mi", "<none>", "800m", "4096Mi"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			var buf bytes.Buffer
+			if err := WriteTopNodes(&buf, samples, tc.opts); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tc.expected) {
+				t.Fatalf("expecting %d lines, got %d:\n%s", len(tc.expected), len(lines), buf.String())
+			}
+			for i, line := range lines {
+				if fields := strings.Fields(line); strings.Join(fields, " ") != strings.Join(tc.expected[i], " ") {
+					t.Errorf("expecting line %d %v, got %v", i, tc.expected[i], fields)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTopSort(t *testing.T) {
+	tests := []struct {
+		name   string
+		sortBy string
+		err    bool
+	}{
+		{name: "default", sortBy: ""},
+		{name: "cpu", sortBy: TopSortCPU},
+		{name: "memory", sortBy: TopSortMemory},
+		{name: "unsupported", sortBy: "name", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("running test %s", tc.name)
+			if err := ValidateTopSort(tc.sortBy); (err != nil) != tc.err {
+				t.Errorf("expecting error %t, got %v", tc.err, err)
+			}
+		})
+	}
+}